@@ -73,11 +73,11 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.KustomizeBuildOptions = src.Spec.KustomizeBuildOptions
 	dst.Spec.KustomizeVersions = ConvertAlphaToBetaKustomizeVersions(src.Spec.KustomizeVersions)
 	dst.Spec.OIDCConfig = src.Spec.OIDCConfig
-	dst.Spec.Monitoring = v1beta1.ArgoCDMonitoringSpec(src.Spec.Monitoring)
-	dst.Spec.NodePlacement = (*v1beta1.ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
-	dst.Spec.Notifications = v1beta1.ArgoCDNotifications(src.Spec.Notifications)
+	dst.Spec.Monitoring = *ConvertAlphaToBetaMonitoring(&src.Spec.Monitoring)
+	dst.Spec.NodePlacement = ConvertAlphaToBetaNodePlacement(src.Spec.NodePlacement)
+	dst.Spec.Notifications = *ConvertAlphaToBetaNotifications(&src.Spec.Notifications)
 	dst.Spec.Prometheus = *ConvertAlphaToBetaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = v1beta1.ArgoCDRBACSpec(src.Spec.RBAC)
+	dst.Spec.RBAC = *ConvertAlphaToBetaRBAC(&src.Spec.RBAC)
 	dst.Spec.Redis = *ConvertAlphaToBetaRedis(&src.Spec.Redis)
 	dst.Spec.Repo = *ConvertAlphaToBetaRepo(&src.Spec.Repo)
 	dst.Spec.RepositoryCredentials = src.Spec.RepositoryCredentials
@@ -93,12 +93,12 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.TLS = *ConvertAlphaToBetaTLS(&src.Spec.TLS)
 	dst.Spec.UsersAnonymousEnabled = src.Spec.UsersAnonymousEnabled
 	dst.Spec.Version = src.Spec.Version
-	dst.Spec.Banner = (*v1beta1.Banner)(src.Spec.Banner)
+	dst.Spec.Banner = ConvertAlphaToBetaBanner(src.Spec.Banner)
 	dst.Spec.DefaultClusterScopedRoleDisabled = src.Spec.DefaultClusterScopedRoleDisabled
 	dst.Spec.AggregatedClusterRoles = src.Spec.AggregatedClusterRoles
 
 	// Status conversion
-	dst.Status = v1beta1.ArgoCDStatus(src.Status)
+	dst.Status = *ConvertAlphaToBetaStatus(&src.Status)
 
 	return nil
 }
@@ -143,11 +143,11 @@ func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.KustomizeBuildOptions = src.Spec.KustomizeBuildOptions
 	dst.Spec.KustomizeVersions = ConvertBetaToAlphaKustomizeVersions(src.Spec.KustomizeVersions)
 	dst.Spec.OIDCConfig = src.Spec.OIDCConfig
-	dst.Spec.Monitoring = ArgoCDMonitoringSpec(src.Spec.Monitoring)
-	dst.Spec.NodePlacement = (*ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
-	dst.Spec.Notifications = ArgoCDNotifications(src.Spec.Notifications)
+	dst.Spec.Monitoring = *ConvertBetaToAlphaMonitoring(&src.Spec.Monitoring)
+	dst.Spec.NodePlacement = ConvertBetaToAlphaNodePlacement(src.Spec.NodePlacement)
+	dst.Spec.Notifications = *ConvertBetaToAlphaNotifications(&src.Spec.Notifications)
 	dst.Spec.Prometheus = *ConvertBetaToAlphaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = ArgoCDRBACSpec(src.Spec.RBAC)
+	dst.Spec.RBAC = *ConvertBetaToAlphaRBAC(&src.Spec.RBAC)
 	dst.Spec.Redis = *ConvertBetaToAlphaRedis(&src.Spec.Redis)
 	dst.Spec.Repo = *ConvertBetaToAlphaRepo(&src.Spec.Repo)
 	dst.Spec.RepositoryCredentials = src.Spec.RepositoryCredentials
@@ -163,12 +163,12 @@ func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.TLS = *ConvertBetaToAlphaTLS(&src.Spec.TLS)
 	dst.Spec.UsersAnonymousEnabled = src.Spec.UsersAnonymousEnabled
 	dst.Spec.Version = src.Spec.Version
-	dst.Spec.Banner = (*Banner)(src.Spec.Banner)
+	dst.Spec.Banner = ConvertBetaToAlphaBanner(src.Spec.Banner)
 	dst.Spec.DefaultClusterScopedRoleDisabled = src.Spec.DefaultClusterScopedRoleDisabled
 	dst.Spec.AggregatedClusterRoles = src.Spec.AggregatedClusterRoles
 
 	// Status conversion
-	dst.Status = ArgoCDStatus(src.Status)
+	dst.Status = *ConvertBetaToAlphaStatus(&src.Status)
 
 	return nil
 }
@@ -205,6 +205,33 @@ func ConvertAlphaToBetaRedis(src *ArgoCDRedisSpec) *v1beta1.ArgoCDRedisSpec {
 	return dst
 }
 
+func ConvertAlphaToBetaNodePlacement(src *ArgoCDNodePlacementSpec) *v1beta1.ArgoCDNodePlacementSpec {
+	var dst *v1beta1.ArgoCDNodePlacementSpec
+	if src != nil {
+		dst = &v1beta1.ArgoCDNodePlacementSpec{
+			NodeSelector: src.NodeSelector,
+			Tolerations:  src.Tolerations,
+		}
+	}
+	return dst
+}
+
+func ConvertAlphaToBetaNotifications(src *ArgoCDNotifications) *v1beta1.ArgoCDNotifications {
+	var dst *v1beta1.ArgoCDNotifications
+	if src != nil {
+		dst = &v1beta1.ArgoCDNotifications{
+			Replicas:  src.Replicas,
+			Enabled:   src.Enabled,
+			Env:       src.Env,
+			Image:     src.Image,
+			Version:   src.Version,
+			Resources: src.Resources,
+			LogLevel:  src.LogLevel,
+		}
+	}
+	return dst
+}
+
 func ConvertAlphaToBetaRepo(src *ArgoCDRepoSpec) *v1beta1.ArgoCDRepoSpec {
 	var dst *v1beta1.ArgoCDRepoSpec
 	if src != nil {
@@ -296,7 +323,22 @@ func ConvertAlphaToBetaSSO(src *ArgoCDSSOSpec) *v1beta1.ArgoCDSSOSpec {
 		dst = &v1beta1.ArgoCDSSOSpec{
 			Provider: v1beta1.SSOProviderType(src.Provider),
 			Dex:      ConvertAlphaToBetaDex(src.Dex),
-			Keycloak: (*v1beta1.ArgoCDKeycloakSpec)(src.Keycloak),
+			Keycloak: ConvertAlphaToBetaKeycloak(src.Keycloak),
+		}
+	}
+	return dst
+}
+
+func ConvertAlphaToBetaKeycloak(src *ArgoCDKeycloakSpec) *v1beta1.ArgoCDKeycloakSpec {
+	var dst *v1beta1.ArgoCDKeycloakSpec
+	if src != nil {
+		dst = &v1beta1.ArgoCDKeycloakSpec{
+			Image:     src.Image,
+			Resources: src.Resources,
+			RootCA:    src.RootCA,
+			Version:   src.Version,
+			VerifyTLS: src.VerifyTLS,
+			Host:      src.Host,
 		}
 	}
 	return dst
@@ -437,6 +479,61 @@ func ConvertAlphaToBetaResourceHealthChecks(src []ResourceHealthCheck) []v1beta1
 	return dst
 }
 
+func ConvertAlphaToBetaRBAC(src *ArgoCDRBACSpec) *v1beta1.ArgoCDRBACSpec {
+	var dst *v1beta1.ArgoCDRBACSpec
+	if src != nil {
+		dst = &v1beta1.ArgoCDRBACSpec{
+			DefaultPolicy:     src.DefaultPolicy,
+			Policy:            src.Policy,
+			Scopes:            src.Scopes,
+			PolicyMatcherMode: src.PolicyMatcherMode,
+		}
+	}
+	return dst
+}
+
+func ConvertAlphaToBetaMonitoring(src *ArgoCDMonitoringSpec) *v1beta1.ArgoCDMonitoringSpec {
+	var dst *v1beta1.ArgoCDMonitoringSpec
+	if src != nil {
+		dst = &v1beta1.ArgoCDMonitoringSpec{
+			Enabled:        src.Enabled,
+			DisableMetrics: src.DisableMetrics,
+		}
+	}
+	return dst
+}
+
+func ConvertAlphaToBetaBanner(src *Banner) *v1beta1.Banner {
+	var dst *v1beta1.Banner
+	if src != nil {
+		dst = &v1beta1.Banner{
+			Content: src.Content,
+			URL:     src.URL,
+		}
+	}
+	return dst
+}
+
+func ConvertAlphaToBetaStatus(src *ArgoCDStatus) *v1beta1.ArgoCDStatus {
+	var dst *v1beta1.ArgoCDStatus
+	if src != nil {
+		dst = &v1beta1.ArgoCDStatus{
+			ApplicationController:    src.ApplicationController,
+			ApplicationSetController: src.ApplicationSetController,
+			SSO:                      src.SSO,
+			NotificationsController:  src.NotificationsController,
+			Phase:                    src.Phase,
+			Redis:                    src.Redis,
+			Repo:                     src.Repo,
+			Server:                   src.Server,
+			RepoTLSChecksum:          src.RepoTLSChecksum,
+			RedisTLSChecksum:         src.RedisTLSChecksum,
+			Host:                     src.Host,
+		}
+	}
+	return dst
+}
+
 // Conversion funcs for v1beta1 to v1alpha1.
 func ConvertBetaToAlphaController(src *v1beta1.ArgoCDApplicationControllerSpec) *ArgoCDApplicationControllerSpec {
 	var dst *ArgoCDApplicationControllerSpec
@@ -520,7 +617,22 @@ func ConvertBetaToAlphaSSO(src *v1beta1.ArgoCDSSOSpec) *ArgoCDSSOSpec {
 		dst = &ArgoCDSSOSpec{
 			Provider: SSOProviderType(src.Provider),
 			Dex:      ConvertBetaToAlphaDex(src.Dex),
-			Keycloak: (*ArgoCDKeycloakSpec)(src.Keycloak),
+			Keycloak: ConvertBetaToAlphaKeycloak(src.Keycloak),
+		}
+	}
+	return dst
+}
+
+func ConvertBetaToAlphaKeycloak(src *v1beta1.ArgoCDKeycloakSpec) *ArgoCDKeycloakSpec {
+	var dst *ArgoCDKeycloakSpec
+	if src != nil {
+		dst = &ArgoCDKeycloakSpec{
+			Image:     src.Image,
+			Resources: src.Resources,
+			RootCA:    src.RootCA,
+			Version:   src.Version,
+			VerifyTLS: src.VerifyTLS,
+			Host:      src.Host,
 		}
 	}
 	return dst
@@ -674,6 +786,33 @@ func ConvertBetaToAlphaRedis(src *v1beta1.ArgoCDRedisSpec) *ArgoCDRedisSpec {
 	return dst
 }
 
+func ConvertBetaToAlphaNodePlacement(src *v1beta1.ArgoCDNodePlacementSpec) *ArgoCDNodePlacementSpec {
+	var dst *ArgoCDNodePlacementSpec
+	if src != nil {
+		dst = &ArgoCDNodePlacementSpec{
+			NodeSelector: src.NodeSelector,
+			Tolerations:  src.Tolerations,
+		}
+	}
+	return dst
+}
+
+func ConvertBetaToAlphaNotifications(src *v1beta1.ArgoCDNotifications) *ArgoCDNotifications {
+	var dst *ArgoCDNotifications
+	if src != nil {
+		dst = &ArgoCDNotifications{
+			Replicas:  src.Replicas,
+			Enabled:   src.Enabled,
+			Env:       src.Env,
+			Image:     src.Image,
+			Version:   src.Version,
+			Resources: src.Resources,
+			LogLevel:  src.LogLevel,
+		}
+	}
+	return dst
+}
+
 func ConvertBetaToAlphaRepo(src *v1beta1.ArgoCDRepoSpec) *ArgoCDRepoSpec {
 	var dst *ArgoCDRepoSpec
 	if src != nil {
@@ -699,3 +838,58 @@ func ConvertBetaToAlphaRepo(src *v1beta1.ArgoCDRepoSpec) *ArgoCDRepoSpec {
 	}
 	return dst
 }
+
+func ConvertBetaToAlphaRBAC(src *v1beta1.ArgoCDRBACSpec) *ArgoCDRBACSpec {
+	var dst *ArgoCDRBACSpec
+	if src != nil {
+		dst = &ArgoCDRBACSpec{
+			DefaultPolicy:     src.DefaultPolicy,
+			Policy:            src.Policy,
+			Scopes:            src.Scopes,
+			PolicyMatcherMode: src.PolicyMatcherMode,
+		}
+	}
+	return dst
+}
+
+func ConvertBetaToAlphaMonitoring(src *v1beta1.ArgoCDMonitoringSpec) *ArgoCDMonitoringSpec {
+	var dst *ArgoCDMonitoringSpec
+	if src != nil {
+		dst = &ArgoCDMonitoringSpec{
+			Enabled:        src.Enabled,
+			DisableMetrics: src.DisableMetrics,
+		}
+	}
+	return dst
+}
+
+func ConvertBetaToAlphaBanner(src *v1beta1.Banner) *Banner {
+	var dst *Banner
+	if src != nil {
+		dst = &Banner{
+			Content: src.Content,
+			URL:     src.URL,
+		}
+	}
+	return dst
+}
+
+func ConvertBetaToAlphaStatus(src *v1beta1.ArgoCDStatus) *ArgoCDStatus {
+	var dst *ArgoCDStatus
+	if src != nil {
+		dst = &ArgoCDStatus{
+			ApplicationController:    src.ApplicationController,
+			ApplicationSetController: src.ApplicationSetController,
+			SSO:                      src.SSO,
+			NotificationsController:  src.NotificationsController,
+			Phase:                    src.Phase,
+			Redis:                    src.Redis,
+			Repo:                     src.Repo,
+			Server:                   src.Server,
+			RepoTLSChecksum:          src.RepoTLSChecksum,
+			RedisTLSChecksum:         src.RedisTLSChecksum,
+			Host:                     src.Host,
+		}
+	}
+	return dst
+}
@@ -107,6 +107,11 @@ type ArgoCDApplicationControllerSpec struct {
 	// +optional
 	AppSync *metav1.Duration `json:"appSync,omitempty"`
 
+	// AppSyncJitter is the maximum random delay added to AppSync, to spread the reconciliations of the applications over
+	// time. It is passed to the application controller as ARGOCD_RECONCILIATION_JITTER.
+	// +optional
+	AppSyncJitter *metav1.Duration `json:"appSyncJitter,omitempty"`
+
 	// Sharding contains the options for the Application Controller sharding configuration.
 	Sharding ArgoCDApplicationControllerShardSpec `json:"sharding,omitempty"`
 
@@ -134,6 +139,13 @@ type ArgoCDApplicationControllerSpec struct {
 
 	// Custom labels to pods deployed by the operator
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Patches are applied in order to the StatefulSet of the Argo CD Application Controller, after it is rendered by the operator.
+	Patches []ArgoCDResourcePatch `json:"patches,omitempty"`
+
+	// Scheduling defines the scheduling options of the pods of the StatefulSet of the Argo CD Application Controller. They take precedence over
+	// the scheduling options in .spec.nodePlacement.
+	Scheduling *ArgoCDSchedulingSpec `json:"scheduling,omitempty"`
 }
 
 func (a *ArgoCDApplicationControllerSpec) IsEnabled() bool {
@@ -213,6 +225,38 @@ type ArgoCDApplicationSet struct {
 
 	// VolumeMounts adds volumeMounts to the Argo CD ApplicationSet Controller container.
 	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Patches are applied in order to the Deployment of the ApplicationSet controller, after it is rendered by the operator.
+	Patches []ArgoCDResourcePatch `json:"patches,omitempty"`
+
+	// Scheduling defines the scheduling options of the pods of the Deployment of the ApplicationSet controller. They take precedence over
+	// the scheduling options in .spec.nodePlacement.
+	Scheduling *ArgoCDSchedulingSpec `json:"scheduling,omitempty"`
+
+	// Replicas defines the number of replicas of the ApplicationSet controller. Value should be greater than or equal to 0. Default is nil.
+	// Leader election is enabled when more than one replica is requested, so that a single replica reconciles the ApplicationSets.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// EnableProgressiveSyncs enables the progressive syncs of the ApplicationSets, i.e. their rollout strategies.
+	EnableProgressiveSyncs bool `json:"enableProgressiveSyncs,omitempty"`
+
+	// Policy restricts how the ApplicationSet controller modifies the generated Applications. Valid options are sync
+	// (create, update and delete), create-only, create-update (no deletion) and create-delete (no update). Default is sync.
+	// +kubebuilder:validation:Enum=sync;create-only;create-update;create-delete
+	Policy string `json:"policy,omitempty"`
+
+	// EnablePolicyOverride allows the ApplicationSets to override Policy with their own sync policy. Defaults to true when
+	// Policy is not set, and to false otherwise.
+	EnablePolicyOverride *bool `json:"enablePolicyOverride,omitempty"`
+
+	// ConcurrentReconciliations is the maximum number of ApplicationSets reconciled concurrently. Default is 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	ConcurrentReconciliations *int32 `json:"concurrentReconciliations,omitempty"`
+
+	// DryRun runs the ApplicationSet controller in dry-run mode: the generated Applications are logged instead of being
+	// created, updated or deleted.
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 func (a *ArgoCDApplicationSet) IsEnabled() bool {
@@ -240,6 +284,10 @@ type ArgoCDDexSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Configuration",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Dex","urn:alm:descriptor:com.tectonic.ui:text"}
 	Config string `json:"config,omitempty"`
 
+	// Connectors is a list of typed Dex connectors rendered into the dex configuration. Secret values are read from
+	// Secrets in the namespace of the Argo CD instance and copied into the argocd-secret.
+	Connectors []ArgoCDDexConnectorSpec `json:"connectors,omitempty"`
+
 	// Optional list of required groups a user must be a member of
 	Groups []string `json:"groups,omitempty"`
 
@@ -261,6 +309,275 @@ type ArgoCDDexSpec struct {
 
 	// Env lets you specify environment variables for Dex.
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Patches are applied in order to the Deployment of Dex, after it is rendered by the operator.
+	Patches []ArgoCDResourcePatch `json:"patches,omitempty"`
+
+	// Scheduling defines the scheduling options of the pods of the Deployment of Dex. They take precedence over
+	// the scheduling options in .spec.nodePlacement.
+	Scheduling *ArgoCDSchedulingSpec `json:"scheduling,omitempty"`
+}
+
+// DexConnectorType is the type of a Dex connector.
+type DexConnectorType string
+
+const (
+	DexConnectorTypeGitHub    DexConnectorType = "github"
+	DexConnectorTypeGitLab    DexConnectorType = "gitlab"
+	DexConnectorTypeLDAP      DexConnectorType = "ldap"
+	DexConnectorTypeOIDC      DexConnectorType = "oidc"
+	DexConnectorTypeSAML      DexConnectorType = "saml"
+	DexConnectorTypeMicrosoft DexConnectorType = "microsoft"
+	DexConnectorTypeOpenShift DexConnectorType = "openshift"
+)
+
+// ArgoCDDexConnectorSpec defines a typed Dex connector. The field matching Type holds the connector configuration.
+type ArgoCDDexConnectorSpec struct {
+	// ID is the unique identifier of the connector.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	ID string `json:"id"`
+
+	// Name is the name of the connector shown on the login page. Defaults to the ID.
+	Name string `json:"name,omitempty"`
+
+	// Type is the type of the connector.
+	// +kubebuilder:validation:Enum=github;gitlab;ldap;oidc;saml;microsoft;openshift
+	Type DexConnectorType `json:"type"`
+
+	// GitHub is the configuration of a github connector.
+	GitHub *ArgoCDDexGitHubConnectorSpec `json:"github,omitempty"`
+
+	// GitLab is the configuration of a gitlab connector.
+	GitLab *ArgoCDDexGitLabConnectorSpec `json:"gitlab,omitempty"`
+
+	// LDAP is the configuration of an ldap connector.
+	LDAP *ArgoCDDexLDAPConnectorSpec `json:"ldap,omitempty"`
+
+	// OIDC is the configuration of an oidc connector.
+	OIDC *ArgoCDDexOIDCConnectorSpec `json:"oidc,omitempty"`
+
+	// SAML is the configuration of a saml connector.
+	SAML *ArgoCDDexSAMLConnectorSpec `json:"saml,omitempty"`
+
+	// Microsoft is the configuration of a microsoft connector.
+	Microsoft *ArgoCDDexMicrosoftConnectorSpec `json:"microsoft,omitempty"`
+
+	// OpenShift is the configuration of an openshift connector using the Dex ServiceAccount as OAuth client.
+	OpenShift *ArgoCDDexOpenShiftConnectorSpec `json:"openshift,omitempty"`
+}
+
+// ArgoCDDexGitHubOrg defines a GitHub organization and the teams of it a user must be a member of.
+type ArgoCDDexGitHubOrg struct {
+	// Name of the organization.
+	Name string `json:"name"`
+
+	// Teams of the organization. All teams are allowed when empty.
+	Teams []string `json:"teams,omitempty"`
+}
+
+// ArgoCDDexGitHubConnectorSpec defines the configuration of a Dex github connector.
+type ArgoCDDexGitHubConnectorSpec struct {
+	// ClientID is the OAuth client ID.
+	ClientID string `json:"clientID"`
+
+	// ClientSecret references the key of a Secret holding the OAuth client secret.
+	ClientSecret *corev1.SecretKeySelector `json:"clientSecret"`
+
+	// HostName of a GitHub Enterprise instance.
+	HostName string `json:"hostName,omitempty"`
+
+	// Orgs restricts login to members of the given organizations.
+	Orgs []ArgoCDDexGitHubOrg `json:"orgs,omitempty"`
+
+	// LoadAllGroups loads all the organizations and teams of a user as groups.
+	LoadAllGroups bool `json:"loadAllGroups,omitempty"`
+
+	// TeamNameField is the team field used in group names, one of name, slug or both.
+	// +kubebuilder:validation:Enum=name;slug;both
+	TeamNameField string `json:"teamNameField,omitempty"`
+
+	// UseLoginAsID uses the GitHub login instead of the user ID as identifier.
+	UseLoginAsID bool `json:"useLoginAsID,omitempty"`
+}
+
+// ArgoCDDexGitLabConnectorSpec defines the configuration of a Dex gitlab connector.
+type ArgoCDDexGitLabConnectorSpec struct {
+	// BaseURL of the GitLab instance. Defaults to https://gitlab.com.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// ClientID is the OAuth application ID.
+	ClientID string `json:"clientID"`
+
+	// ClientSecret references the key of a Secret holding the OAuth application secret.
+	ClientSecret *corev1.SecretKeySelector `json:"clientSecret"`
+
+	// Groups restricts login to members of the given groups.
+	Groups []string `json:"groups,omitempty"`
+
+	// UseLoginAsID uses the GitLab username instead of the user ID as identifier.
+	UseLoginAsID bool `json:"useLoginAsID,omitempty"`
+}
+
+// ArgoCDDexLDAPUserSearch defines how users are looked up in LDAP.
+type ArgoCDDexLDAPUserSearch struct {
+	// BaseDN to start the search from.
+	BaseDN string `json:"baseDN"`
+
+	// Filter applied to the search.
+	Filter string `json:"filter,omitempty"`
+
+	// Username is the attribute matched against the username entered by the user.
+	Username string `json:"username"`
+
+	// IDAttr is the attribute used as user ID.
+	IDAttr string `json:"idAttr"`
+
+	// EmailAttr is the attribute used as email.
+	EmailAttr string `json:"emailAttr"`
+
+	// NameAttr is the attribute used as display name.
+	NameAttr string `json:"nameAttr,omitempty"`
+
+	// PreferredUsernameAttr is the attribute used as preferred username.
+	PreferredUsernameAttr string `json:"preferredUsernameAttr,omitempty"`
+}
+
+// ArgoCDDexLDAPUserMatcher defines how a user entry is matched to a group entry.
+type ArgoCDDexLDAPUserMatcher struct {
+	// UserAttr is the attribute of the user entry.
+	UserAttr string `json:"userAttr"`
+
+	// GroupAttr is the attribute of the group entry.
+	GroupAttr string `json:"groupAttr"`
+}
+
+// ArgoCDDexLDAPGroupSearch defines how groups of a user are looked up in LDAP.
+type ArgoCDDexLDAPGroupSearch struct {
+	// BaseDN to start the search from.
+	BaseDN string `json:"baseDN"`
+
+	// Filter applied to the search.
+	Filter string `json:"filter,omitempty"`
+
+	// UserMatchers define how users are matched to groups.
+	UserMatchers []ArgoCDDexLDAPUserMatcher `json:"userMatchers"`
+
+	// NameAttr is the attribute used as group name.
+	NameAttr string `json:"nameAttr"`
+}
+
+// ArgoCDDexLDAPConnectorSpec defines the configuration of a Dex ldap connector.
+type ArgoCDDexLDAPConnectorSpec struct {
+	// Host and optional port of the LDAP server.
+	Host string `json:"host"`
+
+	// InsecureNoSSL connects to the LDAP server without TLS.
+	InsecureNoSSL bool `json:"insecureNoSSL,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the LDAP server certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// StartTLS connects without TLS and upgrades the connection with StartTLS.
+	StartTLS bool `json:"startTLS,omitempty"`
+
+	// RootCA is the PEM encoded certificate of the CA that signed the LDAP server certificate.
+	RootCA string `json:"rootCA,omitempty"`
+
+	// BindDN is the DN used to search users and groups. Anonymous search is used when empty.
+	BindDN string `json:"bindDN,omitempty"`
+
+	// BindPW references the key of a Secret holding the password of the BindDN.
+	BindPW *corev1.SecretKeySelector `json:"bindPW,omitempty"`
+
+	// UsernamePrompt is the label of the username field on the login page.
+	UsernamePrompt string `json:"usernamePrompt,omitempty"`
+
+	// UserSearch defines how users are looked up.
+	UserSearch ArgoCDDexLDAPUserSearch `json:"userSearch"`
+
+	// GroupSearch defines how the groups of a user are looked up.
+	GroupSearch *ArgoCDDexLDAPGroupSearch `json:"groupSearch,omitempty"`
+}
+
+// ArgoCDDexOIDCConnectorSpec defines the configuration of a Dex oidc connector.
+type ArgoCDDexOIDCConnectorSpec struct {
+	// Issuer is the URL of the OpenID Connect provider.
+	Issuer string `json:"issuer"`
+
+	// ClientID is the OAuth client ID.
+	ClientID string `json:"clientID"`
+
+	// ClientSecret references the key of a Secret holding the OAuth client secret.
+	ClientSecret *corev1.SecretKeySelector `json:"clientSecret"`
+
+	// Scopes requested in addition to openid.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// InsecureSkipEmailVerified accepts users whose email is not verified.
+	InsecureSkipEmailVerified bool `json:"insecureSkipEmailVerified,omitempty"`
+
+	// InsecureEnableGroups reads the groups of a user from the groups claim.
+	InsecureEnableGroups bool `json:"insecureEnableGroups,omitempty"`
+
+	// GetUserInfo queries the userinfo endpoint for additional claims.
+	GetUserInfo bool `json:"getUserInfo,omitempty"`
+
+	// UserNameKey is the claim used as username. Defaults to name.
+	UserNameKey string `json:"userNameKey,omitempty"`
+}
+
+// ArgoCDDexSAMLConnectorSpec defines the configuration of a Dex saml connector.
+type ArgoCDDexSAMLConnectorSpec struct {
+	// SSOURL is the URL of the identity provider where users are redirected to log in.
+	SSOURL string `json:"ssoURL"`
+
+	// CA is the PEM encoded certificate used to validate the signature of the SAML response.
+	CA string `json:"ca,omitempty"`
+
+	// EntityIssuer is the issuer sent in the authentication request.
+	EntityIssuer string `json:"entityIssuer,omitempty"`
+
+	// SSOIssuer is the issuer expected in the SAML response.
+	SSOIssuer string `json:"ssoIssuer,omitempty"`
+
+	// UsernameAttr is the attribute used as username.
+	UsernameAttr string `json:"usernameAttr"`
+
+	// EmailAttr is the attribute used as email.
+	EmailAttr string `json:"emailAttr"`
+
+	// GroupsAttr is the attribute used as groups.
+	GroupsAttr string `json:"groupsAttr,omitempty"`
+
+	// NameIDPolicyFormat is the NameID format requested from the identity provider.
+	NameIDPolicyFormat string `json:"nameIDPolicyFormat,omitempty"`
+
+	// InsecureSkipSignatureValidation disables the validation of the signature of the SAML response.
+	InsecureSkipSignatureValidation bool `json:"insecureSkipSignatureValidation,omitempty"`
+}
+
+// ArgoCDDexMicrosoftConnectorSpec defines the configuration of a Dex microsoft connector.
+type ArgoCDDexMicrosoftConnectorSpec struct {
+	// ClientID is the application ID.
+	ClientID string `json:"clientID"`
+
+	// ClientSecret references the key of a Secret holding the application secret.
+	ClientSecret *corev1.SecretKeySelector `json:"clientSecret"`
+
+	// Tenant is the tenant ID or name. Defaults to common.
+	Tenant string `json:"tenant,omitempty"`
+
+	// Groups restricts login to members of the given groups.
+	Groups []string `json:"groups,omitempty"`
+
+	// OnlySecurityGroups only loads the security groups of a user.
+	OnlySecurityGroups bool `json:"onlySecurityGroups,omitempty"`
+}
+
+// ArgoCDDexOpenShiftConnectorSpec defines the configuration of a Dex openshift connector.
+type ArgoCDDexOpenShiftConnectorSpec struct {
+	// Groups restricts login to members of the given groups.
+	Groups []string `json:"groups,omitempty"`
 }
 
 // ArgoCDGrafanaSpec defines the desired state for the Grafana component.
@@ -310,6 +627,12 @@ type ArgoCDHASpec struct {
 
 	// Resources defines the Compute Resources required by the container for HA.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// RedisProxyPatches are applied in order to the Deployment of the Redis HAProxy, after it is rendered by the operator.
+	RedisProxyPatches []ArgoCDResourcePatch `json:"redisProxyPatches,omitempty"`
+
+	// RedisPatches are applied in order to the StatefulSet of the Redis HA servers, after it is rendered by the operator.
+	RedisPatches []ArgoCDResourcePatch `json:"redisPatches,omitempty"`
 }
 
 // ArgoCDImportSpec defines the desired state for the ArgoCD import/restore process.
@@ -366,6 +689,47 @@ type ArgoCDKeycloakSpec struct {
 
 	// Host is the hostname to use for Ingress/Route resources.
 	Host string `json:"host,omitempty"`
+
+	// Mode selects the Keycloak distribution managed by the operator, or an external Keycloak. Defaults to legacy.
+	// +kubebuilder:validation:Enum=legacy;quarkus;external
+	Mode KeycloakMode `json:"mode,omitempty"`
+
+	// AdminSecret is the name of the Secret holding the Keycloak admin credentials in the `username` and `password` keys.
+	// The Secret is generated when it does not exist. Only used in quarkus mode.
+	AdminSecret string `json:"adminSecret,omitempty"`
+
+	// Database configures an external database for Keycloak. Only used in quarkus mode.
+	Database *ArgoCDKeycloakDatabaseSpec `json:"database,omitempty"`
+
+	// External configures the existing Keycloak realm used in external mode.
+	External *ArgoCDKeycloakExternalSpec `json:"external,omitempty"`
+
+	// Patches are applied in order to the Deployment of Keycloak, after it is rendered by the operator. They are not
+	// applied to the DeploymentConfig created from the OpenShift template in legacy mode.
+	Patches []ArgoCDResourcePatch `json:"patches,omitempty"`
+}
+
+// ArgoCDKeycloakDatabaseSpec defines the external database used by Keycloak.
+type ArgoCDKeycloakDatabaseSpec struct {
+	// Vendor is the database vendor, passed to Keycloak as KC_DB.
+	// +kubebuilder:validation:Enum=postgres;mysql;mariadb;mssql;oracle
+	Vendor string `json:"vendor"`
+
+	// SecretName is the name of the Secret holding the database connection in the `url`, `username` and `password` keys.
+	SecretName string `json:"secretName"`
+}
+
+// ArgoCDKeycloakExternalSpec defines an existing Keycloak realm used for Argo CD SSO.
+type ArgoCDKeycloakExternalSpec struct {
+	// URL is the base URL of the Keycloak server including its context path, if any. e.g. https://sso.example.com or https://sso.example.com/auth
+	URL string `json:"url"`
+
+	// Realm is the name of the existing realm in which the argocd client is managed.
+	Realm string `json:"realm"`
+
+	// CredentialsSecret is the name of the Secret holding the `clientID` and `clientSecret` keys of a service account
+	// client of the realm that is allowed to manage clients.
+	CredentialsSecret string `json:"credentialsSecret"`
 }
 
 //+kubebuilder:object:root=true
@@ -400,6 +764,13 @@ type ArgoCDNotifications struct {
 
 	// LogLevel describes the log level that should be used by the argocd-notifications. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
+
+	// Patches are applied in order to the Deployment of the notifications controller, after it is rendered by the operator.
+	Patches []ArgoCDResourcePatch `json:"patches,omitempty"`
+
+	// Scheduling defines the scheduling options of the pods of the Deployment of the notifications controller. They take precedence over
+	// the scheduling options in .spec.nodePlacement.
+	Scheduling *ArgoCDSchedulingSpec `json:"scheduling,omitempty"`
 }
 
 // ArgoCDPrometheusSpec defines the desired state for the Prometheus component.
@@ -448,6 +819,54 @@ type ArgoCDRBACSpec struct {
 	// PolicyMatcherMode configures the matchers function mode for casbin.
 	// There are two options for this, 'glob' for glob matcher or 'regex' for regex matcher.
 	PolicyMatcherMode *string `json:"policyMatcherMode,omitempty"`
+
+	// Roles are typed role definitions rendered into the policy.spec.csv key of the argocd-rbac-cm ConfigMap.
+	Roles []ArgoCDRBACRole `json:"roles,omitempty"`
+
+	// Bindings assign users and groups to roles. Bindings are rendered into the policy.spec.csv key of the
+	// argocd-rbac-cm ConfigMap.
+	Bindings []ArgoCDRBACBinding `json:"bindings,omitempty"`
+
+	// PolicyFragmentSelector selects ConfigMaps in the namespace of the Argo CD instance whose policy.csv key is
+	// merged into the argocd-rbac-cm ConfigMap as policy.<configmap name>.csv. Invalid fragments are skipped and
+	// reported in the RBACPolicyValid condition.
+	PolicyFragmentSelector *metav1.LabelSelector `json:"policyFragmentSelector,omitempty"`
+}
+
+// ArgoCDRBACRole defines a role and the permissions granted or denied by it.
+type ArgoCDRBACRole struct {
+	// Name of the role. The role is referenced as role:<name> in policies.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.-]+$`
+	Name string `json:"name"`
+
+	// Policies are the permissions of the role.
+	Policies []ArgoCDRBACPolicy `json:"policies,omitempty"`
+}
+
+// ArgoCDRBACPolicy defines a permission of a role.
+type ArgoCDRBACPolicy struct {
+	// Resource the permission applies to.
+	// +kubebuilder:validation:Enum=applications;applicationsets;clusters;projects;repositories;accounts;certificates;gpgkeys;logs;exec;extensions;"*"
+	Resource string `json:"resource"`
+
+	// Action the permission applies to, e.g. get, create, update, delete, sync, override or action/<group/kind/action>.
+	Action string `json:"action"`
+
+	// Object the permission applies to, e.g. <project>/<application>. Defaults to *.
+	Object string `json:"object,omitempty"`
+
+	// Effect of the permission. Defaults to allow.
+	// +kubebuilder:validation:Enum=allow;deny
+	Effect string `json:"effect,omitempty"`
+}
+
+// ArgoCDRBACBinding assigns users and groups to a role.
+type ArgoCDRBACBinding struct {
+	// Role is the name of the role, either one of the roles of the spec or a built-in role such as admin or readonly.
+	Role string `json:"role"`
+
+	// Subjects are the users and groups assigned to the role.
+	Subjects []string `json:"subjects"`
 }
 
 // ArgoCDRedisSpec defines the desired state for the Redis server component.
@@ -477,6 +896,14 @@ type ArgoCDRedisSpec struct {
 
 	// Remote specifies the remote URL of the Redis container. (optional, by default, a local instance managed by the operator is used.)
 	Remote *string `json:"remote,omitempty"`
+
+	// Patches are applied in order to the Deployment of Redis, after it is rendered by the operator. The Redis HA
+	// workloads are patched with .spec.ha.redisProxyPatches and .spec.ha.redisPatches instead.
+	Patches []ArgoCDResourcePatch `json:"patches,omitempty"`
+
+	// Scheduling defines the scheduling options of the pods of the Redis workloads, including the Redis HA workloads. They take precedence over
+	// the scheduling options in .spec.nodePlacement.
+	Scheduling *ArgoCDSchedulingSpec `json:"scheduling,omitempty"`
 }
 
 func (a *ArgoCDRedisSpec) IsEnabled() bool {
@@ -557,6 +984,13 @@ type ArgoCDRepoSpec struct {
 
 	// Custom labels to pods deployed by the operator
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Patches are applied in order to the Deployment of the Argo CD Repo server, after it is rendered by the operator.
+	Patches []ArgoCDResourcePatch `json:"patches,omitempty"`
+
+	// Scheduling defines the scheduling options of the pods of the Deployment of the Argo CD Repo server. They take precedence over
+	// the scheduling options in .spec.nodePlacement.
+	Scheduling *ArgoCDSchedulingSpec `json:"scheduling,omitempty"`
 }
 
 func (a *ArgoCDRepoSpec) IsEnabled() bool {
@@ -610,6 +1044,75 @@ type ArgoCDServerGRPCSpec struct {
 	Ingress ArgoCDIngressSpec `json:"ingress,omitempty"`
 }
 
+// ArgoCDServerExtension defines an Argo CD UI extension installed in the Argo CD server.
+type ArgoCDServerExtension struct {
+	// Name is the name of the extension. The extension is installed in a directory with this name, and its proxy extension
+	// backend is served under the /extensions/<name> path of the Argo CD server.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	Name string `json:"name"`
+
+	// URL is the URL of the tar archive of the extension.
+	URL string `json:"url"`
+
+	// ChecksumURL is the URL of a file holding the sha256 checksum of the archive, used to verify the archive before it is
+	// installed (optional).
+	ChecksumURL string `json:"checksumURL,omitempty"`
+
+	// Proxy defines the backend services the Argo CD server proxies the requests of the extension to (optional).
+	Proxy *ArgoCDServerExtensionProxy `json:"proxy,omitempty"`
+}
+
+// ArgoCDServerExtensionProxy defines the backend services of a proxy extension.
+type ArgoCDServerExtensionProxy struct {
+	// Services are the backend services of the extension. When several services are set, each must define the cluster
+	// whose Applications it serves.
+	// +kubebuilder:validation:MinItems=1
+	Services []ArgoCDServerExtensionService `json:"services"`
+
+	// ConnectionTimeout is the maximum amount of time a dial to a backend service waits for a connect to complete.
+	ConnectionTimeout *metav1.Duration `json:"connectionTimeout,omitempty"`
+
+	// KeepAlive is the interval between keep-alive probes of the connections to the backend services.
+	KeepAlive *metav1.Duration `json:"keepAlive,omitempty"`
+
+	// IdleConnectionTimeout is the maximum amount of time an idle connection to a backend service remains open.
+	IdleConnectionTimeout *metav1.Duration `json:"idleConnectionTimeout,omitempty"`
+
+	// MaxIdleConnections is the maximum number of idle connections to the backend services.
+	MaxIdleConnections *int32 `json:"maxIdleConnections,omitempty"`
+}
+
+// ArgoCDServerExtensionService defines a backend service of a proxy extension.
+type ArgoCDServerExtensionService struct {
+	// URL is the address of the backend service.
+	URL string `json:"url"`
+
+	// Cluster is the cluster whose Applications are served by the backend service, identified by its name or server URL.
+	Cluster *ArgoCDServerExtensionCluster `json:"cluster,omitempty"`
+
+	// Headers are added to the requests proxied to the backend service. A value prefixed with $ references a key of the
+	// argocd-secret Secret.
+	Headers []ArgoCDServerExtensionHeader `json:"headers,omitempty"`
+}
+
+// ArgoCDServerExtensionCluster identifies the cluster served by a backend service of a proxy extension.
+type ArgoCDServerExtensionCluster struct {
+	// Name is the name of the cluster. It must be set when Server is not set.
+	Name string `json:"name,omitempty"`
+
+	// Server is the URL of the Kubernetes API of the cluster. It must be set when Name is not set.
+	Server string `json:"server,omitempty"`
+}
+
+// ArgoCDServerExtensionHeader defines a header added to the requests proxied to a backend service.
+type ArgoCDServerExtensionHeader struct {
+	// Name is the name of the header.
+	Name string `json:"name"`
+
+	// Value is the value of the header, or a reference to a key of the argocd-secret Secret when prefixed with $.
+	Value string `json:"value"`
+}
+
 // ArgoCDServerSpec defines the options for the ArgoCD Server component.
 type ArgoCDServerSpec struct {
 	// Autoscale defines the autoscale options for the Argo CD Server component.
@@ -618,6 +1121,11 @@ type ArgoCDServerSpec struct {
 	// EnableRolloutsUI will add the Argo Rollouts UI extension in ArgoCD Dashboard.
 	EnableRolloutsUI bool `json:"enableRolloutsUI,omitempty"`
 
+	// Extensions is the list of Argo CD UI extensions installed in the Argo CD server, with their optional proxy extension backends.
+	// +listType=map
+	// +listMapKey=name
+	Extensions []ArgoCDServerExtension `json:"extensions,omitempty"`
+
 	// GRPC defines the state for the Argo CD Server GRPC options.
 	GRPC ArgoCDServerGRPCSpec `json:"grpc,omitempty"`
 
@@ -679,6 +1187,13 @@ type ArgoCDServerSpec struct {
 
 	// Custom labels to pods deployed by the operator
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Patches are applied in order to the Deployment of the Argo CD Server, after it is rendered by the operator.
+	Patches []ArgoCDResourcePatch `json:"patches,omitempty"`
+
+	// Scheduling defines the scheduling options of the pods of the Deployment of the Argo CD Server. They take precedence over
+	// the scheduling options in .spec.nodePlacement.
+	Scheduling *ArgoCDSchedulingSpec `json:"scheduling,omitempty"`
 }
 
 func (a *ArgoCDServerSpec) IsEnabled() bool {
@@ -697,12 +1212,20 @@ type ResourceHealthCheck struct {
 	Group string `json:"group,omitempty"`
 	Kind  string `json:"kind,omitempty"`
 	Check string `json:"check,omitempty"`
+
+	// CheckFrom loads the Lua health check from a key of a ConfigMap in the namespace of the Argo CD instance.
+	// Check is ignored when CheckFrom is set.
+	CheckFrom *corev1.ConfigMapKeySelector `json:"checkFrom,omitempty"`
 }
 
 // Resource Customization for ignore difference
 type ResourceIgnoreDifference struct {
 	All                 *IgnoreDifferenceCustomization `json:"all,omitempty"`
 	ResourceIdentifiers []ResourceIdentifiers          `json:"resourceIdentifiers,omitempty"`
+
+	// AllFrom loads the customization applied to all resources from a key of a ConfigMap in the namespace of the
+	// Argo CD instance. All is ignored when AllFrom is set.
+	AllFrom *corev1.ConfigMapKeySelector `json:"allFrom,omitempty"`
 }
 
 // Resource Customization fields for ignore difference
@@ -710,6 +1233,10 @@ type ResourceIdentifiers struct {
 	Group         string                        `json:"group,omitempty"`
 	Kind          string                        `json:"kind,omitempty"`
 	Customization IgnoreDifferenceCustomization `json:"customization,omitempty"`
+
+	// CustomizationFrom loads the customization from a key of a ConfigMap in the namespace of the Argo CD instance.
+	// Customization is ignored when CustomizationFrom is set.
+	CustomizationFrom *corev1.ConfigMapKeySelector `json:"customizationFrom,omitempty"`
 }
 
 type IgnoreDifferenceCustomization struct {
@@ -723,6 +1250,10 @@ type ResourceAction struct {
 	Group  string `json:"group,omitempty"`
 	Kind   string `json:"kind,omitempty"`
 	Action string `json:"action,omitempty"`
+
+	// ActionFrom loads the action definitions from a key of a ConfigMap in the namespace of the Argo CD instance.
+	// Action is ignored when ActionFrom is set.
+	ActionFrom *corev1.ConfigMapKeySelector `json:"actionFrom,omitempty"`
 }
 
 // SSOProviderType string defines the type of SSO provider.
@@ -735,6 +1266,23 @@ const (
 
 	// SSOProviderTypeDex means dex will be Installed and Integrated with Argo CD.
 	SSOProviderTypeDex SSOProviderType = "dex"
+
+	// SSOProviderTypeOIDC means Argo CD will authenticate users directly against an external OIDC provider.
+	SSOProviderTypeOIDC SSOProviderType = "oidc"
+)
+
+// KeycloakMode defines the Keycloak distribution managed by the operator.
+type KeycloakMode string
+
+const (
+	// KeycloakModeLegacy installs the legacy (WildFly based) Keycloak distribution and posts the argocd realm through the admin API.
+	KeycloakModeLegacy KeycloakMode = "legacy"
+
+	// KeycloakModeQuarkus installs a current (Quarkus based) Keycloak distribution and imports the argocd realm at startup.
+	KeycloakModeQuarkus KeycloakMode = "quarkus"
+
+	// KeycloakModeExternal does not install Keycloak. The argocd client is managed in an existing realm of an external Keycloak.
+	KeycloakModeExternal KeycloakMode = "external"
 )
 
 // ArgoCDSSOSpec defines SSO provider.
@@ -747,6 +1295,35 @@ type ArgoCDSSOSpec struct {
 
 	// Keycloak contains the configuration for Argo CD keycloak authentication
 	Keycloak *ArgoCDKeycloakSpec `json:"keycloak,omitempty"`
+
+	// OIDC contains the configuration for Argo CD authentication against an external OIDC provider
+	OIDC *ArgoCDOIDCSpec `json:"oidc,omitempty"`
+}
+
+// ArgoCDOIDCSpec defines the desired state for authentication against an external OIDC provider.
+type ArgoCDOIDCSpec struct {
+	// Name of the provider shown on the login page. Defaults to OIDC.
+	Name string `json:"name,omitempty"`
+
+	// Issuer is the URL of the OIDC provider.
+	Issuer string `json:"issuer"`
+
+	// ClientID is the OAuth client ID registered for Argo CD.
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef references the key of a Secret holding the OAuth client secret.
+	ClientSecretRef *corev1.SecretKeySelector `json:"clientSecretRef"`
+
+	// RequestedScopes are the scopes requested from the provider. Defaults to openid, profile and email.
+	RequestedScopes []string `json:"requestedScopes,omitempty"`
+
+	// RootCARef references the key of a ConfigMap holding the PEM encoded certificate of the CA that signed the
+	// certificate of the provider.
+	RootCARef *corev1.ConfigMapKeySelector `json:"rootCARef,omitempty"`
+
+	// GroupsClaim is the claim holding the groups of a user. It is requested as an essential ID token claim and used
+	// as RBAC scope unless the scopes are set in .spec.rbac.scopes.
+	GroupsClaim string `json:"groupsClaim,omitempty"`
 }
 
 // KustomizeVersionSpec is used to specify information about a kustomize version to be used within ArgoCD.
@@ -766,6 +1343,147 @@ type ArgoCDMonitoringSpec struct {
 	Enabled bool `json:"enabled"`
 	// DisableMetrics field can be used to enable or disable the collection of Metrics on Openshift
 	DisableMetrics *bool `json:"disableMetrics,omitempty"`
+	// Dashboards defines the Grafana dashboards shipped for this instance.
+	Dashboards *ArgoCDDashboardsSpec `json:"dashboards,omitempty"`
+	// Alerts configures the alert catalogue created for this instance when monitoring is enabled. The catalogue is
+	// not created unless enabled.
+	Alerts *ArgoCDAlertsSpec `json:"alerts,omitempty"`
+}
+
+// ArgoCDAlertsSpec is used to configure the alert catalogue of an Argo CD instance. Once the catalogue is enabled,
+// every alert group but ManifestGeneration is enabled by default, and each can be tuned or switched individually.
+type ArgoCDAlertsSpec struct {
+	// Enabled defines whether the alert catalogue is created for this instance or not.
+	Enabled bool `json:"enabled"`
+	// AdditionalLabels are added to every alert of the catalogue.
+	AdditionalLabels map[string]string `json:"additionalLabels,omitempty"`
+	// ApplicationSync alerts when the ratio of failed application syncs in the last hour exceeds the threshold.
+	// The threshold defaults to 0.1.
+	ApplicationSync *ArgoCDAlertGroupSpec `json:"applicationSync,omitempty"`
+	// ApplicationHealth alerts when the ratio of degraded applications exceeds the threshold.
+	// The threshold defaults to 0.1.
+	ApplicationHealth *ArgoCDAlertGroupSpec `json:"applicationHealth,omitempty"`
+	// ManifestGeneration alerts when the 95th percentile of the repo server manifest generation latency exceeds the
+	// threshold in seconds. The threshold defaults to 30. Disabled by default, as it requires the gRPC time histogram
+	// of the repo server, which Argo CD does not expose by default.
+	ManifestGeneration *ArgoCDAlertGroupSpec `json:"manifestGeneration,omitempty"`
+	// ControllerQueue alerts when the depth of the application controller reconciliation queues exceeds the
+	// threshold. The threshold defaults to 100.
+	ControllerQueue *ArgoCDAlertGroupSpec `json:"controllerQueue,omitempty"`
+	// RedisMemory alerts when the memory usage of Redis exceeds the threshold as a ratio of its memory limit.
+	// The threshold defaults to 0.9.
+	RedisMemory *ArgoCDAlertGroupSpec `json:"redisMemory,omitempty"`
+	// GitRequests alerts when the number of failed Git fetches in the last 10 minutes exceeds the threshold.
+	// The threshold defaults to 0.
+	GitRequests *ArgoCDAlertGroupSpec `json:"gitRequests,omitempty"`
+	// CertificateExpiry alerts when a cert-manager certificate in the namespace of the instance expires within the
+	// threshold in days. The threshold defaults to 14.
+	CertificateExpiry *ArgoCDAlertGroupSpec `json:"certificateExpiry,omitempty"`
+}
+
+// ArgoCDAlertGroupSpec is used to configure a group of the alert catalogue.
+type ArgoCDAlertGroupSpec struct {
+	// Enabled defines whether the alert group is created. Defaults to true, except for ManifestGeneration.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Threshold of the alert group. Its meaning and default depend on the group.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	Threshold string `json:"threshold,omitempty"`
+	// Severity of the alerts of the group. Defaults to warning.
+	Severity string `json:"severity,omitempty"`
+	// For is how long the alert condition must hold before the alert fires. Defaults to 10m.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(ms|s|m|h|d|w|y)$`
+	For string `json:"for,omitempty"`
+	// Labels are added to the alerts of the group.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// IsEnabled will return true if the alert group is enabled, or enabledByDefault when it is not configured.
+func (a *ArgoCDAlertGroupSpec) IsEnabled(enabledByDefault bool) bool {
+	if a == nil || a.Enabled == nil {
+		return enabledByDefault
+	}
+	return *a.Enabled
+}
+
+// ArgoCDDashboardsSpec is used to configure the Grafana dashboards of the Argo CD components. The dashboards are
+// created as ConfigMaps that are picked up by the Grafana dashboard sidecar.
+type ArgoCDDashboardsSpec struct {
+	// Enabled defines whether the dashboard ConfigMaps are created for this instance or not.
+	Enabled bool `json:"enabled"`
+	// Labels are added to the dashboard ConfigMaps. Defaults to grafana_dashboard: "1", the label watched by the
+	// Grafana dashboard sidecar.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are added to the dashboard ConfigMaps, e.g. the folder annotation of the Grafana dashboard sidecar.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// GrafanaOperator defines the GrafanaDashboard resources created when the grafana-operator APIs are available.
+	GrafanaOperator *ArgoCDGrafanaOperatorDashboardsSpec `json:"grafanaOperator,omitempty"`
+}
+
+// ArgoCDGrafanaOperatorDashboardsSpec is used to configure the GrafanaDashboard resources referencing the dashboard
+// ConfigMaps.
+type ArgoCDGrafanaOperatorDashboardsSpec struct {
+	// Enabled defines whether GrafanaDashboard resources are created for this instance or not.
+	Enabled bool `json:"enabled"`
+	// InstanceSelector selects the Grafana instances the dashboards are imported into.
+	InstanceSelector *metav1.LabelSelector `json:"instanceSelector,omitempty"`
+	// Folder is the Grafana folder the dashboards are imported into.
+	Folder string `json:"folder,omitempty"`
+	// AllowCrossNamespaceImport allows importing the dashboards into Grafana instances in other namespaces.
+	AllowCrossNamespaceImport bool `json:"allowCrossNamespaceImport,omitempty"`
+}
+
+// ArgoCDResourcePatch is a patch applied to a resource rendered by the operator. The patch must be idempotent, as it
+// is applied again on every reconciliation.
+type ArgoCDResourcePatch struct {
+	// Type of the patch, either a strategic merge patch or a JSON patch (RFC 6902). Defaults to strategic.
+	// +kubebuilder:validation:Enum=strategic;json
+	Type string `json:"type,omitempty"`
+	// Patch is the content of the patch, in YAML or JSON.
+	Patch string `json:"patch"`
+}
+
+const (
+	ArgoCDResourcePatchTypeStrategic = "strategic"
+	ArgoCDResourcePatchTypeJSON      = "json"
+)
+
+// ArgoCDSuspendableComponent is the name of an Argo CD component whose workload reconciliation can be suspended.
+// +kubebuilder:validation:Enum=applicationController;applicationSet;dex;notifications;redis;repo;server
+type ArgoCDSuspendableComponent string
+
+const (
+	ArgoCDSuspendableComponentApplicationController ArgoCDSuspendableComponent = "applicationController"
+	ArgoCDSuspendableComponentApplicationSet        ArgoCDSuspendableComponent = "applicationSet"
+	ArgoCDSuspendableComponentDex                   ArgoCDSuspendableComponent = "dex"
+	ArgoCDSuspendableComponentNotifications         ArgoCDSuspendableComponent = "notifications"
+	ArgoCDSuspendableComponentRedis                 ArgoCDSuspendableComponent = "redis"
+	ArgoCDSuspendableComponentRepo                  ArgoCDSuspendableComponent = "repo"
+	ArgoCDSuspendableComponentServer                ArgoCDSuspendableComponent = "server"
+)
+
+// ArgoCDImageRegistrySpec defines the registry the images of Argo CD workloads are pulled from.
+type ArgoCDImageRegistrySpec struct {
+	// Registry replaces the registry of the images that do not match any of the mirrors, e.g. registry.example.com or
+	// registry.example.com/argocd. The repository path of the image is kept, images of Docker Hub keep their library/
+	// prefix.
+	Registry string `json:"registry,omitempty"`
+
+	// Mirrors replace the given image prefixes. The longest matching source prefix is used.
+	Mirrors []ArgoCDImageMirror `json:"mirrors,omitempty"`
+
+	// ResolveDigests resolves the tags of the images to digests, which are recorded in the status and used in place
+	// of the tags. A digest is resolved once per image reference, so that the workloads are only rolled out again when
+	// the image or version is changed. The image pull secrets are used to authenticate to the registry.
+	ResolveDigests bool `json:"resolveDigests,omitempty"`
+}
+
+// ArgoCDImageMirror defines a mirror of the images matching a prefix.
+type ArgoCDImageMirror struct {
+	// Source is the prefix of the images to replace, e.g. quay.io/argoproj.
+	Source string `json:"source"`
+
+	// Mirror is the prefix the source prefix is replaced with, e.g. registry.example.com/argoproj.
+	Mirror string `json:"mirror"`
 }
 
 // ArgoCDNodePlacementSpec is used to specify NodeSelector and Tolerations for Argo CD workloads
@@ -774,12 +1492,46 @@ type ArgoCDNodePlacementSpec struct {
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 	// Tolerations allow the pods to schedule onto nodes with matching taints
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// ArgoCDSchedulingSpec defines the scheduling options of all Argo CD workloads. The scheduling options of a
+	// component take precedence.
+	ArgoCDSchedulingSpec `json:",inline"`
+}
+
+// ArgoCDSchedulingSpec is used to specify the scheduling options of the pods of Argo CD workloads.
+type ArgoCDSchedulingSpec struct {
+	// Affinity of the pods. It replaces the default anti-affinity of the application controller and Redis HA pods.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// TopologySpreadConstraints of the pods. It replaces the default zone spread of the application controller,
+	// repo server and server pods when HA is enabled.
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// PriorityClassName of the pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// RuntimeClassName of the pods.
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
 }
 
 // ArgoCDSpec defines the desired state of ArgoCD
 // +k8s:openapi-gen=true
 type ArgoCDSpec struct {
 
+	// Accounts defines the local accounts of Argo CD, in addition to the admin account.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Accounts []ArgoCDAccount `json:"accounts,omitempty"`
+
+	// AdminPassword defines the rotation of the password of the admin account, and whether the account is disabled
+	// once SSO is bootstrapped.
+	// +optional
+	AdminPassword *ArgoCDAdminPasswordSpec `json:"adminPassword,omitempty"`
+
+	// LocalUsers defines the local users of Argo CD whose password, and optionally API token, are generated and
+	// managed by the operator.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	LocalUsers []ArgoCDLocalUser `json:"localUsers,omitempty"`
+
 	// ArgoCDApplicationSet defines whether the Argo CD ApplicationSet controller should be installed.
 	ApplicationSet *ArgoCDApplicationSet `json:"applicationSet,omitempty"`
 
@@ -797,12 +1549,17 @@ type ArgoCDSpec struct {
 	// DisableAdmin will disable the admin user.
 	DisableAdmin bool `json:"disableAdmin,omitempty"`
 
+	// Exec defines the options of the web-based terminal of the Argo CD UI.
+	// +optional
+	Exec *ArgoCDExecSpec `json:"exec,omitempty"`
+
 	// ExtraConfig can be used to add fields to Argo CD configmap that are not supported by Argo CD CRD.
 	//
 	// Note: ExtraConfig takes precedence over Argo CD CRD.
 	// For example, A user sets `argocd.Spec.DisableAdmin` = true and also
 	// `a.Spec.ExtraConfig["admin.enabled"]` = true. In this case, operator updates
 	// Argo CD Configmap as follows -> argocd-cm.Data["admin.enabled"] = true.
+	// The keys overriding a typed field are reported by the ExtraConfigOverrides condition.
 	ExtraConfig map[string]string `json:"extraConfig,omitempty"`
 
 	// GATrackingID is the google analytics tracking ID to use.
@@ -831,6 +1588,13 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:ArgoCD","urn:alm:descriptor:com.tectonic.ui:text"}
 	Image string `json:"image,omitempty"`
 
+	// ImagePullSecrets are the secrets used to pull the images of all Argo CD workloads.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// ImageRegistry defines the registry the images of all Argo CD workloads are pulled from, e.g. a mirror in an
+	// air-gapped cluster.
+	ImageRegistry *ArgoCDImageRegistrySpec `json:"imageRegistry,omitempty"`
+
 	// Import is the import/restore options for ArgoCD.
 	Import *ArgoCDImportSpec `json:"import,omitempty"`
 
@@ -888,6 +1652,11 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Action Customizations'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceActions []ResourceAction `json:"resourceActions,omitempty"`
 
+	// ResourceCustomizationSelector selects ConfigMaps in the namespace of the Argo CD instance whose
+	// resource.customizations.* keys are merged into the argocd-cm ConfigMap. Keys set by the spec take precedence,
+	// and invalid customizations are skipped and reported in the ResourceCustomizationsValid condition.
+	ResourceCustomizationSelector *metav1.LabelSelector `json:"resourceCustomizationSelector,omitempty"`
+
 	// ResourceExclusions is used to completely ignore entire classes of resource group/kinds.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Exclusions'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceExclusions string `json:"resourceExclusions,omitempty"`
@@ -903,6 +1672,10 @@ type ArgoCDSpec struct {
 	// Server defines the options for the ArgoCD Server component.
 	Server ArgoCDServerSpec `json:"server,omitempty"`
 
+	// SourceNamespaceSelector selects namespaces application resources are allowed to be created in, in addition to
+	// the namespaces matching SourceNamespaces.
+	SourceNamespaceSelector *metav1.LabelSelector `json:"sourceNamespaceSelector,omitempty"`
+
 	// SourceNamespaces defines the namespaces application resources are allowed to be created in
 	SourceNamespaces []string `json:"sourceNamespaces,omitempty"`
 
@@ -913,9 +1686,28 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Status Badge Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	StatusBadgeEnabled bool `json:"statusBadgeEnabled,omitempty"`
 
+	// Suspend stops the operator from changing the resources of this instance, e.g. to keep manual changes during an
+	// incident. The status is still reported and the resources are reconciled again once the instance is resumed.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// SuspendedComponents stops the operator from changing the workloads of the listed components, while the rest of
+	// the instance is reconciled.
+	SuspendedComponents []ArgoCDSuspendableComponent `json:"suspendedComponents,omitempty"`
+
+	// Tenancy restricts which namespaces can be managed by this instance through the
+	// argocd.argoproj.io/managed-by label. Any labelled namespace is managed when not set.
+	Tenancy *ArgoCDTenancySpec `json:"tenancy,omitempty"`
+
 	// TLS defines the TLS options for ArgoCD.
 	TLS ArgoCDTLSSpec `json:"tls,omitempty"`
 
+	// Tracing defines the OpenTelemetry tracing options of the Argo CD server, repo server and application controller.
+	Tracing *ArgoCDTracingSpec `json:"tracing,omitempty"`
+
+	// UI defines the customization of the Argo CD UI.
+	// +optional
+	UI *ArgoCDUISpec `json:"ui,omitempty"`
+
 	// UsersAnonymousEnabled toggles anonymous user access.
 	// The anonymous users get default role permissions specified argocd-rbac-cm.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Anonymous Users Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
@@ -938,6 +1730,20 @@ type ArgoCDSpec struct {
 // ArgoCDStatus defines the observed state of ArgoCD
 // +k8s:openapi-gen=true
 type ArgoCDStatus struct {
+	// AdminPasswordRotatedAt is the time the operator last rotated the password of the admin account.
+	// +optional
+	AdminPasswordRotatedAt *metav1.Time `json:"adminPasswordRotatedAt,omitempty"`
+
+	// AdminPasswordRotationTrigger is the rotation trigger of the admin password last handled by the operator. The
+	// trigger the current password was generated for is recorded on the cluster Secret.
+	// +optional
+	AdminPasswordRotationTrigger string `json:"adminPasswordRotationTrigger,omitempty"`
+
+	// AdminDisabledAfterSSOBootstrap is true once the admin account was disabled as SSO became ready. The account
+	// stays disabled while SSO is configured and DisableAfterSSOBootstrap is set.
+	// +optional
+	AdminDisabledAfterSSOBootstrap bool `json:"adminDisabledAfterSSOBootstrap,omitempty"`
+
 	// ApplicationController is a simple, high-level summary of where the Argo CD application controller component is in its lifecycle.
 	// There are four possible ApplicationController values:
 	// Pending: The Argo CD application controller component has been accepted by the Kubernetes system, but one or more of the required resources have not been created.
@@ -956,6 +1762,11 @@ type ArgoCDStatus struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="ApplicationSetController",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	ApplicationSetController string `json:"applicationSetController,omitempty"`
 
+	// ApplicationSetControllerReplicas is the number of ready replicas of the Argo CD applicationSet controller component
+	// out of its desired replicas, e.g. 2/3.
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="ApplicationSetControllerReplicas",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	ApplicationSetControllerReplicas string `json:"applicationSetControllerReplicas,omitempty"`
+
 	// SSO is a simple, high-level summary of where the Argo CD SSO(Dex/Keycloak) component is in its lifecycle.
 	// There are four possible sso values:
 	// Pending: The Argo CD SSO component has been accepted by the Kubernetes system, but one or more of the required resources have not been created.
@@ -1018,8 +1829,109 @@ type ArgoCDStatus struct {
 
 	// Host is the hostname of the Ingress.
 	Host string `json:"host,omitempty"`
+
+	// Conditions describe the state of the Argo CD configuration managed by the operator.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// RejectedNamespaces are the namespaces labelled to be managed by this instance that are not allowed to join by
+	// the tenancy options.
+	RejectedNamespaces []string `json:"rejectedNamespaces,omitempty"`
+
+	// Plan lists the operations the operator would perform to apply the spec, while the ArgoCD is annotated with
+	// argocd.argoproj.io/plan: "true". The spec is not applied in that case.
+	Plan *ArgoCDPlanStatus `json:"plan,omitempty"`
+
+	// Images are the digests the images of the workloads were resolved to, when spec.imageRegistry.resolveDigests is
+	// enabled.
+	// +listType=map
+	// +listMapKey=image
+	Images []ArgoCDImageStatus `json:"images,omitempty"`
+
+	// UnhealthyWorkloads are the workloads of the components whose pods are failing, e.g. crash looping or
+	// unschedulable pods. The status of the component is Failed while one of its workloads is listed.
+	// +listType=map
+	// +listMapKey=workload
+	UnhealthyWorkloads []ArgoCDUnhealthyWorkload `json:"unhealthyWorkloads,omitempty"`
+}
+
+// ArgoCDUnhealthyWorkload describes why the pods of a workload are failing.
+type ArgoCDUnhealthyWorkload struct {
+	// Component the workload belongs to, e.g. repo or server.
+	Component string `json:"component"`
+
+	// Workload is the name of the Deployment or StatefulSet.
+	Workload string `json:"workload"`
+
+	// Pod is the name of the first failing pod of the workload.
+	Pod string `json:"pod"`
+
+	// Reason is one of CrashLoopBackOff, OOMKilled, Unschedulable, ErrImagePull, ImagePullBackOff, InvalidImageName
+	// or ProbeFailing.
+	Reason string `json:"reason"`
+
+	// Message describes the failure of the pod.
+	Message string `json:"message,omitempty"`
+}
+
+// ArgoCDImageStatus is the digest an image reference was resolved to.
+type ArgoCDImageStatus struct {
+	// Image is the image reference, after the registry options were applied.
+	Image string `json:"image"`
+
+	// Digest is the digest of the manifest the image reference pointed to when it was resolved.
+	Digest string `json:"digest"`
+}
+
+// ArgoCDPlanStatus is the plan of the operations needed to apply the spec of an ArgoCD.
+type ArgoCDPlanStatus struct {
+	// ObservedGeneration is the generation of the ArgoCD the plan was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Operations are the create, update, patch and delete operations the operator would perform.
+	Operations []ArgoCDPlannedOperation `json:"operations,omitempty"`
+
+	// Error is the error that stopped the computation of the plan. The operations planned up to the error are listed.
+	Error string `json:"error,omitempty"`
+}
+
+// ArgoCDPlannedOperation is an operation the operator would perform on an object.
+type ArgoCDPlannedOperation struct {
+	// Action is one of create, update, patch or delete.
+	Action string `json:"action"`
+
+	// Kind of the object.
+	Kind string `json:"kind"`
+
+	// Namespace of the object, empty for cluster scoped objects.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the object.
+	Name string `json:"name"`
+
+	// Changes describe the fields changed by an update.
+	Changes []string `json:"changes,omitempty"`
 }
 
+const (
+	// ArgoCDConditionTypeRBACPolicyValid indicates whether the RBAC policies merged into the argocd-rbac-cm ConfigMap
+	// are valid and free of conflicts.
+	ArgoCDConditionTypeRBACPolicyValid = "RBACPolicyValid"
+
+	// ArgoCDConditionTypeSuspended indicates whether the reconciliation of the instance, or of some of its
+	// components, is suspended.
+	ArgoCDConditionTypeSuspended = "Suspended"
+
+	// ArgoCDConditionTypeExtraConfigOverrides indicates whether keys of ExtraConfig override settings of argocd-cm
+	// rendered from typed fields.
+	ArgoCDConditionTypeExtraConfigOverrides = "ExtraConfigOverrides"
+
+	// ArgoCDConditionTypeResourceCustomizationsValid indicates whether the resource customizations merged into the
+	// argocd-cm ConfigMap could be loaded and are valid.
+	ArgoCDConditionTypeResourceCustomizationsValid = "ResourceCustomizationsValid"
+)
+
 // Banner defines an additional banner message to be displayed in Argo CD UI
 // https://argo-cd.readthedocs.io/en/stable/operator-manual/custom-styles/#banners
 type Banner struct {
@@ -1027,6 +1939,106 @@ type Banner struct {
 	Content string `json:"content"`
 	// URL defines an optional URL to be used as banner message link
 	URL string `json:"url,omitempty"`
+	// Permanent disables the close button of the banner
+	// +optional
+	Permanent bool `json:"permanent,omitempty"`
+	// Position defines where the banner is displayed, either top or bottom
+	// +kubebuilder:validation:Enum=top;bottom
+	// +optional
+	Position string `json:"position,omitempty"`
+}
+
+// ArgoCDAccountCapability is a capability of a local account of Argo CD.
+// +kubebuilder:validation:Enum=login;apiKey
+type ArgoCDAccountCapability string
+
+const (
+	// ArgoCDAccountCapabilityLogin allows the account to log in to the UI and the CLI.
+	ArgoCDAccountCapabilityLogin ArgoCDAccountCapability = "login"
+
+	// ArgoCDAccountCapabilityAPIKey allows the account to generate API keys.
+	ArgoCDAccountCapabilityAPIKey ArgoCDAccountCapability = "apiKey"
+)
+
+// ArgoCDAccount defines a local account of Argo CD.
+type ArgoCDAccount struct {
+	// Name of the account. The admin account is configured by DisableAdmin and cannot be declared here.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9_.]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Capabilities of the account.
+	// +kubebuilder:validation:MinItems=1
+	Capabilities []ArgoCDAccountCapability `json:"capabilities"`
+
+	// Enabled toggles the account. Accounts are enabled by default.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ArgoCDAdminPasswordSpec defines the lifecycle of the password of the admin account.
+type ArgoCDAdminPasswordSpec struct {
+	// RotationTrigger rotates the admin password whenever its value changes, e.g. when set to the current date.
+	// +optional
+	RotationTrigger string `json:"rotationTrigger,omitempty"`
+
+	// RotationInterval rotates the admin password once it is older than the interval.
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+
+	// DisableAfterSSOBootstrap disables the admin account once the configured SSO provider is ready.
+	// +optional
+	DisableAfterSSOBootstrap bool `json:"disableAfterSSOBootstrap,omitempty"`
+}
+
+// ArgoCDLocalUser defines a local user of Argo CD. The operator generates its password and stores it, with its API
+// token when requested, in the <argocd name>-local-user-<user name> Secret.
+type ArgoCDLocalUser struct {
+	// Name of the user. It must not be admin, nor the name of one of the Accounts.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+
+	// Capabilities of the user. The apiKey capability is added when an API token is requested.
+	// +kubebuilder:validation:MinItems=1
+	Capabilities []ArgoCDAccountCapability `json:"capabilities"`
+
+	// Enabled toggles the user. Users are enabled by default.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// APIToken requests an API token minted by the operator for the user.
+	// +optional
+	APIToken *ArgoCDLocalUserAPIToken `json:"apiToken,omitempty"`
+}
+
+// ArgoCDLocalUserAPIToken defines the API token minted by the operator for a local user.
+type ArgoCDLocalUserAPIToken struct {
+	// Lifetime of the token. The token does not expire when not set.
+	// +optional
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// AutoRenew mints a new token once the token has expired. Defaults to true.
+	// +optional
+	AutoRenew *bool `json:"autoRenew,omitempty"`
+}
+
+// ArgoCDExecSpec defines the options of the web-based terminal of the Argo CD UI.
+type ArgoCDExecSpec struct {
+	// Enabled allows the users with the exec RBAC permission to open a terminal in the pods of their applications.
+	Enabled bool `json:"enabled"`
+
+	// Shells is the ordered list of shells tried in the containers. Argo CD defaults to bash, sh, powershell and cmd.
+	// +optional
+	Shells []string `json:"shells,omitempty"`
+}
+
+// ArgoCDUISpec defines the customization of the Argo CD UI.
+type ArgoCDUISpec struct {
+	// CSSURL is the URL, or the path relative to the Argo CD server, of a stylesheet customizing the UI.
+	// https://argo-cd.readthedocs.io/en/stable/operator-manual/custom-styles/
+	// +optional
+	CSSURL string `json:"cssURL,omitempty"`
 }
 
 // ArgoCDTLSSpec defines the TLS options for ArgCD.
@@ -1038,6 +2050,50 @@ type ArgoCDTLSSpec struct {
 	InitialCerts map[string]string `json:"initialCerts,omitempty"`
 }
 
+// ArgoCDTenancySpec defines which namespaces may join an ArgoCD instance through the argocd.argoproj.io/managed-by
+// label. A namespace may join when it matches the namespace selector or is owned by one of the owners. Any labelled
+// namespace may join when neither is set.
+type ArgoCDTenancySpec struct {
+	// NamespaceSelector selects the namespaces that may join this instance.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Owners are the users whose namespaces may join this instance. The owner of a namespace is read from the
+	// owner annotation.
+	Owners []string `json:"owners,omitempty"`
+
+	// OwnerAnnotation is the namespace annotation holding the owner of a namespace, such as openshift.io/requester
+	// on OpenShift. Required when owners are set.
+	OwnerAnnotation string `json:"ownerAnnotation,omitempty"`
+
+	// MaxManagedNamespaces is the maximum number of namespaces managed by this instance, not counting the namespace
+	// of the instance. Namespaces beyond the limit are rejected, the most recently created first. Unlimited when not set.
+	// +kubebuilder:validation:Minimum=0
+	MaxManagedNamespaces *int32 `json:"maxManagedNamespaces,omitempty"`
+}
+
+// ArgoCDTracingSpec defines the OpenTelemetry tracing options for ArgoCD. Traces are exported with OTLP over gRPC.
+type ArgoCDTracingSpec struct {
+	// Enabled defines whether the Argo CD components export traces.
+	Enabled bool `json:"enabled"`
+
+	// Endpoint is the host:port of the OTLP gRPC collector the traces are sent to.
+	// +kubebuilder:validation:Pattern=`^[^:/\s]+:[0-9]+$`
+	Endpoint string `json:"endpoint"`
+
+	// Insecure disables TLS for the connection to the collector. When not set, the collector is verified with the
+	// system CA certificates.
+	Insecure bool `json:"insecure,omitempty"`
+
+	// HeadersSecretRef references the key of a Secret holding the headers sent to the collector, in the form
+	// key1=value1,key2=value2.
+	HeadersSecretRef *corev1.SecretKeySelector `json:"headersSecretRef,omitempty"`
+}
+
+// IsEnabled will return true if tracing is enabled.
+func (t *ArgoCDTracingSpec) IsEnabled() bool {
+	return t != nil && t.Enabled
+}
+
 type SSHHostsSpec struct {
 	// ExcludeDefaultHosts describes whether you would like to include the default
 	// list of SSH Known Hosts provided by ArgoCD.
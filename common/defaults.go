@@ -63,6 +63,9 @@ const (
 	// ArgoCDDefaultAdminPasswordNumSymbols is the number of symbols to use for the generated default admin password.
 	ArgoCDDefaultAdminPasswordNumSymbols = 0
 
+	// ArgoCDDefaultAdminUsername is the name of the built-in admin account of Argo CD.
+	ArgoCDDefaultAdminUsername = "admin"
+
 	// ArgoCDDefaultApplicationInstanceLabelKey is the default app name as a tracking label.
 	ArgoCDDefaultApplicationInstanceLabelKey = "app.kubernetes.io/instance"
 
@@ -161,6 +164,9 @@ const (
 	// Version: 15.0.2
 	ArgoCDKeycloakVersion = "sha256:64fb81886fde61dee55091e6033481fa5ccdac62ae30a4fd29b54eb5e97df6a9"
 
+	// ArgoCDKeycloakQuarkusVersion is the default Keycloak version used in quarkus mode when not specified.
+	ArgoCDKeycloakQuarkusVersion = "24.0.5"
+
 	// ArgoCDKeycloakImageForOpenShift is the default Keycloak Image used for the OpenShift platform when not specified.
 	ArgoCDKeycloakImageForOpenShift = "registry.redhat.io/rh-sso-7/sso76-openshift-rhel8"
 
@@ -183,6 +189,9 @@ const (
 	// ArgoCDDefaultRBACScopes is the default Argo CD RBAC scopes.
 	ArgoCDDefaultRBACScopes = "[groups]"
 
+	// ArgoCDDefaultGrafanaDashboardPath is the default Grafana dashboard template directory when not specified.
+	ArgoCDDefaultGrafanaDashboardPath = "/var/lib/dashboards"
+
 	// ArgoCDDefaultRedisConfigPath is the default Redis configuration directory when not specified.
 	ArgoCDDefaultRedisConfigPath = "/var/lib/redis"
 
@@ -300,6 +309,9 @@ vs-ssh.visualstudio.com ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC7Hr1oTWqNqOlzGJOf
 
 	// NotificationsControllerMetricsPort is the port that is used to expose notifications controller metrics.
 	NotificationsControllerMetricsPort = 9001
+
+	// ApplicationSetControllerMetricsPort is the port that is used to expose applicationset controller metrics.
+	ApplicationSetControllerMetricsPort = 8080
 )
 
 // DefaultLabels returns the default set of labels for controllers.
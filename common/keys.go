@@ -103,6 +103,12 @@ const (
 	// ArgoCDKeyRBACPolicyCSV is the configuration key for the Argo CD RBAC policy CSV.
 	ArgoCDKeyRBACPolicyCSV = "policy.csv"
 
+	// ArgoCDKeyRBACPolicySpecCSV is the configuration key for the RBAC policy CSV rendered from the typed roles and bindings.
+	ArgoCDKeyRBACPolicySpecCSV = "policy.spec.csv"
+
+	// ArgoCDKeyRBACPolicyKeys is the annotation on the RBAC ConfigMap listing the policy keys managed by the operator.
+	ArgoCDKeyRBACPolicyKeys = "argocd.argoproj.io/rbac-policy-keys"
+
 	// ArgoCDKeyRBACPolicyDefault is the configuration key for the Argo CD RBAC default policy.
 	ArgoCDKeyRBACPolicyDefault = "policy.default"
 
@@ -118,6 +124,18 @@ const (
 	// ArgoCDKeyResourceInclusions is the configuration key for resource inclusions.
 	ArgoCDKeyResourceInclusions = "resource.inclusions"
 
+	// ArgoCDKeyResourceCustomizationsPrefix is the prefix of the configuration keys for resource customizations.
+	ArgoCDKeyResourceCustomizationsPrefix = "resource.customizations."
+
+	// ArgoCDKeyResourceHealthPrefix is the prefix of the configuration keys for resource health checks.
+	ArgoCDKeyResourceHealthPrefix = "resource.customizations.health."
+
+	// ArgoCDKeyResourceActionsPrefix is the prefix of the configuration keys for resource actions.
+	ArgoCDKeyResourceActionsPrefix = "resource.customizations.actions."
+
+	// ArgoCDKeyResourceIgnoreDifferencesPrefix is the prefix of the configuration keys for resource ignore differences.
+	ArgoCDKeyResourceIgnoreDifferencesPrefix = "resource.customizations.ignoreDifferences."
+
 	// ArgoCDKeyResourceTrackingMethod is the configuration key for resource tracking method
 	ArgoCDKeyResourceTrackingMethod = "application.resourceTrackingMethod"
 
@@ -145,6 +163,61 @@ const (
 	// ArgoCDKeyBannerURL is the configuration key for a banner message URL.
 	ArgoCDKeyBannerURL = "ui.bannerurl"
 
+	// ArgoCDKeyBannerPermanent is the configuration key for disabling the close button of the banner.
+	ArgoCDKeyBannerPermanent = "ui.bannerpermanent"
+
+	// ArgoCDKeyBannerPosition is the configuration key for the position of the banner.
+	ArgoCDKeyBannerPosition = "ui.bannerposition"
+
+	// ArgoCDKeyUICSSURL is the configuration key for the URL of a stylesheet customizing the UI.
+	ArgoCDKeyUICSSURL = "ui.cssurl"
+
+	// ArgoCDKeyAccountPrefix is the prefix of the configuration keys of the local accounts.
+	ArgoCDKeyAccountPrefix = "accounts."
+
+	// ArgoCDKeyAccountEnabledSuffix is the suffix of the configuration key enabling a local account.
+	ArgoCDKeyAccountEnabledSuffix = ".enabled"
+
+	// ArgoCDKeyAccountPasswordSuffix is the suffix of the Argo CD Secret key of the password hash of a local account.
+	ArgoCDKeyAccountPasswordSuffix = ".password"
+
+	// ArgoCDKeyAccountPasswordMTimeSuffix is the suffix of the Argo CD Secret key of the password modification time
+	// of a local account.
+	ArgoCDKeyAccountPasswordMTimeSuffix = ".passwordMtime"
+
+	// ArgoCDKeyAccountTokensSuffix is the suffix of the Argo CD Secret key of the API tokens of a local account.
+	ArgoCDKeyAccountTokensSuffix = ".tokens"
+
+	// ArgoCDKeyLocalUserName is the key of the name of the user in the Secret of a local user.
+	ArgoCDKeyLocalUserName = "username"
+
+	// ArgoCDKeyLocalUserPassword is the key of the password in the Secret of a local user.
+	ArgoCDKeyLocalUserPassword = "password"
+
+	// ArgoCDKeyLocalUserAPIToken is the key of the API token in the Secret of a local user.
+	ArgoCDKeyLocalUserAPIToken = "apiToken"
+
+	// ArgoCDKeyLocalUserAPITokenID is the key of the ID of the API token in the Secret of a local user.
+	ArgoCDKeyLocalUserAPITokenID = "apiTokenID"
+
+	// ArgoCDKeyLocalUserAPITokenExpiresAt is the key of the expiry time of the API token in the Secret of a local user.
+	ArgoCDKeyLocalUserAPITokenExpiresAt = "apiTokenExpiresAt"
+
+	// ArgoCDKeyExecEnabled is the configuration key for enabling the web-based terminal.
+	ArgoCDKeyExecEnabled = "exec.enabled"
+
+	// ArgoCDKeyExecShells is the configuration key for the shells of the web-based terminal.
+	ArgoCDKeyExecShells = "exec.shells"
+
+	// ArgoCDKeyReconciliationTimeout is the configuration key for the reconciliation timeout of the applications.
+	ArgoCDKeyReconciliationTimeout = "timeout.reconciliation"
+
+	// ArgoCDKeyExtensionConfig is the configuration key for the proxy extensions.
+	ArgoCDKeyExtensionConfig = "extension.config"
+
+	// ArgoCDKeyTopologyZone is the well-known node label key of the topology zone.
+	ArgoCDKeyTopologyZone = "topology.kubernetes.io/zone"
+
 	// ArgoCDKeyTLSCACert is the key for TLS CA certificates.
 	ArgoCDKeyTLSCACert = "ca.crt"
 
@@ -197,6 +270,17 @@ const (
 	// ArgoCDSecretTypeLabel is needed for cluster secrets
 	ArgoCDSecretTypeLabel = "argocd.argoproj.io/secret-type"
 
+	// ArgoCDLocalUserLabel is the label of the Secrets of the local users, set to the name of the user.
+	ArgoCDLocalUserLabel = "argocd.argoproj.io/local-user"
+
+	// ArgoCDAdminPasswordRotationTriggerAnnotation records on the cluster Secret the rotation trigger the admin
+	// password stored in it was generated for.
+	ArgoCDAdminPasswordRotationTriggerAnnotation = "argocd.argoproj.io/admin-password-rotation-trigger"
+
+	// ArgoCDPlanAnnotation puts an ArgoCD in plan mode when set to true. The operations needed to apply the spec are
+	// reported in the status instead of being performed.
+	ArgoCDPlanAnnotation = "argocd.argoproj.io/plan"
+
 	// ArgoCDManagedByLabel is needed to identify namespace managed by an instance on ArgoCD
 	ArgoCDManagedByLabel = "argocd.argoproj.io/managed-by"
 
@@ -215,6 +299,9 @@ const (
 	// ArgoCDDexSecretKey is used to reference Dex secret from Argo CD secret into Argo CD configmap
 	ArgoCDDexSecretKey = "oidc.dex.clientSecret"
 
+	// ArgoCDOIDCSecretKey is used to reference the client secret of the oidc SSO provider from Argo CD secret into Argo CD configmap
+	ArgoCDOIDCSecretKey = "oidc.sso.clientSecret"
+
 	// Label Selector is an env variable for ArgoCD instance reconcilliation.
 	ArgoCDLabelSelectorKey = "ARGOCD_LABEL_SELECTOR"
 )
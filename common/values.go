@@ -86,6 +86,9 @@ const (
 	//ApplicationSetServiceNameSuffix is the suffix for Apllication Set Controller Service
 	ApplicationSetServiceNameSuffix = "applicationset-controller"
 
+	// ApplicationSetMetricsServiceNameSuffix is the suffix for the Application Set Controller metrics Service and ServiceMonitor.
+	ApplicationSetMetricsServiceNameSuffix = "applicationset-controller-metrics"
+
 	// ArgoCDAggregateToControllerLabelKey is label to configure base aggregated ClusterRole for Argo CD Application Controller.
 	ArgoCDAggregateToControllerLabelKey = "argocd/aggregate-to-controller"
 
@@ -0,0 +1,188 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	argopass "github.com/argoproj/argo-cd/v2/util/password"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// adminPasswordEventReasonRotated is the reason of the Event emitted when the admin password is rotated.
+const adminPasswordEventReasonRotated = "AdminPasswordRotated"
+
+// isSSOReady returns true when an SSO provider is configured for the given ArgoCD and ready to authenticate users.
+func isSSOReady(cr *argoproj.ArgoCD) bool {
+	if cr.Spec.SSO == nil {
+		return false
+	}
+	if isOIDCProvider(cr) {
+		return cr.Status.SSO == ssoLegalSuccess
+	}
+	return cr.Status.SSO == "Running"
+}
+
+// isAdminDisabled returns true when the admin account of the given ArgoCD is disabled, either explicitly or once SSO
+// was bootstrapped.
+func isAdminDisabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.DisableAdmin || (isAdminDisabledAfterSSOBootstrap(cr) && cr.Status.AdminDisabledAfterSSOBootstrap)
+}
+
+// isAdminDisabledAfterSSOBootstrap returns true when the admin account of the given ArgoCD is requested to be disabled
+// once its SSO provider is ready.
+func isAdminDisabledAfterSSOBootstrap(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.AdminPassword != nil && cr.Spec.AdminPassword.DisableAfterSSOBootstrap && cr.Spec.SSO != nil
+}
+
+// reconcileStatusAdminBootstrap will ensure that the status of the given ArgoCD records whether the admin account was
+// disabled after SSO was bootstrapped. The admin account stays disabled when SSO is no longer ready afterwards.
+func (r *ReconcileArgoCD) reconcileStatusAdminBootstrap(cr *argoproj.ArgoCD) error {
+	disabled := false
+	if isAdminDisabledAfterSSOBootstrap(cr) {
+		disabled = cr.Status.AdminDisabledAfterSSOBootstrap || isSSOReady(cr)
+	}
+	if cr.Status.AdminDisabledAfterSSOBootstrap == disabled {
+		return nil
+	}
+
+	if disabled {
+		log.Info(fmt.Sprintf("SSO of ArgoCD %s in namespace %s is ready, disabling the admin account", cr.Name, cr.Namespace))
+	}
+	cr.Status.AdminDisabledAfterSSOBootstrap = disabled
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// getAdminPasswordRotationReason will return why the admin password of the given ArgoCD, generated for the given
+// rotation trigger and last modified at the given time, must be rotated, or an empty string when it must not.
+func getAdminPasswordRotationReason(cr *argoproj.ArgoCD, appliedTrigger string, modifiedAt time.Time, now time.Time) string {
+	spec := cr.Spec.AdminPassword
+	if spec == nil {
+		return ""
+	}
+	if spec.RotationTrigger != "" && spec.RotationTrigger != appliedTrigger {
+		return fmt.Sprintf("the rotation trigger changed to %s", spec.RotationTrigger)
+	}
+	if spec.RotationInterval != nil && spec.RotationInterval.Duration > 0 && !modifiedAt.IsZero() &&
+		!now.Before(modifiedAt.Add(spec.RotationInterval.Duration)) {
+		return fmt.Sprintf("the password is older than the rotation interval %s", spec.RotationInterval.Duration)
+	}
+	return ""
+}
+
+// getAdminPasswordModifiedAt will return the time the given Argo CD Secret records for the last modification of the
+// admin password, or the zero time when it is not recorded.
+func getAdminPasswordModifiedAt(argoSecret *corev1.Secret) time.Time {
+	modifiedAt, err := time.Parse(time.RFC3339, string(argoSecret.Data[common.ArgoCDKeyAdminPasswordMTime]))
+	if err != nil {
+		return time.Time{}
+	}
+	return modifiedAt
+}
+
+// reconcileAdminPasswordRotation will ensure that the admin password of the given ArgoCD is regenerated when its
+// rotation trigger changes or once it is older than its rotation interval. The new password is set in the cluster
+// Secret along with the rotation trigger it was generated for, so that a failure to update the status afterwards does
+// not rotate it again. Its hash and modification time are set in the Argo CD Secret, and the rotation in the status.
+func (r *ReconcileArgoCD) reconcileAdminPasswordRotation(cr *argoproj.ArgoCD) error {
+	if cr.Spec.AdminPassword == nil {
+		return nil
+	}
+
+	clusterSecret := argoutil.NewSecretWithSuffix(cr, "cluster")
+	argoSecret := argoutil.NewSecretWithName(cr, common.ArgoCDSecretName)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, clusterSecret.Name, clusterSecret) ||
+		!argoutil.IsObjectFound(r.Client, cr.Namespace, argoSecret.Name, argoSecret) {
+		log.Info(fmt.Sprintf("cluster secret [%s] or argo secret [%s] not found, waiting to rotate the admin password", clusterSecret.Name, argoSecret.Name))
+		return nil
+	}
+
+	now := time.Now()
+	appliedTrigger := clusterSecret.Annotations[common.ArgoCDAdminPasswordRotationTriggerAnnotation]
+	reason := getAdminPasswordRotationReason(cr, appliedTrigger, getAdminPasswordModifiedAt(argoSecret), now)
+	if reason == "" {
+		return nil
+	}
+
+	password, err := generateArgoAdminPassword()
+	if err != nil {
+		return err
+	}
+	hashedPassword, err := argopass.HashPassword(string(password))
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("rotating the admin password of ArgoCD %s in namespace %s, as %s", cr.Name, cr.Namespace, reason)
+	log.Info(message)
+	if clusterSecret.Data == nil {
+		clusterSecret.Data = make(map[string][]byte)
+	}
+	clusterSecret.Data[common.ArgoCDKeyAdminPassword] = password
+	if cr.Spec.AdminPassword.RotationTrigger != "" {
+		if clusterSecret.Annotations == nil {
+			clusterSecret.Annotations = make(map[string]string)
+		}
+		clusterSecret.Annotations[common.ArgoCDAdminPasswordRotationTriggerAnnotation] = cr.Spec.AdminPassword.RotationTrigger
+	}
+	if err := r.Client.Update(context.TODO(), clusterSecret); err != nil {
+		return err
+	}
+	if argoSecret.Data == nil {
+		argoSecret.Data = make(map[string][]byte)
+	}
+	argoSecret.Data[common.ArgoCDKeyAdminPassword] = []byte(hashedPassword)
+	argoSecret.Data[common.ArgoCDKeyAdminPasswordMTime] = nowBytes()
+	if err := r.Client.Update(context.TODO(), argoSecret); err != nil {
+		return err
+	}
+
+	if err := argoutil.CreateEvent(r.Client, corev1.EventTypeNormal, "Rotate", message, adminPasswordEventReasonRotated, cr.ObjectMeta, cr.TypeMeta); err != nil {
+		log.Error(err, "failed to create event for the rotation of the admin password")
+	}
+
+	rotatedAt := metav1.NewTime(now)
+	cr.Status.AdminPasswordRotatedAt = &rotatedAt
+	cr.Status.AdminPasswordRotationTrigger = cr.Spec.AdminPassword.RotationTrigger
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// getAdminPasswordRotation will return the delay until the admin password of the given ArgoCD is older than its
+// rotation interval, or zero when it is not rotated periodically.
+func (r *ReconcileArgoCD) getAdminPasswordRotation(cr *argoproj.ArgoCD) time.Duration {
+	if cr.Spec.AdminPassword == nil || cr.Spec.AdminPassword.RotationInterval == nil || cr.Spec.AdminPassword.RotationInterval.Duration <= 0 {
+		return 0
+	}
+	argoSecret := argoutil.NewSecretWithName(cr, common.ArgoCDSecretName)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, argoSecret.Name, argoSecret) {
+		return 0
+	}
+	modifiedAt := getAdminPasswordModifiedAt(argoSecret)
+	if modifiedAt.IsZero() {
+		return 0
+	}
+	delay := time.Until(modifiedAt.Add(cr.Spec.AdminPassword.RotationInterval.Duration))
+	if delay < time.Second {
+		delay = time.Second
+	}
+	return delay
+}
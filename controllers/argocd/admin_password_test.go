@@ -0,0 +1,148 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	argopass "github.com/argoproj/argo-cd/v2/util/password"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcileAdminPasswordRotation(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.AdminPassword = &argoproj.ArgoCDAdminPasswordSpec{RotationTrigger: "2024-10-01"}
+	})
+	hashedPassword, err := argopass.HashPassword("initial")
+	assert.NoError(t, err)
+	clusterSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-cluster", Namespace: a.Namespace},
+		Data:       map[string][]byte{common.ArgoCDKeyAdminPassword: []byte("initial")},
+	}
+	argoSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDSecretName, Namespace: a.Namespace},
+		Data: map[string][]byte{
+			common.ArgoCDKeyAdminPassword:      []byte(hashedPassword),
+			common.ArgoCDKeyAdminPasswordMTime: []byte(time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)),
+		},
+	}
+
+	resObjs := []client.Object{a, clusterSecret, argoSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	getSecrets := func() {
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterSecret.Name, Namespace: a.Namespace}, clusterSecret))
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: argoSecret.Name, Namespace: a.Namespace}, argoSecret))
+	}
+
+	// the new trigger rotates the password
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	getSecrets()
+	password := string(clusterSecret.Data[common.ArgoCDKeyAdminPassword])
+	assert.NotEqual(t, "initial", password)
+	valid, _ := argopass.VerifyPassword(password, string(argoSecret.Data[common.ArgoCDKeyAdminPassword]))
+	assert.True(t, valid)
+	assert.WithinDuration(t, time.Now(), getAdminPasswordModifiedAt(argoSecret), time.Minute)
+	assert.NotNil(t, a.Status.AdminPasswordRotatedAt)
+	assert.Equal(t, "2024-10-01", a.Status.AdminPasswordRotationTrigger)
+	assert.Equal(t, "2024-10-01", clusterSecret.Annotations[common.ArgoCDAdminPasswordRotationTriggerAnnotation])
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+	if assert.Len(t, events.Items, 1) {
+		assert.Equal(t, adminPasswordEventReasonRotated, events.Items[0].Reason)
+	}
+
+	// the same trigger does not rotate the password again, even when the status was not updated
+	a.Status.AdminPasswordRotationTrigger = ""
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	getSecrets()
+	assert.Equal(t, password, string(clusterSecret.Data[common.ArgoCDKeyAdminPassword]))
+
+	// the password is rotated once it is older than the interval
+	a.Spec.AdminPassword.RotationInterval = &metav1.Duration{Duration: time.Hour}
+	assert.Greater(t, r.getAdminPasswordRotation(a), 59*time.Minute)
+	argoSecret.Data[common.ArgoCDKeyAdminPasswordMTime] = []byte(time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339))
+	assert.NoError(t, r.Client.Update(context.TODO(), argoSecret))
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	getSecrets()
+	assert.NotEqual(t, password, string(clusterSecret.Data[common.ArgoCDKeyAdminPassword]))
+}
+
+func TestGetAdminPasswordRotationReason(t *testing.T) {
+	now := time.Now()
+	a := makeTestArgoCD()
+	assert.Empty(t, getAdminPasswordRotationReason(a, "", now.Add(-time.Hour), now))
+
+	a.Spec.AdminPassword = &argoproj.ArgoCDAdminPasswordSpec{RotationInterval: &metav1.Duration{Duration: 24 * time.Hour}}
+	assert.Empty(t, getAdminPasswordRotationReason(a, "", now.Add(-time.Hour), now))
+	assert.NotEmpty(t, getAdminPasswordRotationReason(a, "", now.Add(-25*time.Hour), now))
+	assert.Empty(t, getAdminPasswordRotationReason(a, "", time.Time{}, now))
+
+	a.Spec.AdminPassword.RotationTrigger = "1"
+	assert.NotEmpty(t, getAdminPasswordRotationReason(a, "", now.Add(-time.Hour), now))
+	assert.Empty(t, getAdminPasswordRotationReason(a, "1", now.Add(-time.Hour), now))
+}
+
+func TestReconcileArgoCD_reconcileStatusAdminBootstrap(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{Provider: argoproj.SSOProviderTypeDex}
+		cr.Spec.AdminPassword = &argoproj.ArgoCDAdminPasswordSpec{DisableAfterSSOBootstrap: true}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// admin stays enabled until SSO is ready
+	a.Status.SSO = "Pending"
+	assert.NoError(t, r.reconcileStatusAdminBootstrap(a))
+	assert.False(t, isAdminDisabled(a))
+
+	a.Status.SSO = "Running"
+	assert.NoError(t, r.reconcileStatusAdminBootstrap(a))
+	assert.True(t, a.Status.AdminDisabledAfterSSOBootstrap)
+	assert.True(t, isAdminDisabled(a))
+
+	// admin stays disabled when SSO is no longer ready
+	a.Status.SSO = "Pending"
+	assert.NoError(t, r.reconcileStatusAdminBootstrap(a))
+	assert.True(t, isAdminDisabled(a))
+
+	// admin is enabled again once SSO is removed
+	a.Spec.SSO = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileStatusAdminBootstrap(a))
+	assert.False(t, a.Status.AdminDisabledAfterSSOBootstrap)
+	assert.False(t, isAdminDisabled(a))
+}
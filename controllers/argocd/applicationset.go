@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"strings"
 
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -91,6 +92,31 @@ func (r *ReconcileArgoCD) getArgoApplicationSetCommand(cr *argoproj.ArgoCD) []st
 		cmd = append(cmd, "--enable-scm-providers=false")
 	}
 
+	if cr.Spec.ApplicationSet.EnableProgressiveSyncs {
+		cmd = append(cmd, "--enable-progressive-syncs")
+	}
+
+	if policy := getApplicationSetPolicy(cr); policy != "" {
+		cmd = append(cmd, "--policy", policy)
+	}
+
+	if cr.Spec.ApplicationSet.EnablePolicyOverride != nil {
+		cmd = append(cmd, fmt.Sprintf("--enable-policy-override=%t", *cr.Spec.ApplicationSet.EnablePolicyOverride))
+	}
+
+	if reconciliations := getApplicationSetConcurrentReconciliations(cr); reconciliations != nil {
+		cmd = append(cmd, "--concurrent-reconciliations", fmt.Sprint(*reconciliations))
+	}
+
+	if cr.Spec.ApplicationSet.DryRun {
+		cmd = append(cmd, "--dry-run")
+	}
+
+	// only one of the replicas of an appset controller in HA reconciles the appsets
+	if replicas := getArgoCDApplicationSetControllerReplicas(cr); replicas != nil && *replicas > 1 {
+		cmd = append(cmd, "--enable-leader-election")
+	}
+
 	// ApplicationSet command arguments provided by the user
 	extraArgs := cr.Spec.ApplicationSet.ExtraCommandArgs
 	err = isMergable(extraArgs, cmd)
@@ -132,6 +158,18 @@ func (r *ReconcileArgoCD) reconcileApplicationSetController(cr *argoproj.ArgoCD)
 		return err
 	}
 
+	log.Info("reconciling applicationset metrics service")
+	if err := r.reconcileApplicationSetMetricsService(cr); err != nil {
+		return err
+	}
+
+	if IsPrometheusAPIAvailable() {
+		log.Info("reconciling applicationset metrics service monitor")
+		if err := r.reconcileApplicationSetServiceMonitor(cr); err != nil {
+			return err
+		}
+	}
+
 	// create clusterrole & clusterrolebinding if cluster-scoped ArgoCD
 	log.Info("reconciling applicationset clusterroles")
 	clusterrole, err := r.reconcileApplicationSetClusterRole(cr)
@@ -161,6 +199,9 @@ func (r *ReconcileArgoCD) reconcileApplicationSetController(cr *argoproj.ArgoCD)
 
 // reconcileApplicationControllerDeployment will ensure the Deployment resource is present for the ArgoCD Application Controller component.
 func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD, sa *corev1.ServiceAccount) error {
+	if skipSuspendedComponent(cr, argoproj.ArgoCDSuspendableComponentApplicationSet, "deployment") {
+		return nil
+	}
 
 	exists := false
 	existing := newDeploymentWithSuffix("applicationset-controller", "controller", cr)
@@ -178,6 +219,10 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 
 	setAppSetLabels(&deploy.ObjectMeta)
 
+	if replicas := getArgoCDApplicationSetControllerReplicas(cr); replicas != nil {
+		deploy.Spec.Replicas = replicas
+	}
+
 	podSpec := &deploy.Spec.Template.Spec
 
 	// sa would be nil when spec.applicationset.enabled = false
@@ -266,6 +311,13 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 	}
 	AddSeccompProfileForOpenShift(r.Client, podSpec)
 
+	setPodScheduling(cr, &deploy.Spec.Template.Spec, cr.Spec.ApplicationSet.Scheduling, nil)
+	setPodImagePullSecrets(cr, &deploy.Spec.Template.Spec)
+	rendered := deploy.DeepCopy()
+	if err := applyResourcePatches(deploy, cr.Spec.ApplicationSet.Patches); err != nil {
+		return err
+	}
+
 	if exists {
 
 		existingSpec := existing.Spec.Template.Spec
@@ -293,7 +345,21 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 			existing.Spec.Template.Spec.Tolerations = deploy.Spec.Template.Spec.Tolerations
 			existing.Spec.Template.Spec.Containers[0].SecurityContext = deploy.Spec.Template.Spec.Containers[0].SecurityContext
 			existing.Spec.Template.Annotations = deploy.Spec.Template.Annotations
+		}
+
+		if !reflect.DeepEqual(existing.Spec.Replicas, deploy.Spec.Replicas) {
+			existing.Spec.Replicas = deploy.Spec.Replicas
+			deploymentsDifferent = true
+		}
+
+		updatePodScheduling(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &deploymentsDifferent)
+		updatePodImagePullSecrets(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &deploymentsDifferent)
+
+		if err := updatePatchedResource(existing, rendered, cr.Spec.ApplicationSet.Patches, &deploymentsDifferent); err != nil {
+			return err
+		}
 
+		if deploymentsDifferent {
 			return r.Client.Update(context.TODO(), existing)
 		}
 		return nil // Deployment found with nothing to do, move along...
@@ -812,9 +878,9 @@ func getApplicationSetContainerImage(cr *argoproj.ArgoCD) string {
 
 	// If an env var is specified then use that, but don't override the spec values (if they are present)
 	if e := os.Getenv(common.ArgoCDImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
+		return argoutil.GetImageReference(cr, e)
 	}
-	return argoutil.CombineImageTag(img, tag)
+	return argoutil.GetImageReference(cr, argoutil.CombineImageTag(img, tag))
 }
 
 // getApplicationSetResources will return the ResourceRequirements for the Application Sets container.
@@ -883,6 +949,112 @@ func (r *ReconcileArgoCD) reconcileApplicationSetService(cr *argoproj.ArgoCD) er
 	return r.Client.Create(context.TODO(), svc)
 }
 
+// reconcileApplicationSetMetricsService will ensure that the Service for the ApplicationSet controller metrics is present.
+func (r *ReconcileArgoCD) reconcileApplicationSetMetricsService(cr *argoproj.ArgoCD) error {
+	svc := newServiceWithSuffix(common.ApplicationSetMetricsServiceNameSuffix, common.ApplicationSetServiceNameSuffix, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
+		if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.IsEnabled() {
+			log.Info(fmt.Sprintf("Deleting applicationset controller metrics service %s as applicationset is disabled", svc.Name))
+			return r.Client.Delete(context.TODO(), svc)
+		}
+		return nil // Service found, do nothing
+	}
+
+	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.IsEnabled() {
+		return nil
+	}
+
+	svc.Spec.Selector = map[string]string{
+		common.ArgoCDKeyName: nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr),
+	}
+
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       common.ArgoCDKeyMetrics,
+			Port:       common.ApplicationSetControllerMetricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ApplicationSetControllerMetricsPort),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), svc)
+}
+
+// reconcileApplicationSetServiceMonitor will ensure that the ServiceMonitor is present for the ApplicationSet controller metrics Service.
+func (r *ReconcileArgoCD) reconcileApplicationSetServiceMonitor(cr *argoproj.ArgoCD) error {
+	enabled := cr.Spec.Prometheus.Enabled && cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.IsEnabled()
+
+	sm := newServiceMonitorWithSuffix(common.ApplicationSetMetricsServiceNameSuffix, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
+		if !enabled {
+			// ServiceMonitor exists but prometheus or applicationset has been disabled, delete the ServiceMonitor
+			return r.Client.Delete(context.TODO(), sm)
+		}
+		return nil // ServiceMonitor found, do nothing
+	}
+
+	if !enabled {
+		return nil
+	}
+
+	sm.Spec.Selector = metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			common.ArgoCDKeyName: nameWithSuffix(common.ApplicationSetMetricsServiceNameSuffix, cr),
+		},
+	}
+	sm.Spec.Endpoints = []monitoringv1.Endpoint{
+		{
+			Port: common.ArgoCDKeyMetrics,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), sm)
+}
+
+// getApplicationSetPolicy returns the policy of the applicationset controller, or an empty string when the policy is not
+// set or is not valid.
+func getApplicationSetPolicy(cr *argoproj.ArgoCD) string {
+	switch policy := cr.Spec.ApplicationSet.Policy; policy {
+	case "":
+		return ""
+	case "sync", "create-only", "create-update", "create-delete":
+		return policy
+	default:
+		log.Info(fmt.Sprintf("Invalid applicationset policy %s, valid options are sync, create-only, create-update and create-delete. Ignoring the policy.", policy))
+		return ""
+	}
+}
+
+// getApplicationSetConcurrentReconciliations returns the maximum number of concurrent reconciliations of the
+// applicationset controller, or nil when it is not set or is out of the range accepted by the controller.
+func getApplicationSetConcurrentReconciliations(cr *argoproj.ArgoCD) *int32 {
+	reconciliations := cr.Spec.ApplicationSet.ConcurrentReconciliations
+	if reconciliations == nil {
+		return nil
+	}
+	if *reconciliations < 1 || *reconciliations > 100 {
+		log.Info(fmt.Sprintf("Invalid applicationset concurrent reconciliations %d, value should be between 1 and 100. Ignoring the value.", *reconciliations))
+		return nil
+	}
+	return reconciliations
+}
+
+// getArgoCDApplicationSetControllerReplicas will return the size value for the applicationset controller replica count if it
+// has been set in argocd CR. Otherwise, nil is returned if the replicas is not set in the argocd CR or
+// replicas value is < 0.
+func getArgoCDApplicationSetControllerReplicas(cr *argoproj.ArgoCD) *int32 {
+	if cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.Replicas != nil && *cr.Spec.ApplicationSet.Replicas >= 0 {
+		return cr.Spec.ApplicationSet.Replicas
+	}
+	return nil
+}
+
 // Returns the name of the role/rolebinding for the source namespaces for applicationset-controller in the format of "argocdName-argocdNamespace-applicationset"
 func getResourceNameForApplicationSetSourceNamespaces(cr *argoproj.ArgoCD) string {
 	return fmt.Sprintf("%s-%s-applicationset", cr.Name, cr.Namespace)
@@ -20,6 +20,7 @@ import (
 	"sort"
 	"testing"
 
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
@@ -643,6 +644,56 @@ func TestReconcileApplicationSet_Deployments_Command(t *testing.T) {
 	}
 }
 
+func TestReconcileApplicationSet_HighAvailability(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+		cr.Spec.Prometheus.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	defaultPrometheusAPIFound := prometheusAPIFound
+	prometheusAPIFound = true
+	defer func() { prometheusAPIFound = defaultPrometheusAPIFound }()
+
+	// a single replica runs without leader election
+	assert.NoError(t, r.reconcileApplicationSetController(a))
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Nil(t, deployment.Spec.Replicas)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--enable-leader-election")
+
+	// the metrics are exposed by a dedicated service, scraped by a service monitor
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller-metrics", Namespace: a.Namespace}, svc))
+	assert.Equal(t, int32(common.ApplicationSetControllerMetricsPort), svc.Spec.Ports[0].Port)
+	sm := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller-metrics", Namespace: a.Namespace}, sm))
+	assert.Equal(t, svc.Labels[common.ArgoCDKeyName], sm.Spec.Selector.MatchLabels[common.ArgoCDKeyName])
+	assert.Equal(t, common.ArgoCDKeyMetrics, sm.Spec.Endpoints[0].Port)
+
+	// several replicas elect a leader
+	replicas := int32(3)
+	a.Spec.ApplicationSet.Replicas = &replicas
+	assert.NoError(t, r.reconcileApplicationSetController(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, &replicas, deployment.Spec.Replicas)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--enable-leader-election")
+
+	// the service monitor is removed when prometheus is disabled
+	a.Spec.Prometheus.Enabled = false
+	assert.NoError(t, r.reconcileApplicationSetController(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller-metrics", Namespace: a.Namespace}, sm)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
 func TestReconcileApplicationSet_ServiceAccount(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -1099,6 +1150,62 @@ func TestArgoCDApplicationSetCommand(t *testing.T) {
 	assert.Equal(t, baseCommand, deployment.Spec.Template.Spec.Containers[0].Command)
 }
 
+func TestArgoCDApplicationSetCommand_Settings(t *testing.T) {
+	enabled := true
+	reconciliations := int32(20)
+	invalidReconciliations := int32(500)
+
+	tests := []struct {
+		name        string
+		appSet      *argoproj.ArgoCDApplicationSet
+		expectedCmd []string
+	}{
+		{
+			name: "all settings",
+			appSet: &argoproj.ArgoCDApplicationSet{
+				EnableProgressiveSyncs:    true,
+				Policy:                    "create-update",
+				EnablePolicyOverride:      &enabled,
+				ConcurrentReconciliations: &reconciliations,
+				DryRun:                    true,
+			},
+			expectedCmd: []string{"--enable-progressive-syncs", "--policy", "create-update", "--enable-policy-override=true", "--concurrent-reconciliations", "20", "--dry-run"},
+		},
+		{
+			name: "invalid settings are ignored",
+			appSet: &argoproj.ArgoCDApplicationSet{
+				Policy:                    "delete-only",
+				ConcurrentReconciliations: &invalidReconciliations,
+			},
+			expectedCmd: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD()
+			a.Spec.ApplicationSet = test.appSet
+
+			resObjs := []client.Object{a}
+			subresObjs := []client.Object{a}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			baseCommand := []string{
+				"entrypoint.sh",
+				"argocd-applicationset-controller",
+				"--argocd-repo-server",
+				"argocd-repo-server.argocd.svc.cluster.local:8081",
+				"--loglevel",
+				"info",
+			}
+			assert.Equal(t, append(baseCommand, test.expectedCmd...), r.getArgoApplicationSetCommand(a))
+		})
+	}
+}
+
 func TestArgoCDApplicationSetEnv(t *testing.T) {
 	a := makeTestArgoCD()
 	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
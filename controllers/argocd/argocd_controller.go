@@ -21,8 +21,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/prometheus/client_golang/prometheus"
-
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 
 	corev1 "k8s.io/api/core/v1"
@@ -51,6 +49,10 @@ type ReconcileArgoCD struct {
 	ManagedApplicationSetSourceNamespaces map[string]string
 	// Stores label selector used to reconcile a subset of ArgoCD
 	LabelSelector string
+	// planning is set when the reconciler only records the operations needed to apply an ArgoCD spec
+	planning bool
+	// digestResolver resolves image references to digests, the registries are queried when not set
+	digestResolver imageDigestResolver
 }
 
 var log = logr.Log.WithName("controller_argocd")
@@ -73,6 +75,7 @@ var ActiveInstanceMap = make(map[string]string)
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=*
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;delete;get;list;patch;update;watch;
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheuses;prometheusrules;servicemonitors,verbs=*
+//+kubebuilder:rbac:groups=grafana.integreatly.org,resources=grafanadashboards,verbs=*
 //+kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=*
 //+kubebuilder:rbac:groups=argoproj.io,resources=applications;appprojects,verbs=*
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=*,verbs=*
@@ -156,8 +159,7 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		delete(ActiveInstanceMap, argocd.Namespace)
 		ActiveInstancesByPhase.WithLabelValues(newPhase).Dec()
 		ActiveInstancesTotal.Dec()
-		ActiveInstanceReconciliationCount.DeleteLabelValues(argocd.Namespace)
-		ReconcileTime.DeletePartialMatch(prometheus.Labels{"namespace": argocd.Namespace})
+		deleteInstanceMetrics(argocd.Namespace)
 
 		if argocd.IsDeletionFinalizerPresent() {
 			if err := r.deleteClusterResources(argocd); err != nil {
@@ -200,9 +202,28 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, err
 	}
 
+	// only report the operations needed to apply the spec while the instance is in plan mode
+	if isPlanMode(argocd) {
+		reqLogger.Info("ArgoCD is in plan mode, skipping changes to its resources")
+		return reconcile.Result{}, r.reconcilePlan(argocd)
+	}
+	if err = r.reconcileStatusPlan(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// only report the status while the instance is suspended, so that manual changes to its resources are kept
+	if argocd.Spec.Suspend {
+		reqLogger.Info("ArgoCD is suspended, skipping reconciliation of its resources")
+		if err = r.reconcileStatus(argocd); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: r.getPodHealthRecheck(argocd)}, r.reconcileStatusSuspended(argocd)
+	}
+
 	if err = r.setManagedNamespaces(argocd); err != nil {
 		return reconcile.Result{}, err
 	}
+	ManagedNamespacesTotal.WithLabelValues(argocd.Namespace).Set(float64(len(r.ManagedNamespaces.Items)))
 
 	if err = r.setManagedSourceNamespaces(argocd); err != nil {
 		return reconcile.Result{}, err
@@ -217,13 +238,29 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, err
 	}
 
+	// Requeue once the first auto renewed API token of the local users expires, the admin password is to be rotated,
+	// or the pods that are not ready are to be inspected again
+	requeueAfter := r.getLocalUserTokenRenewal(argocd)
+	if rotation := r.getAdminPasswordRotation(argocd); rotation > 0 && (requeueAfter == 0 || rotation < requeueAfter) {
+		requeueAfter = rotation
+	}
+	if recheck := r.getPodHealthRecheck(argocd); recheck > 0 && (requeueAfter == 0 || recheck < requeueAfter) {
+		requeueAfter = recheck
+	}
+	if requeueAfter > 0 {
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	// Return and don't requeue
 	return reconcile.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconcileArgoCD) SetupWithManager(mgr ctrl.Manager) error {
+	// count the write requests made by the operator
+	r.Client = newMetricsClient(r.Client)
+
 	bldr := ctrl.NewControllerManagedBy(mgr)
-	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.applicationSetSCMTLSConfigMapMapper)
+	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.configMapResourceMapper)
 	return bldr.Complete(r)
 }
@@ -39,10 +39,19 @@ func (r *ReconcileArgoCD) createRBACConfigMap(cm *corev1.ConfigMap, cr *argoproj
 	data[common.ArgoCDKeyRBACScopes] = getRBACScopes(cr)
 	cm.Data = data
 
+	policies, condition, err := r.getDesiredRBACPolicies(cr)
+	if err != nil {
+		return err
+	}
+	setRBACPolicies(cm, policies)
+
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
 	}
-	return r.Client.Create(context.TODO(), cm)
+	if err := r.Client.Create(context.TODO(), cm); err != nil {
+		return err
+	}
+	return r.reconcileStatusRBACPolicy(cr, condition)
 }
 
 // getApplicationInstanceLabelKey will return the application instance label key  for the given ArgoCD.
@@ -147,6 +156,8 @@ func getRBACScopes(cr *argoproj.ArgoCD) string {
 	scopes := common.ArgoCDDefaultRBACScopes
 	if cr.Spec.RBAC.Scopes != nil {
 		scopes = *cr.Spec.RBAC.Scopes
+	} else if groupsClaim := getOIDCGroupsClaim(cr); groupsClaim != "" {
+		scopes = fmt.Sprintf("[%s]", groupsClaim)
 	}
 	return scopes
 }
@@ -157,6 +168,9 @@ func getResourceHealthChecks(cr *argoproj.ArgoCD) map[string]string {
 	if cr.Spec.ResourceHealthChecks != nil {
 		resourceHealthChecks := cr.Spec.ResourceHealthChecks
 		for _, healthCustomization := range resourceHealthChecks {
+			if healthCustomization.CheckFrom != nil {
+				continue
+			}
 			if healthCustomization.Group != "" {
 				healthCustomization.Group += "_"
 			}
@@ -173,7 +187,7 @@ func getResourceIgnoreDifferences(cr *argoproj.ArgoCD) (map[string]string, error
 	ignoreDiff := make(map[string]string)
 	if cr.Spec.ResourceIgnoreDifferences != nil {
 		resourceIgnoreDiff := cr.Spec.ResourceIgnoreDifferences
-		if !reflect.DeepEqual(resourceIgnoreDiff.All, &argoproj.IgnoreDifferenceCustomization{}) {
+		if resourceIgnoreDiff.AllFrom == nil && !reflect.DeepEqual(resourceIgnoreDiff.All, &argoproj.IgnoreDifferenceCustomization{}) {
 			subkey := "resource.customizations.ignoreDifferences.all"
 			bytes, err := yaml.Marshal(resourceIgnoreDiff.All)
 			if err != nil {
@@ -183,6 +197,9 @@ func getResourceIgnoreDifferences(cr *argoproj.ArgoCD) (map[string]string, error
 			ignoreDiff[subkey] = subvalue
 		}
 		for _, ignoreDiffCustomization := range resourceIgnoreDiff.ResourceIdentifiers {
+			if ignoreDiffCustomization.CustomizationFrom != nil {
+				continue
+			}
 			if ignoreDiffCustomization.Group != "" {
 				ignoreDiffCustomization.Group += "_"
 			}
@@ -204,6 +221,9 @@ func getResourceActions(cr *argoproj.ArgoCD) map[string]string {
 	if cr.Spec.ResourceActions != nil {
 		resourceAction := cr.Spec.ResourceActions
 		for _, actionCustomization := range resourceAction {
+			if actionCustomization.ActionFrom != nil {
+				continue
+			}
 			if actionCustomization.Group != "" {
 				actionCustomization.Group += "_"
 			}
@@ -310,35 +330,35 @@ func newConfigMapWithName(name string, cr *argoproj.ArgoCD) *corev1.ConfigMap {
 
 // reconcileConfigMaps will ensure that all ArgoCD ConfigMaps are present.
 func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
-	if err := r.reconcileArgoConfigMap(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileArgoConfigMap", func() error { return r.reconcileArgoConfigMap(cr) }); err != nil {
 		return err
 	}
 
-	if err := r.reconcileRedisConfiguration(cr, useTLSForRedis); err != nil {
+	if err := reconcileStep(cr, "reconcileRedisConfiguration", func() error { return r.reconcileRedisConfiguration(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
-	if err := r.reconcileRBAC(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileRBAC", func() error { return r.reconcileRBAC(cr) }); err != nil {
 		return err
 	}
 
-	if err := r.reconcileSSHKnownHosts(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileSSHKnownHosts", func() error { return r.reconcileSSHKnownHosts(cr) }); err != nil {
 		return err
 	}
 
-	if err := r.reconcileTLSCerts(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileTLSCerts", func() error { return r.reconcileTLSCerts(cr) }); err != nil {
 		return err
 	}
 
-	if err := r.reconcileGrafanaConfiguration(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileGrafanaConfiguration", func() error { return r.reconcileGrafanaConfiguration(cr) }); err != nil {
 		return err
 	}
 
-	if err := r.reconcileGrafanaDashboards(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileGrafanaDashboards", func() error { return r.reconcileGrafanaDashboards(cr) }); err != nil {
 		return err
 	}
 
-	return r.reconcileGPGKeysConfigMap(cr)
+	return reconcileStep(cr, "reconcileGPGKeysConfigMap", func() error { return r.reconcileGPGKeysConfigMap(cr) })
 }
 
 // reconcileCAConfigMap will ensure that the Certificate Authority ConfigMap is present.
@@ -373,7 +393,7 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 
 	cm.Data[common.ArgoCDKeyApplicationInstanceLabelKey] = getApplicationInstanceLabelKey(cr)
 	cm.Data[common.ArgoCDKeyConfigManagementPlugins] = getConfigManagementPlugins(cr)
-	cm.Data[common.ArgoCDKeyAdminEnabled] = fmt.Sprintf("%t", !cr.Spec.DisableAdmin)
+	cm.Data[common.ArgoCDKeyAdminEnabled] = fmt.Sprintf("%t", !isAdminDisabled(cr))
 	cm.Data[common.ArgoCDKeyGATrackingID] = getGATrackingID(cr)
 	cm.Data[common.ArgoCDKeyGAAnonymizeUsers] = fmt.Sprint(cr.Spec.GAAnonymizeUsers)
 	cm.Data[common.ArgoCDKeyHelpChatURL] = getHelpChatURL(cr)
@@ -387,25 +407,20 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 	}
 
 	cm.Data[common.ArgoCDKeyOIDCConfig] = getOIDCConfig(cr)
-
-	if c := getResourceHealthChecks(cr); c != nil {
-		for k, v := range c {
-			cm.Data[k] = v
+	if isOIDCProvider(cr) {
+		oidcConfig, err := r.getOIDCProviderConfig(cr)
+		if err != nil {
+			return err
 		}
+		cm.Data[common.ArgoCDKeyOIDCConfig] = oidcConfig
 	}
 
-	if c, err := getResourceIgnoreDifferences(cr); c != nil && err == nil {
-		for k, v := range c {
-			cm.Data[k] = v
-		}
-	} else {
+	customizations, customizationsCondition, err := r.getDesiredResourceCustomizations(cr)
+	if err != nil {
 		return err
 	}
-
-	if c := getResourceActions(cr); c != nil {
-		for k, v := range c {
-			cm.Data[k] = v
-		}
+	for k, v := range customizations {
+		cm.Data[k] = v
 	}
 
 	cm.Data[common.ArgoCDKeyResourceExclusions] = getResourceExclusions(cr)
@@ -419,15 +434,9 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 
 	// create dex config if dex is enabled through `.spec.sso`
 	if UseDex(cr) {
-		dexConfig := getDexConfig(cr)
-
-		// Append the default OpenShift dex config if the openShiftOAuth is requested through `.spec.sso.dex`.
-		if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.OpenShiftOAuth {
-			cfg, err := r.getOpenShiftDexConfig(cr)
-			if err != nil {
-				return err
-			}
-			dexConfig = cfg
+		dexConfig, err := r.getDesiredDexConfig(cr)
+		if err != nil {
+			return err
 		}
 		cm.Data[common.ArgoCDKeyDexConfig] = dexConfig
 	}
@@ -441,6 +450,22 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 		}
 	}
 
+	extensionConfig, err := getServerExtensionConfig(cr)
+	if err != nil {
+		return err
+	}
+	if extensionConfig != "" {
+		cm.Data[common.ArgoCDKeyExtensionConfig] = extensionConfig
+	}
+
+	for _, settings := range []map[string]string{getAccountSettings(cr), getExecSettings(cr), getReconciliationSettings(cr), getUISettings(cr)} {
+		for k, v := range settings {
+			cm.Data[k] = v
+		}
+	}
+
+	// ExtraConfig takes precedence over the typed settings, the overridden ones are reported in the status
+	overrides := getExtraConfigOverrides(cm.Data, cr.Spec.ExtraConfig)
 	if len(cr.Spec.ExtraConfig) > 0 {
 		for k, v := range cr.Spec.ExtraConfig {
 			cm.Data[k] = v
@@ -484,12 +509,22 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 		}
 
 		if changed {
-			return r.Client.Update(context.TODO(), existingCM)
+			if err := r.Client.Update(context.TODO(), existingCM); err != nil {
+				return err
+			}
+		}
+		if err := r.reconcileStatusResourceCustomizations(cr, customizationsCondition); err != nil {
+			return err
 		}
-		return nil // Do nothing as there is no change in the configmap.
+		return r.reconcileStatusExtraConfigOverrides(cr, overrides)
 	}
-	return r.Client.Create(context.TODO(), cm)
-
+	if err := r.Client.Create(context.TODO(), cm); err != nil {
+		return err
+	}
+	if err := r.reconcileStatusResourceCustomizations(cr, customizationsCondition); err != nil {
+		return err
+	}
+	return r.reconcileStatusExtraConfigOverrides(cr, overrides)
 }
 
 // reconcileGrafanaConfiguration will ensure that the Grafana configuration ConfigMap is present.
@@ -504,16 +539,18 @@ func (r *ReconcileArgoCD) reconcileGrafanaConfiguration(cr *argoproj.ArgoCD) err
 	return nil
 }
 
-// reconcileGrafanaDashboards will ensure that the Grafana dashboards ConfigMap is present.
+// reconcileGrafanaDashboards will ensure that the Grafana dashboard ConfigMaps and GrafanaDashboard resources are
+// present when dashboards are enabled in the monitoring spec.
 func (r *ReconcileArgoCD) reconcileGrafanaDashboards(cr *argoproj.ArgoCD) error {
 	//nolint:staticcheck
-	if !cr.Spec.Grafana.Enabled {
-		return nil // Grafana not enabled, do nothing.
+	if cr.Spec.Grafana.Enabled {
+		log.Info(grafanaDeprecatedWarning)
 	}
 
-	log.Info(grafanaDeprecatedWarning)
-
-	return nil
+	if err := r.reconcileGrafanaDashboardConfigMaps(cr); err != nil {
+		return err
+	}
+	return r.reconcileGrafanaDashboardResources(cr)
 }
 
 // reconcileRBAC will ensure that the ArgoCD RBAC ConfigMap is present.
@@ -547,16 +584,27 @@ func (r *ReconcileArgoCD) reconcileRBACConfigMap(cm *corev1.ConfigMap, cr *argop
 	}
 
 	// Scopes
-	if cr.Spec.RBAC.Scopes != nil && cm.Data[common.ArgoCDKeyRBACScopes] != *cr.Spec.RBAC.Scopes {
-		cm.Data[common.ArgoCDKeyRBACScopes] = *cr.Spec.RBAC.Scopes
+	if (cr.Spec.RBAC.Scopes != nil || getOIDCGroupsClaim(cr) != "") && cm.Data[common.ArgoCDKeyRBACScopes] != getRBACScopes(cr) {
+		cm.Data[common.ArgoCDKeyRBACScopes] = getRBACScopes(cr)
+		changed = true
+	}
+
+	// Policies rendered from roles, bindings and policy fragments
+	policies, condition, err := r.getDesiredRBACPolicies(cr)
+	if err != nil {
+		return err
+	}
+	if setRBACPolicies(cm, policies) {
 		changed = true
 	}
 
 	if changed {
 		// TODO: Reload server (and dex?) if RBAC settings change?
-		return r.Client.Update(context.TODO(), cm)
+		if err := r.Client.Update(context.TODO(), cm); err != nil {
+			return err
+		}
 	}
-	return nil // ConfigMap exists and nothing to do, move along...
+	return r.reconcileStatusRBACPolicy(cr, condition)
 }
 
 // validateOwnerReferences checks if OwnerReferences is changed
@@ -453,7 +453,7 @@ func TestReconcileArgoCD_reconcileArgoConfigMap_withDexConnector(t *testing.T) {
 			// verify that the dex config in the CR matches the config from the argocd-cm
 			if a.Spec.SSO.Dex.Config != "" {
 				expectedCfg := make(map[string]interface{})
-				expectedCfgStr, err := r.getOpenShiftDexConfig(a)
+				expectedCfgStr, err := r.getDesiredDexConfig(a)
 				assert.NoError(t, err)
 
 				err = yaml.Unmarshal([]byte(expectedCfgStr), expectedCfg)
@@ -983,6 +983,7 @@ func TestReconcileArgoCD_reconcileArgoConfigMap_withExtraConfig(t *testing.T) {
 	// Verify that ExtraConfig overrides FirstClass entries
 	a.Spec.DisableAdmin = true
 	a.Spec.ExtraConfig["admin.enabled"] = "true"
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
 
 	err = r.reconcileArgoConfigMap(a)
 	assert.NoError(t, err)
@@ -998,6 +999,7 @@ func TestReconcileArgoCD_reconcileArgoConfigMap_withExtraConfig(t *testing.T) {
 	// Verify that deletion of a field from ExtraConfig does not delete any existing configuration
 	// created by FirstClass citizens.
 	a.Spec.ExtraConfig = make(map[string]string, 0)
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
 
 	err = r.reconcileArgoConfigMap(a)
 	assert.NoError(t, err)
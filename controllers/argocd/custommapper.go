@@ -12,6 +12,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -93,6 +94,10 @@ func (r *ReconcileArgoCD) isUserManagedSecret(ctx context.Context, o client.Obje
 		ok = true
 	} else if argocd.Spec.ApplicationSet != nil && argocd.Spec.ApplicationSet.WebhookServer.Route.UseExternalCertificate() && argocd.Spec.ApplicationSet.WebhookServer.Route.TLS.ExternalCertificate.Name == o.GetName() {
 		ok = true
+	} else if isDexConnectorSecret(&argocd, o.GetName()) {
+		ok = true
+	} else if isOIDCClientSecret(&argocd, o.GetName()) {
+		ok = true
 	}
 
 	return namespacedName, ok
@@ -191,13 +196,19 @@ func (r *ReconcileArgoCD) namespaceResourceMapper(ctx context.Context, o client.
 	} else {
 		// If the namespace does not have the expected managed-by label,
 		// iterate through each ArgoCD instance to identify if the observed namespace
-		// matches any configured sourceNamespace pattern. If a match is found,
+		// matches any configured sourceNamespace pattern or selector, or is still
+		// labelled as a source namespace of the instance. If a match is found,
 		// generate a reconcile request for the instances.
 		if err := r.Client.List(ctx, argocds, &client.ListOptions{}); err != nil {
 			return result
 		}
 		for _, argocd := range argocds.Items {
-			if glob.MatchStringInList(argocd.Spec.SourceNamespaces, namespaceName, glob.GLOB) {
+			selected, err := isSelectedSourceNamespace(&argocd, labels)
+			if err != nil {
+				log.Error(err, fmt.Sprintf("failed to match namespace %s against the source namespace selector of %s/%s", namespaceName, argocd.Namespace, argocd.Name))
+			}
+			if selected || labels[common.ArgoCDManagedByClusterArgoCDLabel] == argocd.Namespace ||
+				glob.MatchStringInList(argocd.Spec.SourceNamespaces, namespaceName, glob.GLOB) {
 				namespacedName := client.ObjectKey{
 					Name:      argocd.Name,
 					Namespace: argocd.Namespace,
@@ -239,6 +250,30 @@ func (r *ReconcileArgoCD) clusterSecretResourceMapper(ctx context.Context, o cli
 	return result
 }
 
+// configMapResourceMapper maps a watch event on a configmap that is not owned by an ArgoCD back to the ArgoCD objects
+// that consume it: as the ApplicationSet SCM TLS certificates, as an RBAC policy fragment, as resource customizations
+// or as the root CA of the oidc SSO provider.
+func (r *ReconcileArgoCD) configMapResourceMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	seen := map[reconcile.Request]bool{}
+	for _, mapper := range []func(context.Context, client.Object) []reconcile.Request{
+		r.applicationSetSCMTLSConfigMapMapper,
+		r.rbacPolicyFragmentConfigMapMapper,
+		r.resourceCustomizationConfigMapMapper,
+		r.oidcRootCAConfigMapMapper,
+	} {
+		for _, request := range mapper(ctx, o) {
+			if !seen[request] {
+				seen[request] = true
+				result = append(result, request)
+			}
+		}
+	}
+
+	return result
+}
+
 // applicationSetSCMTLSConfigMapMapper maps a watch event on a configmap with name "argocd-appset-gitlab-scm-tls-certs-cm",
 // back to the ArgoCD object that we want to reconcile.
 func (r *ReconcileArgoCD) applicationSetSCMTLSConfigMapMapper(ctx context.Context, o client.Object) []reconcile.Request {
@@ -266,3 +301,106 @@ func (r *ReconcileArgoCD) applicationSetSCMTLSConfigMapMapper(ctx context.Contex
 
 	return result
 }
+
+// selectsLabels will return true when the given label selector is set and matches the given labels.
+func selectsLabels(labelSelector *v1.LabelSelector, lbls map[string]string) bool {
+	if labelSelector == nil {
+		return false
+	}
+	selector, err := v1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(lbls))
+}
+
+// rbacPolicyFragmentConfigMapMapper maps a watch event on a configmap back to the ArgoCD objects in the same namespace
+// whose policy fragment selector matches its labels, so that fragments are merged as they are added, changed or
+// deselected. Update events are mapped for both the old and the new labels of the configmap.
+func (r *ReconcileArgoCD) rbacPolicyFragmentConfigMapMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	if o.GetName() == common.ArgoCDRBACConfigMapName {
+		return result
+	}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for _, argocd := range argocds.Items {
+		if !selectsLabels(argocd.Spec.RBAC.PolicyFragmentSelector, o.GetLabels()) {
+			continue
+		}
+		result = append(result, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      argocd.Name,
+				Namespace: argocd.Namespace,
+			},
+		})
+	}
+
+	return result
+}
+
+// resourceCustomizationConfigMapMapper maps a watch event on a configmap back to the ArgoCD objects in the same
+// namespace whose resource customization selector matches its labels or that load resource customizations from it,
+// so that customizations are merged as they are added, changed or deselected.
+func (r *ReconcileArgoCD) resourceCustomizationConfigMapMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	if o.GetName() == common.ArgoCDConfigMapName {
+		return result
+	}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for _, argocd := range argocds.Items {
+		referenced := selectsLabels(argocd.Spec.ResourceCustomizationSelector, o.GetLabels())
+		for _, ref := range getResourceCustomizationRefs(&argocd) {
+			if ref.ref.Name == o.GetName() {
+				referenced = true
+			}
+		}
+		if !referenced {
+			continue
+		}
+		result = append(result, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      argocd.Name,
+				Namespace: argocd.Namespace,
+			},
+		})
+	}
+
+	return result
+}
+
+// oidcRootCAConfigMapMapper maps a watch event on a configmap back to the ArgoCD objects in the same namespace that
+// read the root CA of their oidc SSO provider from it, so that oidc.config is rendered again as the CA is rotated.
+func (r *ReconcileArgoCD) oidcRootCAConfigMapMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+
+	for _, argocd := range argocds.Items {
+		if !isOIDCRootCAConfigMap(&argocd, o.GetName()) {
+			continue
+		}
+		result = append(result, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      argocd.Name,
+				Namespace: argocd.Namespace,
+			},
+		})
+	}
+
+	return result
+}
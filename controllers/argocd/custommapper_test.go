@@ -1027,3 +1027,68 @@ func TestReconcileArgoCD_tlsSecretMapperUserManagedSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileArgoCD_namespaceResourceMapperForSourceNamespaceSelector(t *testing.T) {
+	argocd1 := makeTestArgoCD()
+	argocd1.Spec.SourceNamespaceSelector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"team": "a"},
+	}
+	resObjs := []client.Object{argocd1}
+	subresObjs := []client.Object{argocd1}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	want := []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      argocd1.Name,
+				Namespace: argocd1.Namespace,
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		o    client.Object
+		want []reconcile.Request
+	}{
+		{
+			name: "Reconcile for selected namespace",
+			o: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "payments",
+					Labels: map[string]string{"team": "a"},
+				},
+			},
+			want: want,
+		},
+		{
+			name: "Reconcile for namespace that lost the label",
+			o: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "payments",
+					Labels: map[string]string{common.ArgoCDManagedByClusterArgoCDLabel: argocd1.Namespace},
+				},
+			},
+			want: want,
+		},
+		{
+			name: "No Reconcile for namespace of another team",
+			o: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "billing",
+					Labels: map[string]string{"team": "b"},
+				},
+			},
+			want: []reconcile.Request{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.want, r.namespaceResourceMapper(context.TODO(), tt.o))
+		})
+	}
+}
@@ -141,19 +141,19 @@ func getArgoImportContainerEnv(cr *argoprojv1alpha1.ArgoCDExport) []corev1.EnvVa
 	return env
 }
 
-// getArgoImportContainerImage will return the container image for the Argo CD import process.
-func getArgoImportContainerImage(cr *argoprojv1alpha1.ArgoCDExport) string {
+// getArgoImportContainerImage will return the container image for the Argo CD import process of the given ArgoCD.
+func getArgoImportContainerImage(cr *argoproj.ArgoCD, export *argoprojv1alpha1.ArgoCDExport) string {
 	img := common.ArgoCDDefaultExportJobImage
-	if len(cr.Spec.Image) > 0 {
-		img = cr.Spec.Image
+	if len(export.Spec.Image) > 0 {
+		img = export.Spec.Image
 	}
 
 	tag := common.ArgoCDDefaultExportJobVersion
-	if len(cr.Spec.Version) > 0 {
-		tag = cr.Spec.Version
+	if len(export.Spec.Version) > 0 {
+		tag = export.Spec.Version
 	}
 
-	return argoutil.CombineImageTag(img, tag)
+	return argoutil.GetImageReference(cr, argoutil.CombineImageTag(img, tag))
 }
 
 // getArgoImportVolumeMounts will return the VolumneMounts for the given ArgoCDExport.
@@ -253,6 +253,8 @@ func getArgoRepoCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	cmd = append(cmd, "--logformat")
 	cmd = append(cmd, getLogFormat(cr.Spec.Repo.LogFormat))
 
+	cmd = append(cmd, getTracingCommandArgs(cr)...)
+
 	// *** NOTE ***
 	// Do Not add any new default command line arguments below this.
 	extraArgs := cr.Spec.Repo.ExtraRepoCommandArgs
@@ -321,6 +323,12 @@ func getArgoServerCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	cmd = append(cmd, "--logformat")
 	cmd = append(cmd, getLogFormat(cr.Spec.Server.LogFormat))
 
+	cmd = append(cmd, getTracingCommandArgs(cr)...)
+
+	if hasServerProxyExtensions(cr) {
+		cmd = append(cmd, "--enable-proxy-extension")
+	}
+
 	extraArgs := cr.Spec.Server.ExtraCommandArgs
 	err := isMergable(extraArgs, cmd)
 	if err != nil {
@@ -417,31 +425,31 @@ func newDeploymentWithSuffix(suffix string, component string, cr *argoproj.ArgoC
 // reconcileDeployments will ensure that all Deployment resources are present for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileDeployments(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
 
-	if err := r.reconcileDexDeployment(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileDexDeployment", func() error { return r.reconcileDexDeployment(cr) }); err != nil {
 		log.Error(err, "error reconciling dex deployment")
 	}
 
-	err := r.reconcileRedisDeployment(cr, useTLSForRedis)
+	err := reconcileStep(cr, "reconcileRedisDeployment", func() error { return r.reconcileRedisDeployment(cr, useTLSForRedis) })
 	if err != nil {
 		return err
 	}
 
-	err = r.reconcileRedisHAProxyDeployment(cr)
+	err = reconcileStep(cr, "reconcileRedisHAProxyDeployment", func() error { return r.reconcileRedisHAProxyDeployment(cr) })
 	if err != nil {
 		return err
 	}
 
-	err = r.reconcileRepoDeployment(cr, useTLSForRedis)
+	err = reconcileStep(cr, "reconcileRepoDeployment", func() error { return r.reconcileRepoDeployment(cr, useTLSForRedis) })
 	if err != nil {
 		return err
 	}
 
-	err = r.reconcileServerDeployment(cr, useTLSForRedis)
+	err = reconcileStep(cr, "reconcileServerDeployment", func() error { return r.reconcileServerDeployment(cr, useTLSForRedis) })
 	if err != nil {
 		return err
 	}
 
-	err = r.reconcileGrafanaDeployment(cr)
+	err = reconcileStep(cr, "reconcileGrafanaDeployment", func() error { return r.reconcileGrafanaDeployment(cr) })
 	if err != nil {
 		return err
 	}
@@ -461,6 +469,9 @@ func (r *ReconcileArgoCD) reconcileGrafanaDeployment(cr *argoproj.ArgoCD) error
 
 // reconcileRedisDeployment will ensure the Deployment resource is present for the ArgoCD Redis component.
 func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS bool) error {
+	if skipSuspendedComponent(cr, argoproj.ArgoCDSuspendableComponentRedis, "deployment") {
+		return nil
+	}
 	deploy := newDeploymentWithSuffix("redis", "redis", cr)
 
 	env := append(proxyEnvVars(), corev1.EnvVar{
@@ -526,6 +537,12 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 	if err := applyReconcilerHook(cr, deploy, ""); err != nil {
 		return err
 	}
+	setPodScheduling(cr, &deploy.Spec.Template.Spec, cr.Spec.Redis.Scheduling, nil)
+	setPodImagePullSecrets(cr, &deploy.Spec.Template.Spec)
+	rendered := deploy.DeepCopy()
+	if err := applyResourcePatches(deploy, cr.Spec.Redis.Patches); err != nil {
+		return err
+	}
 
 	existing := newDeploymentWithSuffix("redis", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
@@ -543,7 +560,7 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 		}
 		changed := false
 		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getRedisContainerImage(cr)
+		desiredImage := deploy.Spec.Template.Spec.Containers[0].Image
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
 			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
@@ -572,6 +589,10 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 			changed = true
 		}
 
+		if err := updatePatchedResource(existing, rendered, cr.Spec.Redis.Patches, &changed); err != nil {
+			return err
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -599,6 +620,9 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 
 // reconcileRedisHAProxyDeployment will ensure the Deployment resource is present for the Redis HA Proxy component.
 func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) error {
+	if skipSuspendedComponent(cr, argoproj.ArgoCDSuspendableComponentRedis, "HA proxy deployment") {
+		return nil
+	}
 	deploy := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
 
 	var redisEnv = append(proxyEnvVars(), corev1.EnvVar{
@@ -787,6 +811,9 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 
 	deploy.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", cr.Name, "argocd-redis-ha")
 
+	setPodScheduling(cr, &deploy.Spec.Template.Spec, cr.Spec.Redis.Scheduling, nil)
+	setPodImagePullSecrets(cr, &deploy.Spec.Template.Spec)
+
 	version, err := getClusterVersion(r.Client)
 	if err != nil {
 		log.Error(err, "error getting cluster version")
@@ -794,6 +821,10 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 	if err := applyReconcilerHook(cr, deploy, version); err != nil {
 		return err
 	}
+	rendered := deploy.DeepCopy()
+	if err := applyResourcePatches(deploy, cr.Spec.HA.RedisProxyPatches); err != nil {
+		return err
+	}
 
 	existing := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
@@ -803,7 +834,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 		}
 		changed := false
 		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getRedisHAProxyContainerImage(cr)
+		desiredImage := deploy.Spec.Template.Spec.Containers[0].Image
 
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
@@ -832,6 +863,10 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 			changed = true
 		}
 
+		if err := updatePatchedResource(existing, rendered, cr.Spec.HA.RedisProxyPatches, &changed); err != nil {
+			return err
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -850,6 +885,9 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 
 // reconcileRepoDeployment will ensure the Deployment resource is present for the ArgoCD Repo component.
 func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
+	if skipSuspendedComponent(cr, argoproj.ArgoCDSuspendableComponentRepo, "deployment") {
+		return nil
+	}
 	deploy := newDeploymentWithSuffix("repo-server", "repo-server", cr)
 	automountToken := false
 	if cr.Spec.Repo.MountSAToken {
@@ -877,6 +915,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 	})
 	// Environment specified in the CR take precedence over everything else
 	repoEnv = argoutil.EnvMerge(repoEnv, proxyEnvVars(), false)
+	repoEnv = argoutil.EnvMerge(repoEnv, getTracingEnv(cr, "ARGOCD_REPO_OTLP_HEADERS"), false)
 	if cr.Spec.Repo.ExecTimeout != nil {
 		repoEnv = argoutil.EnvMerge(repoEnv, []corev1.EnvVar{{Name: "ARGOCD_EXEC_TIMEOUT", Value: fmt.Sprintf("%ds", *cr.Spec.Repo.ExecTimeout)}}, true)
 	}
@@ -1119,6 +1158,13 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 		}
 	}
 
+	setPodScheduling(cr, &deploy.Spec.Template.Spec, cr.Spec.Repo.Scheduling, getZoneSpreadConstraints(cr, deploy.Name))
+	setPodImagePullSecrets(cr, &deploy.Spec.Template.Spec)
+	rendered := deploy.DeepCopy()
+	if err := applyResourcePatches(deploy, cr.Spec.Repo.Patches); err != nil {
+		return err
+	}
+
 	existing := newDeploymentWithSuffix("repo-server", "repo-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 
@@ -1133,7 +1179,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 
 		changed := false
 		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getRepoServerContainerImage(cr)
+		desiredImage := deploy.Spec.Template.Spec.Containers[0].Image
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
 			if existing.Spec.Template.ObjectMeta.Labels == nil {
@@ -1211,6 +1257,10 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 			changed = true
 		}
 
+		if err := updatePatchedResource(existing, rendered, cr.Spec.Repo.Patches, &changed); err != nil {
+			return err
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -1235,6 +1285,9 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 
 // reconcileServerDeployment will ensure the Deployment resource is present for the ArgoCD Server component.
 func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
+	if skipSuspendedComponent(cr, argoproj.ArgoCDSuspendableComponentServer, "deployment") {
+		return nil
+	}
 	deploy := newDeploymentWithSuffix("server", "server", cr)
 	serverEnv := cr.Spec.Server.Env
 	serverEnv = append(serverEnv, corev1.EnvVar{
@@ -1249,6 +1302,11 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 		},
 	})
 	serverEnv = argoutil.EnvMerge(serverEnv, proxyEnvVars(), false)
+	serverEnv = argoutil.EnvMerge(serverEnv, getTracingEnv(cr, "ARGOCD_SERVER_OTLP_HEADERS"), false)
+	selectedSourceNamespaces, err := r.getSelectedSourceNamespaces(cr)
+	if err != nil {
+		return err
+	}
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
 	if cr.Spec.Server.InitContainers != nil {
@@ -1278,7 +1336,7 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 	}
 
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
-		Command:         getArgoServerCommand(cr, useTLSForRedis),
+		Command:         appendSelectedSourceNamespaces(getArgoServerCommand(cr, useTLSForRedis), selectedSourceNamespaces),
 		Image:           getArgoContainerImage(cr),
 		ImagePullPolicy: corev1.PullAlways,
 		Env:             serverEnv,
@@ -1374,25 +1432,25 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 
 	deploy.Spec.Template.Spec.Volumes = serverVolumes
 
-	if cr.Spec.Server.EnableRolloutsUI {
+	if hasServerExtensions(cr) {
 
-		deploy.Spec.Template.Spec.InitContainers = append(deploy.Spec.Template.Spec.InitContainers, getRolloutInitContainer()...)
+		deploy.Spec.Template.Spec.InitContainers = append(deploy.Spec.Template.Spec.InitContainers, getServerExtensionInitContainers(cr)...)
 
 		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
-			Name:      "extensions",
-			MountPath: "/tmp/extensions/",
+			Name:      serverExtensionsVolumeName,
+			MountPath: serverExtensionsMountPath,
 		})
 
 		deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, corev1.Volume{
-			Name: "extensions",
+			Name: serverExtensionsVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				EmptyDir: &corev1.EmptyDirVolumeSource{},
 			},
 		})
-	} else if !cr.Spec.Server.EnableRolloutsUI {
+	} else {
 		deploy.Spec.Template.Spec.InitContainers = removeInitContainer(deploy.Spec.Template.Spec.InitContainers, "rollout-extension")
-		deploy.Spec.Template.Spec.Volumes = removeVolume(deploy.Spec.Template.Spec.Volumes, "extensions")
-		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = removeVolumeMount(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, "extensions")
+		deploy.Spec.Template.Spec.Volumes = removeVolume(deploy.Spec.Template.Spec.Volumes, serverExtensionsVolumeName)
+		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = removeVolumeMount(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, serverExtensionsVolumeName)
 
 	}
 
@@ -1416,6 +1474,12 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 	if err := applyReconcilerHook(cr, deploy, ""); err != nil {
 		return err
 	}
+	setPodScheduling(cr, &deploy.Spec.Template.Spec, cr.Spec.Server.Scheduling, getZoneSpreadConstraints(cr, deploy.Name))
+	setPodImagePullSecrets(cr, &deploy.Spec.Template.Spec)
+	rendered := deploy.DeepCopy()
+	if err := applyResourcePatches(deploy, cr.Spec.Server.Patches); err != nil {
+		return err
+	}
 
 	existing := newDeploymentWithSuffix("server", "server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
@@ -1425,7 +1489,7 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 			return r.Client.Delete(context.TODO(), existing)
 		}
 		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getArgoContainerImage(cr)
+		desiredImage := deploy.Spec.Template.Spec.Containers[0].Image
 		changed := false
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
@@ -1493,6 +1557,10 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 			changed = true
 		}
 
+		if err := updatePatchedResource(existing, rendered, cr.Spec.Server.Patches, &changed); err != nil {
+			return err
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -1551,7 +1619,7 @@ func isRemoveManagedByLabelOnArgoCDDeletion() bool {
 	return false
 }
 
-// to update nodeSelector and tolerations in reconciler
+// to update nodeSelector, tolerations, the scheduling options and the image pull secrets in reconciler
 func updateNodePlacement(existing *appsv1.Deployment, deploy *appsv1.Deployment, changed *bool) {
 	if !reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, deploy.Spec.Template.Spec.NodeSelector) {
 		existing.Spec.Template.Spec.NodeSelector = deploy.Spec.Template.Spec.NodeSelector
@@ -1561,13 +1629,15 @@ func updateNodePlacement(existing *appsv1.Deployment, deploy *appsv1.Deployment,
 		existing.Spec.Template.Spec.Tolerations = deploy.Spec.Template.Spec.Tolerations
 		*changed = true
 	}
+	updatePodScheduling(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, changed)
+	updatePodImagePullSecrets(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, changed)
 }
 
-func getRolloutInitContainer() []corev1.Container {
+func getRolloutInitContainer(cr *argoproj.ArgoCD) []corev1.Container {
 	return []corev1.Container{
 		{
 			Name:  "rollout-extension",
-			Image: common.ArgoCDExtensionInstallerImage,
+			Image: argoutil.GetImageReference(cr, common.ArgoCDExtensionInstallerImage),
 			Env: []corev1.EnvVar{
 				{
 					Name:  "EXTENSION_URL",
@@ -99,15 +99,9 @@ func (r *ReconcileArgoCD) getDexOAuthClientSecret(cr *argoproj.ArgoCD) (*string,
 // reconcileDexConfiguration will ensure that Dex is configured properly.
 func (r *ReconcileArgoCD) reconcileDexConfiguration(cm *corev1.ConfigMap, cr *argoproj.ArgoCD) error {
 	actual := cm.Data[common.ArgoCDKeyDexConfig]
-	desired := getDexConfig(cr)
-
-	// Append the default OpenShift dex config if the openShiftOAuth is requested through `.spec.sso.dex`.
-	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.OpenShiftOAuth {
-		cfg, err := r.getOpenShiftDexConfig(cr)
-		if err != nil {
-			return err
-		}
-		desired = cfg
+	desired, err := r.getDesiredDexConfig(cr)
+	if err != nil {
+		return err
 	}
 
 	if actual != desired {
@@ -130,44 +124,46 @@ func (r *ReconcileArgoCD) reconcileDexConfiguration(cm *corev1.ConfigMap, cr *ar
 	return nil
 }
 
-// getOpenShiftDexConfig will return the configuration for the Dex server running on OpenShift.
-func (r *ReconcileArgoCD) getOpenShiftDexConfig(cr *argoproj.ArgoCD) (string, error) {
-	groups := []string{}
-
-	// Allow override of groups from CR
-	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.Groups != nil {
-		groups = cr.Spec.SSO.Dex.Groups
-	}
-
-	connector := DexConnector{
-		Type: "openshift",
-		ID:   "openshift",
-		Name: "OpenShift",
-		Config: map[string]interface{}{
-			"issuer":       "https://kubernetes.default.svc", // TODO: Should this be hard-coded?
-			"clientID":     getDexOAuthClientID(cr),
-			"clientSecret": "$oidc.dex.clientSecret",
-			"redirectURI":  r.getDexOAuthRedirectURI(cr),
-			"insecureCA":   true, // TODO: Configure for openshift CA,
-			"groups":       groups,
-		},
-	}
+// getDesiredDexConfig will return the validated dex configuration for the given ArgoCD. The typed connectors,
+// including OpenShift OAuth requested through `.spec.sso.dex.openShiftOAuth`, are rendered ahead of the
+// connectors of the dex config from the CR.
+func (r *ReconcileArgoCD) getDesiredDexConfig(cr *argoproj.ArgoCD) (string, error) {
+	desired := getDexConfig(cr)
 
-	connectors := make([]DexConnector, 0)
-	connectors = append(connectors, connector)
+	if connectors := getDexConnectors(cr); len(connectors) > 0 {
+		if errMsg := validateDexConnectors(cr); errMsg != "" {
+			return "", e.New(errMsg)
+		}
 
-	dex := make(map[string]interface{})
-	dex["connectors"] = connectors
+		dexConnectors := make([]DexConnector, 0)
+		for _, c := range connectors {
+			dexConnectors = append(dexConnectors, r.newDexConnector(cr, c))
+		}
 
-	// add dex config from the Argo CD CR.
-	if err := addDexConfigFromCR(cr, dex); err != nil {
-		return "", err
+		dex := make(map[string]interface{})
+		dex["connectors"] = dexConnectors
+
+		// add dex config from the Argo CD CR.
+		if err := addDexConfigFromCR(cr, dex); err != nil {
+			return "", err
+		}
+
+		bytes, err := yaml.Marshal(dex)
+		if err != nil {
+			return "", err
+		}
+		desired = string(bytes)
 	}
 
-	bytes, err := yaml.Marshal(dex)
-	return string(bytes), err
+	if err := validateDexConfig(desired); err != nil {
+		return "", err
+	}
+	return desired, nil
 }
 
+// addDexConfigFromCR merges the dex config from the Argo CD CR into the given dex configuration. A connector of the
+// dex config replaces the existing connector with the same id, such as a customized openshift connector, and the
+// other ones are appended to the existing connectors.
 func addDexConfigFromCR(cr *argoproj.ArgoCD, dex map[string]interface{}) error {
 	dexCfgStr := getDexConfig(cr)
 	if dexCfgStr == "" {
@@ -180,6 +176,32 @@ func addDexConfigFromCR(cr *argoproj.ArgoCD, dex map[string]interface{}) error {
 	}
 
 	for k, v := range dexCfg {
+		if k == "connectors" {
+			connectors, ok := v.([]interface{})
+			if !ok {
+				return e.New("invalid dex configuration: connectors must be a list")
+			}
+			merged := []interface{}{}
+			indexes := map[string]int{}
+			if existing, ok := dex["connectors"].([]DexConnector); ok {
+				for _, c := range existing {
+					indexes[c.ID] = len(merged)
+					merged = append(merged, c)
+				}
+			}
+			for _, c := range connectors {
+				if connector, ok := c.(map[interface{}]interface{}); ok {
+					if id, ok := connector["id"].(string); ok {
+						if i, ok := indexes[id]; ok {
+							merged[i] = c
+							continue
+						}
+					}
+				}
+				merged = append(merged, c)
+			}
+			v = merged
+		}
 		dex[k] = v
 	}
 
@@ -188,8 +210,8 @@ func addDexConfigFromCR(cr *argoproj.ArgoCD, dex map[string]interface{}) error {
 
 // reconcileDexServiceAccount will ensure that the Dex ServiceAccount is configured properly for OpenShift OAuth.
 func (r *ReconcileArgoCD) reconcileDexServiceAccount(cr *argoproj.ArgoCD) error {
-	// if openShiftOAuth set to false in `.spec.sso.dex`, no need to configure it
-	if cr.Spec.SSO == nil || cr.Spec.SSO.Dex == nil || !cr.Spec.SSO.Dex.OpenShiftOAuth {
+	// if no openshift connector is configured in `.spec.sso.dex`, no need to configure it
+	if !useDexOpenShiftConnector(cr) {
 		return nil // OpenShift OAuth not enabled, move along...
 	}
 
@@ -223,6 +245,9 @@ func (r *ReconcileArgoCD) reconcileDexServiceAccount(cr *argoproj.ArgoCD) error
 
 // reconcileDexDeployment will ensure the Deployment resource is present for the ArgoCD Dex component.
 func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
+	if skipSuspendedComponent(cr, argoproj.ArgoCDSuspendableComponentDex, "deployment") {
+		return nil
+	}
 	deploy := newDeploymentWithSuffix("dex-server", "dex-server", cr)
 
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
@@ -319,6 +344,13 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 		},
 	}}
 
+	setPodScheduling(cr, &deploy.Spec.Template.Spec, getDexScheduling(cr), nil)
+	setPodImagePullSecrets(cr, &deploy.Spec.Template.Spec)
+	rendered := deploy.DeepCopy()
+	if err := applyResourcePatches(deploy, getDexPatches(cr)); err != nil {
+		return err
+	}
+
 	existing := newDeploymentWithSuffix("dex-server", "dex-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 
@@ -330,7 +362,7 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 		changed := false
 
 		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getDexContainerImage(cr)
+		desiredImage := deploy.Spec.Template.Spec.Containers[0].Image
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
 			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
@@ -338,7 +370,7 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 		}
 
 		actualImage = existing.Spec.Template.Spec.InitContainers[0].Image
-		desiredImage = getArgoContainerImage(cr)
+		desiredImage = deploy.Spec.Template.Spec.InitContainers[0].Image
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.InitContainers[0].Image = desiredImage
 			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
@@ -372,6 +404,10 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 			changed = true
 		}
 
+		if err := updatePatchedResource(existing, rendered, getDexPatches(cr), &changed); err != nil {
+			return err
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
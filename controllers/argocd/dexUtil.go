@@ -46,9 +46,9 @@ func getDexContainerImage(cr *argoproj.ArgoCD) string {
 		defaultTag = true
 	}
 	if e := os.Getenv(common.ArgoCDDexImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
+		return argoutil.GetImageReference(cr, e)
 	}
-	return argoutil.CombineImageTag(img, tag)
+	return argoutil.GetImageReference(cr, argoutil.CombineImageTag(img, tag))
 }
 
 // getDexOAuthRedirectURI will return the OAuth redirect URI for the Dex server.
@@ -75,6 +75,14 @@ func getDexResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 	return resources
 }
 
+// getDexPatches will return the patches applied to the Dex Deployment for the given ArgoCD.
+func getDexPatches(cr *argoproj.ArgoCD) []argoproj.ArgoCDResourcePatch {
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil {
+		return cr.Spec.SSO.Dex.Patches
+	}
+	return nil
+}
+
 func getDexConfig(cr *argoproj.ArgoCD) string {
 	config := common.ArgoCDDefaultDexConfig
 
@@ -0,0 +1,393 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// Prefix of the argocd-secret keys holding the secret values of typed Dex connectors.
+const dexConnectorSecretKeyPrefix = "dex.connectors."
+
+// getDexConnectors returns the typed Dex connectors of the given ArgoCD. OpenShift OAuth requested through
+// `.spec.sso.dex.openShiftOAuth` is returned as the first connector.
+func getDexConnectors(cr *argoproj.ArgoCD) []argoproj.ArgoCDDexConnectorSpec {
+	if cr.Spec.SSO == nil || cr.Spec.SSO.Dex == nil {
+		return nil
+	}
+
+	connectors := []argoproj.ArgoCDDexConnectorSpec{}
+	if cr.Spec.SSO.Dex.OpenShiftOAuth {
+		connectors = append(connectors, argoproj.ArgoCDDexConnectorSpec{
+			ID:   "openshift",
+			Name: "OpenShift",
+			Type: argoproj.DexConnectorTypeOpenShift,
+			OpenShift: &argoproj.ArgoCDDexOpenShiftConnectorSpec{
+				Groups: cr.Spec.SSO.Dex.Groups,
+			},
+		})
+	}
+	return append(connectors, cr.Spec.SSO.Dex.Connectors...)
+}
+
+// useDexOpenShiftConnector returns true when Dex authenticates users against the OpenShift OAuth server.
+func useDexOpenShiftConnector(cr *argoproj.ArgoCD) bool {
+	for _, c := range getDexConnectors(cr) {
+		if c.Type == argoproj.DexConnectorTypeOpenShift {
+			return true
+		}
+	}
+	return false
+}
+
+// getDexConnectorSecretKey returns the argocd-secret key holding the given secret field of a connector.
+func getDexConnectorSecretKey(id, field string) string {
+	return fmt.Sprintf("%s%s.%s", dexConnectorSecretKeyPrefix, id, field)
+}
+
+// getDexConnectorSecretRefs returns the Secret references of a connector by the name of the config field they populate.
+func getDexConnectorSecretRefs(c argoproj.ArgoCDDexConnectorSpec) map[string]*corev1.SecretKeySelector {
+	refs := map[string]*corev1.SecretKeySelector{}
+	switch {
+	case c.Type == argoproj.DexConnectorTypeGitHub && c.GitHub != nil:
+		refs["clientSecret"] = c.GitHub.ClientSecret
+	case c.Type == argoproj.DexConnectorTypeGitLab && c.GitLab != nil:
+		refs["clientSecret"] = c.GitLab.ClientSecret
+	case c.Type == argoproj.DexConnectorTypeOIDC && c.OIDC != nil:
+		refs["clientSecret"] = c.OIDC.ClientSecret
+	case c.Type == argoproj.DexConnectorTypeMicrosoft && c.Microsoft != nil:
+		refs["clientSecret"] = c.Microsoft.ClientSecret
+	case c.Type == argoproj.DexConnectorTypeLDAP && c.LDAP != nil && c.LDAP.BindPW != nil:
+		refs["bindPW"] = c.LDAP.BindPW
+	}
+	return refs
+}
+
+// isDexConnectorSecret returns true when the named Secret is referenced by a typed Dex connector of the given ArgoCD.
+func isDexConnectorSecret(cr *argoproj.ArgoCD, name string) bool {
+	if !UseDex(cr) {
+		return false
+	}
+	for _, c := range getDexConnectors(cr) {
+		for _, ref := range getDexConnectorSecretRefs(c) {
+			if ref != nil && ref.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getDexConnectorSecretData returns the secret values of the typed Dex connectors, keyed by the argocd-secret key
+// they are referenced with in the dex configuration.
+func (r *ReconcileArgoCD) getDexConnectorSecretData(cr *argoproj.ArgoCD) (map[string][]byte, error) {
+	data := map[string][]byte{}
+	if !UseDex(cr) {
+		return data, nil
+	}
+
+	for _, c := range getDexConnectors(cr) {
+		for field, ref := range getDexConnectorSecretRefs(c) {
+			if ref == nil {
+				continue
+			}
+			optional := ref.Optional != nil && *ref.Optional
+
+			secret := &corev1.Secret{}
+			if err := argoutil.FetchObject(r.Client, cr.Namespace, ref.Name, secret); err != nil {
+				if optional {
+					continue
+				}
+				return nil, fmt.Errorf("failed to get secret %s referenced by dex connector %s: %w", ref.Name, c.ID, err)
+			}
+
+			value, ok := secret.Data[ref.Key]
+			if !ok {
+				if optional {
+					continue
+				}
+				return nil, fmt.Errorf("key %s not found in secret %s referenced by dex connector %s", ref.Key, ref.Name, c.ID)
+			}
+			data[getDexConnectorSecretKey(c.ID, field)] = value
+		}
+	}
+	return data, nil
+}
+
+// validateDexConnectors returns an error message when a typed Dex connector is incomplete or conflicts with another one.
+func validateDexConnectors(cr *argoproj.ArgoCD) string {
+	ids := map[string]bool{}
+	for i, c := range getDexConnectors(cr) {
+		if c.ID == "" {
+			return fmt.Sprintf("must supply an id for dex connector %d", i)
+		}
+		if ids[c.ID] {
+			return fmt.Sprintf("dex connector id %s is not unique", c.ID)
+		}
+		ids[c.ID] = true
+
+		configs := map[argoproj.DexConnectorType]bool{
+			argoproj.DexConnectorTypeGitHub:    c.GitHub != nil,
+			argoproj.DexConnectorTypeGitLab:    c.GitLab != nil,
+			argoproj.DexConnectorTypeLDAP:      c.LDAP != nil,
+			argoproj.DexConnectorTypeOIDC:      c.OIDC != nil,
+			argoproj.DexConnectorTypeSAML:      c.SAML != nil,
+			argoproj.DexConnectorTypeMicrosoft: c.Microsoft != nil,
+			argoproj.DexConnectorTypeOpenShift: c.OpenShift != nil,
+		}
+		if _, ok := configs[c.Type]; !ok {
+			return fmt.Sprintf("dex connector %s has unsupported type %s", c.ID, c.Type)
+		}
+		for t, set := range configs {
+			if set && t != c.Type {
+				return fmt.Sprintf("dex connector %s of type %s cannot supply %s configuration", c.ID, c.Type, t)
+			}
+		}
+		if !configs[c.Type] && c.Type != argoproj.DexConnectorTypeOpenShift {
+			return fmt.Sprintf("must supply %s configuration for dex connector %s", c.Type, c.ID)
+		}
+
+		missing := []string{}
+		require := func(field string, set bool) {
+			if !set {
+				missing = append(missing, field)
+			}
+		}
+		switch c.Type {
+		case argoproj.DexConnectorTypeGitHub:
+			require("clientID", c.GitHub.ClientID != "")
+			require("clientSecret", c.GitHub.ClientSecret != nil)
+		case argoproj.DexConnectorTypeGitLab:
+			require("clientID", c.GitLab.ClientID != "")
+			require("clientSecret", c.GitLab.ClientSecret != nil)
+		case argoproj.DexConnectorTypeMicrosoft:
+			require("clientID", c.Microsoft.ClientID != "")
+			require("clientSecret", c.Microsoft.ClientSecret != nil)
+		case argoproj.DexConnectorTypeOIDC:
+			require("issuer", c.OIDC.Issuer != "")
+			require("clientID", c.OIDC.ClientID != "")
+			require("clientSecret", c.OIDC.ClientSecret != nil)
+		case argoproj.DexConnectorTypeLDAP:
+			require("host", c.LDAP.Host != "")
+			require("userSearch.baseDN", c.LDAP.UserSearch.BaseDN != "")
+			require("userSearch.username", c.LDAP.UserSearch.Username != "")
+			require("userSearch.idAttr", c.LDAP.UserSearch.IDAttr != "")
+			require("userSearch.emailAttr", c.LDAP.UserSearch.EmailAttr != "")
+		case argoproj.DexConnectorTypeSAML:
+			require("ssoURL", c.SAML.SSOURL != "")
+			require("usernameAttr", c.SAML.UsernameAttr != "")
+			require("emailAttr", c.SAML.EmailAttr != "")
+			require("ca", c.SAML.CA != "" || c.SAML.InsecureSkipSignatureValidation)
+		}
+		if len(missing) > 0 {
+			return fmt.Sprintf("must supply %s for %s dex connector %s", strings.Join(missing, ", "), c.Type, c.ID)
+		}
+	}
+	return ""
+}
+
+// setDexConnectorConfig sets the given key of a connector configuration unless the value is empty.
+func setDexConnectorConfig(config map[string]interface{}, key string, value interface{}) {
+	if !reflect.ValueOf(value).IsZero() {
+		config[key] = value
+	}
+}
+
+// newDexConnector renders a typed connector into a Dex connector. Secret values are referenced from the argocd-secret.
+func (r *ReconcileArgoCD) newDexConnector(cr *argoproj.ArgoCD, c argoproj.ArgoCDDexConnectorSpec) DexConnector {
+	name := c.Name
+	if name == "" {
+		name = c.ID
+	}
+	secretRef := func(field string) string {
+		return "$" + getDexConnectorSecretKey(c.ID, field)
+	}
+	redirectURI := r.getDexOAuthRedirectURI(cr)
+
+	config := map[string]interface{}{}
+	switch c.Type {
+	case argoproj.DexConnectorTypeGitHub:
+		config["clientID"] = c.GitHub.ClientID
+		config["clientSecret"] = secretRef("clientSecret")
+		config["redirectURI"] = redirectURI
+		setDexConnectorConfig(config, "hostName", c.GitHub.HostName)
+		orgs := []map[string]interface{}{}
+		for _, org := range c.GitHub.Orgs {
+			o := map[string]interface{}{"name": org.Name}
+			setDexConnectorConfig(o, "teams", org.Teams)
+			orgs = append(orgs, o)
+		}
+		setDexConnectorConfig(config, "orgs", orgs)
+		setDexConnectorConfig(config, "loadAllGroups", c.GitHub.LoadAllGroups)
+		setDexConnectorConfig(config, "teamNameField", c.GitHub.TeamNameField)
+		setDexConnectorConfig(config, "useLoginAsID", c.GitHub.UseLoginAsID)
+	case argoproj.DexConnectorTypeGitLab:
+		config["clientID"] = c.GitLab.ClientID
+		config["clientSecret"] = secretRef("clientSecret")
+		config["redirectURI"] = redirectURI
+		setDexConnectorConfig(config, "baseURL", c.GitLab.BaseURL)
+		setDexConnectorConfig(config, "groups", c.GitLab.Groups)
+		setDexConnectorConfig(config, "useLoginAsID", c.GitLab.UseLoginAsID)
+	case argoproj.DexConnectorTypeMicrosoft:
+		config["clientID"] = c.Microsoft.ClientID
+		config["clientSecret"] = secretRef("clientSecret")
+		config["redirectURI"] = redirectURI
+		setDexConnectorConfig(config, "tenant", c.Microsoft.Tenant)
+		setDexConnectorConfig(config, "groups", c.Microsoft.Groups)
+		setDexConnectorConfig(config, "onlySecurityGroups", c.Microsoft.OnlySecurityGroups)
+	case argoproj.DexConnectorTypeOIDC:
+		config["issuer"] = c.OIDC.Issuer
+		config["clientID"] = c.OIDC.ClientID
+		config["clientSecret"] = secretRef("clientSecret")
+		config["redirectURI"] = redirectURI
+		setDexConnectorConfig(config, "scopes", c.OIDC.Scopes)
+		setDexConnectorConfig(config, "insecureSkipEmailVerified", c.OIDC.InsecureSkipEmailVerified)
+		setDexConnectorConfig(config, "insecureEnableGroups", c.OIDC.InsecureEnableGroups)
+		setDexConnectorConfig(config, "getUserInfo", c.OIDC.GetUserInfo)
+		setDexConnectorConfig(config, "userNameKey", c.OIDC.UserNameKey)
+	case argoproj.DexConnectorTypeLDAP:
+		config["host"] = c.LDAP.Host
+		setDexConnectorConfig(config, "insecureNoSSL", c.LDAP.InsecureNoSSL)
+		setDexConnectorConfig(config, "insecureSkipVerify", c.LDAP.InsecureSkipVerify)
+		setDexConnectorConfig(config, "startTLS", c.LDAP.StartTLS)
+		setDexConnectorConfig(config, "rootCAData", base64.StdEncoding.EncodeToString([]byte(c.LDAP.RootCA)))
+		setDexConnectorConfig(config, "bindDN", c.LDAP.BindDN)
+		if c.LDAP.BindPW != nil {
+			config["bindPW"] = secretRef("bindPW")
+		}
+		setDexConnectorConfig(config, "usernamePrompt", c.LDAP.UsernamePrompt)
+
+		userSearch := map[string]interface{}{
+			"baseDN":    c.LDAP.UserSearch.BaseDN,
+			"username":  c.LDAP.UserSearch.Username,
+			"idAttr":    c.LDAP.UserSearch.IDAttr,
+			"emailAttr": c.LDAP.UserSearch.EmailAttr,
+		}
+		setDexConnectorConfig(userSearch, "filter", c.LDAP.UserSearch.Filter)
+		setDexConnectorConfig(userSearch, "nameAttr", c.LDAP.UserSearch.NameAttr)
+		setDexConnectorConfig(userSearch, "preferredUsernameAttr", c.LDAP.UserSearch.PreferredUsernameAttr)
+		config["userSearch"] = userSearch
+
+		if gs := c.LDAP.GroupSearch; gs != nil {
+			userMatchers := []map[string]interface{}{}
+			for _, m := range gs.UserMatchers {
+				userMatchers = append(userMatchers, map[string]interface{}{
+					"userAttr":  m.UserAttr,
+					"groupAttr": m.GroupAttr,
+				})
+			}
+			groupSearch := map[string]interface{}{
+				"baseDN":       gs.BaseDN,
+				"userMatchers": userMatchers,
+				"nameAttr":     gs.NameAttr,
+			}
+			setDexConnectorConfig(groupSearch, "filter", gs.Filter)
+			config["groupSearch"] = groupSearch
+		}
+	case argoproj.DexConnectorTypeSAML:
+		config["ssoURL"] = c.SAML.SSOURL
+		config["redirectURI"] = redirectURI
+		config["usernameAttr"] = c.SAML.UsernameAttr
+		config["emailAttr"] = c.SAML.EmailAttr
+		setDexConnectorConfig(config, "caData", base64.StdEncoding.EncodeToString([]byte(c.SAML.CA)))
+		setDexConnectorConfig(config, "entityIssuer", c.SAML.EntityIssuer)
+		setDexConnectorConfig(config, "ssoIssuer", c.SAML.SSOIssuer)
+		setDexConnectorConfig(config, "groupsAttr", c.SAML.GroupsAttr)
+		setDexConnectorConfig(config, "nameIDPolicyFormat", c.SAML.NameIDPolicyFormat)
+		setDexConnectorConfig(config, "insecureSkipSignatureValidation", c.SAML.InsecureSkipSignatureValidation)
+	case argoproj.DexConnectorTypeOpenShift:
+		groups := []string{}
+		if c.OpenShift != nil && c.OpenShift.Groups != nil {
+			groups = c.OpenShift.Groups
+		}
+		config["issuer"] = "https://kubernetes.default.svc" // TODO: Should this be hard-coded?
+		config["clientID"] = getDexOAuthClientID(cr)
+		config["clientSecret"] = "$oidc.dex.clientSecret"
+		config["redirectURI"] = redirectURI
+		config["insecureCA"] = true // TODO: Configure for openshift CA,
+		config["groups"] = groups
+	}
+
+	return DexConnector{
+		Type:   string(c.Type),
+		ID:     c.ID,
+		Name:   name,
+		Config: config,
+	}
+}
+
+// validateDexConfig returns an error when the given dex configuration cannot be loaded by Dex.
+func validateDexConfig(config string) error {
+	var dex interface{}
+	if err := yaml.Unmarshal([]byte(config), &dex); err != nil {
+		return fmt.Errorf("invalid dex configuration: %w", err)
+	}
+
+	cfg, ok := dex.(map[interface{}]interface{})
+	if !ok || cfg["connectors"] == nil {
+		return nil
+	}
+	connectors, ok := cfg["connectors"].([]interface{})
+	if !ok {
+		return errors.New("invalid dex configuration: connectors must be a list")
+	}
+
+	ids := map[string]bool{}
+	for i, c := range connectors {
+		connector, ok := c.(map[interface{}]interface{})
+		if !ok {
+			return fmt.Errorf("invalid dex configuration: connector %d must be a map", i)
+		}
+		id, _ := connector["id"].(string)
+		if id == "" || connector["type"] == nil {
+			return fmt.Errorf("invalid dex configuration: connector %d must have an id and a type", i)
+		}
+		if ids[id] {
+			return fmt.Errorf("invalid dex configuration: connector id %s is not unique", id)
+		}
+		ids[id] = true
+	}
+	return nil
+}
+
+// updateDexConnectorSecretData sets the secret values of the typed Dex connectors in the given argocd-secret and
+// removes the values of connectors that are no longer configured. Returns true when the Secret was changed.
+func updateDexConnectorSecretData(secret *corev1.Secret, data map[string][]byte) bool {
+	changed := false
+	for k := range secret.Data {
+		if _, ok := data[k]; !ok && strings.HasPrefix(k, dexConnectorSecretKeyPrefix) {
+			delete(secret.Data, k)
+			changed = true
+		}
+	}
+	for k, v := range data {
+		if !bytes.Equal(secret.Data[k], v) {
+			secret.Data[k] = v
+			changed = true
+		}
+	}
+	return changed
+}
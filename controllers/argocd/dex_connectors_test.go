@@ -0,0 +1,319 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func makeTestDexConnectors() []argoproj.ArgoCDDexConnectorSpec {
+	return []argoproj.ArgoCDDexConnectorSpec{
+		{
+			ID:   "github",
+			Name: "GitHub",
+			Type: argoproj.DexConnectorTypeGitHub,
+			GitHub: &argoproj.ArgoCDDexGitHubConnectorSpec{
+				ClientID: "github-client",
+				ClientSecret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "github-oauth"},
+					Key:                  "secret",
+				},
+				Orgs: []argoproj.ArgoCDDexGitHubOrg{{Name: "my-org", Teams: []string{"admins"}}},
+			},
+		},
+		{
+			ID:   "ldap",
+			Type: argoproj.DexConnectorTypeLDAP,
+			LDAP: &argoproj.ArgoCDDexLDAPConnectorSpec{
+				Host:   "ldap.example.com:636",
+				RootCA: "ca",
+				BindDN: "cn=admin,dc=example,dc=com",
+				BindPW: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "ldap-bind"},
+					Key:                  "password",
+				},
+				UserSearch: argoproj.ArgoCDDexLDAPUserSearch{
+					BaseDN:    "ou=people,dc=example,dc=com",
+					Username:  "uid",
+					IDAttr:    "uid",
+					EmailAttr: "mail",
+				},
+			},
+		},
+	}
+}
+
+func TestGetDesiredDexConfig_withConnectors(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				OpenShiftOAuth: true,
+				Groups:         []string{"cluster-admins"},
+				Connectors:     makeTestDexConnectors(),
+				Config: `connectors:
+- type: mock
+  id: mock
+  name: Mock
+logger:
+  level: debug
+`,
+			},
+		}
+	})
+
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch)
+
+	cfg, err := r.getDesiredDexConfig(a)
+	assert.NoError(t, err)
+
+	dex := struct {
+		Connectors []DexConnector         `yaml:"connectors"`
+		Logger     map[string]interface{} `yaml:"logger"`
+	}{}
+	assert.NoError(t, yaml.Unmarshal([]byte(cfg), &dex))
+	assert.Equal(t, "debug", dex.Logger["level"])
+
+	ids := []string{}
+	for _, c := range dex.Connectors {
+		ids = append(ids, c.ID)
+	}
+	assert.Equal(t, []string{"openshift", "github", "ldap", "mock"}, ids)
+
+	openshift := dex.Connectors[0]
+	assert.Equal(t, "$oidc.dex.clientSecret", openshift.Config["clientSecret"])
+	assert.Equal(t, []interface{}{"cluster-admins"}, openshift.Config["groups"])
+
+	github := dex.Connectors[1]
+	assert.Equal(t, "GitHub", github.Name)
+	assert.Equal(t, "$dex.connectors.github.clientSecret", github.Config["clientSecret"])
+	assert.Equal(t, r.getDexOAuthRedirectURI(a), github.Config["redirectURI"])
+	assert.NotContains(t, github.Config, "loadAllGroups")
+
+	ldap := dex.Connectors[2]
+	assert.Equal(t, "ldap", ldap.Name)
+	assert.Equal(t, "$dex.connectors.ldap.bindPW", ldap.Config["bindPW"])
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("ca")), ldap.Config["rootCAData"])
+}
+
+func TestValidateDexConnectors(t *testing.T) {
+	tests := []struct {
+		name       string
+		connectors func([]argoproj.ArgoCDDexConnectorSpec) []argoproj.ArgoCDDexConnectorSpec
+		wantErr    string
+	}{
+		{
+			name:       "valid connectors",
+			connectors: func(c []argoproj.ArgoCDDexConnectorSpec) []argoproj.ArgoCDDexConnectorSpec { return c },
+		},
+		{
+			name: "duplicate id",
+			connectors: func(c []argoproj.ArgoCDDexConnectorSpec) []argoproj.ArgoCDDexConnectorSpec {
+				return append(c, c[0])
+			},
+			wantErr: "dex connector id github is not unique",
+		},
+		{
+			name: "missing type configuration",
+			connectors: func(c []argoproj.ArgoCDDexConnectorSpec) []argoproj.ArgoCDDexConnectorSpec {
+				c[0].GitHub = nil
+				return c
+			},
+			wantErr: "must supply github configuration for dex connector github",
+		},
+		{
+			name: "configuration of another type",
+			connectors: func(c []argoproj.ArgoCDDexConnectorSpec) []argoproj.ArgoCDDexConnectorSpec {
+				c[0].OIDC = &argoproj.ArgoCDDexOIDCConnectorSpec{}
+				return c
+			},
+			wantErr: "dex connector github of type github cannot supply oidc configuration",
+		},
+		{
+			name: "missing required fields",
+			connectors: func(c []argoproj.ArgoCDDexConnectorSpec) []argoproj.ArgoCDDexConnectorSpec {
+				c[1].LDAP.Host = ""
+				c[1].LDAP.UserSearch.EmailAttr = ""
+				return c
+			},
+			wantErr: "must supply host, userSearch.emailAttr for ldap dex connector ldap",
+		},
+		{
+			name: "saml without ca",
+			connectors: func(c []argoproj.ArgoCDDexConnectorSpec) []argoproj.ArgoCDDexConnectorSpec {
+				return append(c, argoproj.ArgoCDDexConnectorSpec{
+					ID:   "saml",
+					Type: argoproj.DexConnectorTypeSAML,
+					SAML: &argoproj.ArgoCDDexSAMLConnectorSpec{
+						SSOURL:       "https://idp.example.com/sso",
+						UsernameAttr: "name",
+						EmailAttr:    "email",
+					},
+				})
+			},
+			wantErr: "must supply ca for saml dex connector saml",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+				cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: argoproj.SSOProviderTypeDex,
+					Dex: &argoproj.ArgoCDDexSpec{
+						Connectors: test.connectors(makeTestDexConnectors()),
+					},
+				}
+			})
+			assert.Equal(t, test.wantErr, validateDexConnectors(a))
+		})
+	}
+}
+
+func TestValidateDexConfig(t *testing.T) {
+	assert.NoError(t, validateDexConfig(""))
+	assert.NoError(t, validateDexConfig("connectors:\n- id: a\n  type: mock\n"))
+	assert.ErrorContains(t, validateDexConfig("connectors: {"), "invalid dex configuration")
+	assert.ErrorContains(t, validateDexConfig("connectors: mock"), "connectors must be a list")
+	assert.ErrorContains(t, validateDexConfig("connectors:\n- type: mock\n"), "connector 0 must have an id and a type")
+	assert.ErrorContains(t, validateDexConfig("connectors:\n- id: a\n  type: mock\n- id: a\n  type: mock\n"), "connector id a is not unique")
+}
+
+func TestReconcileExistingArgoSecret_dexConnectorSecrets(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				Connectors: makeTestDexConnectors(),
+			},
+		}
+	})
+
+	sa := newServiceAccountWithName(common.ArgoCDDefaultDexServiceAccountName, a)
+	sa.Secrets = []corev1.ObjectReference{{Name: "token"}}
+	token := argoutil.NewSecretWithName(a, "token")
+	token.Data = map[string][]byte{"token": []byte("sa-token")}
+	github := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-oauth", Namespace: a.Namespace},
+		Data:       map[string][]byte{"secret": []byte("github-secret")},
+	}
+	ldap := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap-bind", Namespace: a.Namespace},
+		Data:       map[string][]byte{"password": []byte("bind-password")},
+	}
+	argoCDSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDSecretName, Namespace: a.Namespace},
+		Data: map[string][]byte{
+			common.ArgoCDKeyServerSecretKey:   []byte("key"),
+			"dex.connectors.removed.password": []byte("stale"),
+		},
+	}
+
+	resObjs := []client.Object{a, sa, token, github, ldap, argoCDSecret}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileExistingArgoSecret(a, argoCDSecret, &corev1.Secret{}, &corev1.Secret{}))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, "github-secret", string(secret.Data["dex.connectors.github.clientSecret"]))
+	assert.Equal(t, "bind-password", string(secret.Data["dex.connectors.ldap.bindPW"]))
+	assert.Equal(t, "sa-token", string(secret.Data[common.ArgoCDDexSecretKey]))
+	assert.NotContains(t, secret.Data, "dex.connectors.removed.password")
+
+	// a missing secret reference is reported
+	assert.NoError(t, r.Client.Delete(context.TODO(), ldap))
+	err := r.reconcileExistingArgoSecret(a, secret, &corev1.Secret{}, &corev1.Secret{})
+	assert.ErrorContains(t, err, "failed to get secret ldap-bind referenced by dex connector ldap")
+}
+
+func TestReconcileSSO_dexConnectors(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		connectors := makeTestDexConnectors()
+		connectors[0].GitHub.ClientID = ""
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				Connectors: connectors,
+			},
+		}
+	})
+
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch)
+
+	err := r.reconcileSSO(a)
+	assert.ErrorContains(t, err, "must supply clientID for github dex connector github")
+}
+
+func TestGetDesiredDexConfig_withCustomOpenShiftConnector(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				OpenShiftOAuth: true,
+				Config: `connectors:
+- type: openshift
+  id: openshift
+  name: Custom OpenShift
+  config:
+    issuer: https://kubernetes.default.svc
+    clientID: custom
+- type: mock
+  id: mock
+  name: Mock
+`,
+			},
+		}
+	})
+
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{a}, []client.Object{a}, []runtime.Object{})
+	r := makeTestReconciler(cl, sch)
+
+	// the customized openshift connector of the config replaces the generated one instead of duplicating its id
+	cfg, err := r.getDesiredDexConfig(a)
+	assert.NoError(t, err)
+
+	dex := struct {
+		Connectors []DexConnector `yaml:"connectors"`
+	}{}
+	assert.NoError(t, yaml.Unmarshal([]byte(cfg), &dex))
+	if assert.Len(t, dex.Connectors, 2) {
+		assert.Equal(t, "openshift", dex.Connectors[0].ID)
+		assert.Equal(t, "Custom OpenShift", dex.Connectors[0].Name)
+		assert.Equal(t, "custom", dex.Connectors[0].Config["clientID"])
+		assert.Equal(t, "mock", dex.Connectors[1].ID)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// serverExtensionsVolumeName is the name of the volume the UI extensions are installed in.
+	serverExtensionsVolumeName = "extensions"
+
+	// serverExtensionsMountPath is the path the Argo CD server loads the UI extensions from.
+	serverExtensionsMountPath = "/tmp/extensions/"
+
+	// serverExtensionInitContainerPrefix is the prefix of the names of the init containers installing the UI extensions.
+	// The containers are named after the index of their extension, as extension names may not be valid container names.
+	serverExtensionInitContainerPrefix = "extension-"
+)
+
+// serverExtensionConfigs is the extension.config rendered for the proxy extensions.
+type serverExtensionConfigs struct {
+	Extensions []serverExtensionConfig `yaml:"extensions"`
+}
+
+type serverExtensionConfig struct {
+	Name    string                 `yaml:"name"`
+	Backend serverExtensionBackend `yaml:"backend"`
+}
+
+type serverExtensionBackend struct {
+	ConnectionTimeout     string                   `yaml:"connectionTimeout,omitempty"`
+	KeepAlive             string                   `yaml:"keepAlive,omitempty"`
+	IdleConnectionTimeout string                   `yaml:"idleConnectionTimeout,omitempty"`
+	MaxIdleConnections    int32                    `yaml:"maxIdleConnections,omitempty"`
+	Services              []serverExtensionService `yaml:"services"`
+}
+
+type serverExtensionService struct {
+	URL     string                  `yaml:"url"`
+	Cluster *serverExtensionCluster `yaml:"cluster,omitempty"`
+	Headers []serverExtensionHeader `yaml:"headers,omitempty"`
+}
+
+type serverExtensionCluster struct {
+	Name   string `yaml:"name,omitempty"`
+	Server string `yaml:"server,omitempty"`
+}
+
+type serverExtensionHeader struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// hasServerExtensions returns true when UI extensions are installed in the Argo CD server.
+func hasServerExtensions(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Server.EnableRolloutsUI || len(cr.Spec.Server.Extensions) > 0
+}
+
+// hasServerProxyExtensions returns true when one of the UI extensions of the Argo CD server has a proxy backend.
+func hasServerProxyExtensions(cr *argoproj.ArgoCD) bool {
+	for _, extension := range cr.Spec.Server.Extensions {
+		if extension.Proxy != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getServerExtensionInitContainers will return the init containers installing the UI extensions of the Argo CD server.
+func getServerExtensionInitContainers(cr *argoproj.ArgoCD) []corev1.Container {
+	containers := []corev1.Container{}
+	if cr.Spec.Server.EnableRolloutsUI {
+		containers = append(containers, getRolloutInitContainer(cr)...)
+	}
+	for i, extension := range cr.Spec.Server.Extensions {
+		env := []corev1.EnvVar{
+			{Name: "EXTENSION_NAME", Value: extension.Name},
+			{Name: "EXTENSION_URL", Value: extension.URL},
+		}
+		if extension.ChecksumURL != "" {
+			env = append(env, corev1.EnvVar{Name: "EXTENSION_CHECKSUM_URL", Value: extension.ChecksumURL})
+		}
+		containers = append(containers, corev1.Container{
+			Name:  fmt.Sprintf("%s%d", serverExtensionInitContainerPrefix, i),
+			Image: argoutil.GetImageReference(cr, common.ArgoCDExtensionInstallerImage),
+			Env:   env,
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      serverExtensionsVolumeName,
+					MountPath: serverExtensionsMountPath,
+				},
+			},
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: boolPtr(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{
+						"ALL",
+					},
+				},
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: "RuntimeDefault",
+				},
+			},
+		})
+	}
+	return containers
+}
+
+// getServerExtensionConfig will return the extension.config of the proxy extensions of the Argo CD server, or an empty
+// string when no extension has a proxy backend.
+func getServerExtensionConfig(cr *argoproj.ArgoCD) (string, error) {
+	configs := serverExtensionConfigs{}
+	for _, extension := range cr.Spec.Server.Extensions {
+		proxy := extension.Proxy
+		if proxy == nil {
+			continue
+		}
+		if len(proxy.Services) == 0 {
+			return "", fmt.Errorf("proxy of server extension %s has no service", extension.Name)
+		}
+
+		backend := serverExtensionBackend{
+			ConnectionTimeout:     formatExtensionDuration(proxy.ConnectionTimeout),
+			KeepAlive:             formatExtensionDuration(proxy.KeepAlive),
+			IdleConnectionTimeout: formatExtensionDuration(proxy.IdleConnectionTimeout),
+		}
+		if proxy.MaxIdleConnections != nil {
+			backend.MaxIdleConnections = *proxy.MaxIdleConnections
+		}
+		for _, svc := range proxy.Services {
+			if svc.URL == "" {
+				return "", fmt.Errorf("a service of the proxy of server extension %s has no url", extension.Name)
+			}
+			service := serverExtensionService{URL: svc.URL}
+			if svc.Cluster != nil {
+				if svc.Cluster.Name == "" && svc.Cluster.Server == "" {
+					return "", fmt.Errorf("cluster of service %s of server extension %s must have a name or a server", svc.URL, extension.Name)
+				}
+				service.Cluster = &serverExtensionCluster{Name: svc.Cluster.Name, Server: svc.Cluster.Server}
+			} else if len(proxy.Services) > 1 {
+				return "", fmt.Errorf("service %s of server extension %s must have a cluster, as the extension has several services", svc.URL, extension.Name)
+			}
+			for _, header := range svc.Headers {
+				service.Headers = append(service.Headers, serverExtensionHeader{Name: header.Name, Value: header.Value})
+			}
+			backend.Services = append(backend.Services, service)
+		}
+		configs.Extensions = append(configs.Extensions, serverExtensionConfig{Name: extension.Name, Backend: backend})
+	}
+
+	if len(configs.Extensions) == 0 {
+		return "", nil
+	}
+	bytes, err := yaml.Marshal(configs)
+	return string(bytes), err
+}
+
+// formatExtensionDuration will return the given duration in the format of the extension.config, or an empty string when
+// it is not set.
+func formatExtensionDuration(d *metav1.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return d.Duration.String()
+}
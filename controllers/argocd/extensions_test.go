@@ -0,0 +1,141 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestGetServerExtensionConfig(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Server.Extensions = []argoproj.ArgoCDServerExtension{
+			{Name: "vulnerabilities", URL: "https://example.com/vulnerabilities.tar"},
+			{
+				Name: "metrics",
+				URL:  "https://example.com/metrics.tar",
+				Proxy: &argoproj.ArgoCDServerExtensionProxy{
+					ConnectionTimeout: &metav1.Duration{Duration: 5 * time.Second},
+					Services: []argoproj.ArgoCDServerExtensionService{{
+						URL:     "http://argocd-metrics-server.argocd:9003",
+						Headers: []argoproj.ArgoCDServerExtensionHeader{{Name: "Authorization", Value: "$metrics.token"}},
+					}},
+				},
+			},
+		}
+	})
+
+	config, err := getServerExtensionConfig(a)
+	assert.NoError(t, err)
+	assert.Equal(t, `extensions:
+- name: metrics
+  backend:
+    connectionTimeout: 5s
+    services:
+    - url: http://argocd-metrics-server.argocd:9003
+      headers:
+      - name: Authorization
+        value: $metrics.token
+`, config)
+
+	// several services must each target a cluster
+	a.Spec.Server.Extensions[1].Proxy.Services = append(a.Spec.Server.Extensions[1].Proxy.Services, argoproj.ArgoCDServerExtensionService{
+		URL:     "http://argocd-metrics-server.remote:9003",
+		Cluster: &argoproj.ArgoCDServerExtensionCluster{Name: "remote"},
+	})
+	_, err = getServerExtensionConfig(a)
+	assert.ErrorContains(t, err, "must have a cluster")
+
+	// no extension.config without proxy extensions
+	a.Spec.Server.Extensions = a.Spec.Server.Extensions[:1]
+	config, err = getServerExtensionConfig(a)
+	assert.NoError(t, err)
+	assert.Empty(t, config)
+}
+
+func TestReconcileServer_Extensions(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Server.EnableRolloutsUI = true
+		cr.Spec.Server.Extensions = []argoproj.ArgoCDServerExtension{
+			{
+				Name:        "metrics",
+				URL:         "https://example.com/metrics.tar",
+				ChecksumURL: "https://example.com/metrics.tar.sha256",
+				Proxy: &argoproj.ArgoCDServerExtensionProxy{
+					Services: []argoproj.ArgoCDServerExtensionService{{URL: "http://argocd-metrics-server.argocd:9003"}},
+				},
+			},
+			{Name: "My_Extension", URL: "https://example.com/my-extension.tar"},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+
+	initContainers := deployment.Spec.Template.Spec.InitContainers
+	if assert.Len(t, initContainers, 3) {
+		assert.Equal(t, "rollout-extension", initContainers[0].Name)
+		assert.Equal(t, "extension-0", initContainers[1].Name)
+		assert.Equal(t, []corev1.EnvVar{
+			{Name: "EXTENSION_NAME", Value: "metrics"},
+			{Name: "EXTENSION_URL", Value: "https://example.com/metrics.tar"},
+			{Name: "EXTENSION_CHECKSUM_URL", Value: "https://example.com/metrics.tar.sha256"},
+		}, initContainers[1].Env)
+
+		// the container name is valid even when the extension name is not a valid DNS label
+		assert.Equal(t, "extension-1", initContainers[2].Name)
+		assert.Empty(t, validation.IsDNS1123Label(initContainers[2].Name))
+		assert.Contains(t, initContainers[2].Env, corev1.EnvVar{Name: "EXTENSION_NAME", Value: "My_Extension"})
+	}
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--enable-proxy-extension")
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Contains(t, cm.Data[common.ArgoCDKeyExtensionConfig], "url: http://argocd-metrics-server.argocd:9003")
+
+	// the extensions are removed
+	a.Spec.Server.EnableRolloutsUI = false
+	a.Spec.Server.Extensions = nil
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+	assert.Empty(t, deployment.Spec.Template.Spec.InitContainers)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--enable-proxy-extension")
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.NotContains(t, cm.Data, common.ArgoCDKeyExtensionConfig)
+}
@@ -0,0 +1,305 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// grafanaDashboardLabel is the label watched by the Grafana dashboard sidecar.
+	grafanaDashboardLabel = "grafana_dashboard"
+)
+
+var (
+	grafanaDashboardAPIFound = false
+
+	// grafanaDashboardGVK is the GroupVersionKind of the GrafanaDashboard resource of the grafana-operator.
+	grafanaDashboardGVK = schema.GroupVersionKind{
+		Group:   "grafana.integreatly.org",
+		Version: "v1beta1",
+		Kind:    "GrafanaDashboard",
+	}
+
+	// grafanaDashboardComponents are the Argo CD components a dashboard is shipped for.
+	grafanaDashboardComponents = []string{
+		"application-controller",
+		"repo-server",
+		"server",
+		"redis",
+		"applicationset-controller",
+	}
+)
+
+// IsGrafanaDashboardAPIAvailable returns true if the GrafanaDashboard API of the grafana-operator is present.
+func IsGrafanaDashboardAPIAvailable() bool {
+	return grafanaDashboardAPIFound
+}
+
+// verifyGrafanaDashboardAPI will verify that the GrafanaDashboard API of the grafana-operator is registered.
+func verifyGrafanaDashboardAPI() error {
+	found, err := argoutil.IsAPIRegistered(grafanaDashboardGVK.Group, grafanaDashboardGVK.Version)
+	if err != nil {
+		return err
+	}
+	grafanaDashboardAPIFound = found
+	return nil
+}
+
+// getGrafanaDashboardPath will return the path for the Grafana dashboard templates.
+func getGrafanaDashboardPath() string {
+	path := os.Getenv("GRAFANA_DASHBOARD_PATH")
+	if len(path) > 0 {
+		return path
+	}
+	return common.ArgoCDDefaultGrafanaDashboardPath
+}
+
+// getGrafanaDashboardName will return the name of the dashboard ConfigMap and GrafanaDashboard of a component.
+func getGrafanaDashboardName(component string, cr *argoproj.ArgoCD) string {
+	return nameWithSuffix(fmt.Sprintf("dashboard-%s", component), cr)
+}
+
+// getGrafanaDashboardKey will return the ConfigMap key of the dashboard of a component. The key is used as file
+// name by the Grafana dashboard sidecar and is unique across instances.
+func getGrafanaDashboardKey(component string, cr *argoproj.ArgoCD) string {
+	return fmt.Sprintf("%s-%s-%s.json", cr.Namespace, cr.Name, component)
+}
+
+// getGrafanaDashboardUID will return a dashboard uid that is unique across instances and within the 40 characters
+// allowed by Grafana.
+func getGrafanaDashboardUID(component string, cr *argoproj.ArgoCD) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", cr.Namespace, cr.Name, component)))
+	return fmt.Sprintf("argocd-%x", sum[:16])
+}
+
+// getGrafanaDashboard will load the dashboard of a component from a template on disk for the given ArgoCD.
+func getGrafanaDashboard(component string, cr *argoproj.ArgoCD) (string, error) {
+	path := fmt.Sprintf("%s/%s.json.tpl", getGrafanaDashboardPath(), component)
+	params := map[string]string{
+		"Name":      cr.Name,
+		"Namespace": cr.Namespace,
+		"UID":       getGrafanaDashboardUID(component, cr),
+	}
+	return loadTemplateFile(path, params)
+}
+
+// isGrafanaDashboardsEnabled returns true when dashboards are shipped for the given ArgoCD.
+func isGrafanaDashboardsEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Monitoring.Dashboards != nil && cr.Spec.Monitoring.Dashboards.Enabled
+}
+
+// isGrafanaOperatorDashboardsEnabled returns true when GrafanaDashboard resources are requested for the given ArgoCD.
+func isGrafanaOperatorDashboardsEnabled(cr *argoproj.ArgoCD) bool {
+	return isGrafanaDashboardsEnabled(cr) && cr.Spec.Monitoring.Dashboards.GrafanaOperator != nil &&
+		cr.Spec.Monitoring.Dashboards.GrafanaOperator.Enabled
+}
+
+// newGrafanaDashboardConfigMap returns the dashboard ConfigMap of a component for the given ArgoCD.
+func newGrafanaDashboardConfigMap(component string, cr *argoproj.ArgoCD) *corev1.ConfigMap {
+	cm := newConfigMapWithName(getGrafanaDashboardName(component, cr), cr)
+	cm.Labels[grafanaDashboardLabel] = "1"
+	if isGrafanaDashboardsEnabled(cr) {
+		for k, v := range cr.Spec.Monitoring.Dashboards.Labels {
+			cm.Labels[k] = v
+		}
+		if len(cr.Spec.Monitoring.Dashboards.Annotations) > 0 {
+			cm.Annotations = map[string]string{}
+			for k, v := range cr.Spec.Monitoring.Dashboards.Annotations {
+				cm.Annotations[k] = v
+			}
+		}
+	}
+	return cm
+}
+
+// newGrafanaDashboard returns the GrafanaDashboard of a component referencing its dashboard ConfigMap.
+func newGrafanaDashboard(component string, cr *argoproj.ArgoCD) (*unstructured.Unstructured, error) {
+	dashboard := &unstructured.Unstructured{}
+	dashboard.SetGroupVersionKind(grafanaDashboardGVK)
+	dashboard.SetName(getGrafanaDashboardName(component, cr))
+	dashboard.SetNamespace(cr.Namespace)
+	dashboard.SetLabels(argoutil.LabelsForCluster(cr))
+
+	// the instance selector is required by the grafana-operator, an empty selector selects all instances
+	spec := map[string]interface{}{
+		"configMapRef": map[string]interface{}{
+			"name": getGrafanaDashboardName(component, cr),
+			"key":  getGrafanaDashboardKey(component, cr),
+		},
+		"instanceSelector":          map[string]interface{}{},
+		"allowCrossNamespaceImport": false,
+	}
+	if isGrafanaOperatorDashboardsEnabled(cr) {
+		opts := cr.Spec.Monitoring.Dashboards.GrafanaOperator
+		if opts.InstanceSelector != nil {
+			selector, err := runtime.DefaultUnstructuredConverter.ToUnstructured(opts.InstanceSelector)
+			if err != nil {
+				return nil, err
+			}
+			spec["instanceSelector"] = selector
+		}
+		if opts.Folder != "" {
+			spec["folder"] = opts.Folder
+		}
+		spec["allowCrossNamespaceImport"] = opts.AllowCrossNamespaceImport
+	}
+	dashboard.Object["spec"] = spec
+	return dashboard, nil
+}
+
+// setGrafanaDashboardSpec will set the spec fields managed by the operator from the desired GrafanaDashboard on the
+// existing one, leaving fields defaulted by the grafana-operator untouched. It returns true when the spec was changed.
+func setGrafanaDashboardSpec(existing, desired *unstructured.Unstructured) bool {
+	spec, ok := existing.Object["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+	}
+	desiredSpec := desired.Object["spec"].(map[string]interface{})
+
+	changed := false
+	for _, field := range []string{"configMapRef", "instanceSelector", "folder", "allowCrossNamespaceImport"} {
+		value, ok := desiredSpec[field]
+		current, exists := spec[field]
+		if !ok {
+			if exists {
+				delete(spec, field)
+				changed = true
+			}
+			continue
+		}
+		if !exists || !reflect.DeepEqual(current, value) {
+			spec[field] = value
+			changed = true
+		}
+	}
+	existing.Object["spec"] = spec
+	return changed
+}
+
+// reconcileGrafanaDashboardConfigMaps will ensure that the dashboard ConfigMaps are present for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileGrafanaDashboardConfigMaps(cr *argoproj.ArgoCD) error {
+	for _, component := range grafanaDashboardComponents {
+		cm := newGrafanaDashboardConfigMap(component, cr)
+		existing := &corev1.ConfigMap{}
+		found := argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, existing)
+
+		if !isGrafanaDashboardsEnabled(cr) {
+			if found {
+				log.Info(fmt.Sprintf("deleting dashboard configmap %s", cm.Name))
+				if err := r.Client.Delete(context.TODO(), existing); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		dashboard, err := getGrafanaDashboard(component, cr)
+		if err != nil {
+			return fmt.Errorf("failed to load %s dashboard: %w", component, err)
+		}
+		cm.Data = map[string]string{getGrafanaDashboardKey(component, cr): dashboard}
+
+		if found {
+			if reflect.DeepEqual(existing.Data, cm.Data) && reflect.DeepEqual(existing.Labels, cm.Labels) &&
+				reflect.DeepEqual(existing.Annotations, cm.Annotations) {
+				continue
+			}
+			existing.Data = cm.Data
+			existing.Labels = cm.Labels
+			existing.Annotations = cm.Annotations
+			log.Info(fmt.Sprintf("updating dashboard configmap %s", cm.Name))
+			if err := r.Client.Update(context.TODO(), existing); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("creating dashboard configmap %s", cm.Name))
+		if err := r.Client.Create(context.TODO(), cm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileGrafanaDashboardResources will ensure that the GrafanaDashboard resources are present for the given
+// ArgoCD when the grafana-operator APIs are available.
+func (r *ReconcileArgoCD) reconcileGrafanaDashboardResources(cr *argoproj.ArgoCD) error {
+	if !IsGrafanaDashboardAPIAvailable() {
+		if isGrafanaOperatorDashboardsEnabled(cr) {
+			log.Info("GrafanaDashboard API is not registered, skipping GrafanaDashboard resources")
+		}
+		return nil
+	}
+
+	for _, component := range grafanaDashboardComponents {
+		dashboard, err := newGrafanaDashboard(component, cr)
+		if err != nil {
+			return err
+		}
+
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(grafanaDashboardGVK)
+		found := argoutil.IsObjectFound(r.Client, cr.Namespace, dashboard.GetName(), existing)
+
+		if !isGrafanaOperatorDashboardsEnabled(cr) {
+			if found {
+				log.Info(fmt.Sprintf("deleting grafana dashboard %s", dashboard.GetName()))
+				if err := r.Client.Delete(context.TODO(), existing); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if found {
+			if !setGrafanaDashboardSpec(existing, dashboard) {
+				continue
+			}
+			log.Info(fmt.Sprintf("updating grafana dashboard %s", dashboard.GetName()))
+			if err := r.Client.Update(context.TODO(), existing); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := controllerutil.SetControllerReference(cr, dashboard, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("creating grafana dashboard %s", dashboard.GetName()))
+		if err := r.Client.Create(context.TODO(), dashboard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func makeTestArgoCDWithDashboards(opts ...argoCDOpt) *argoproj.ArgoCD {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Monitoring.Dashboards = &argoproj.ArgoCDDashboardsSpec{
+			Enabled:     true,
+			Labels:      map[string]string{"team": "platform"},
+			Annotations: map[string]string{"grafana_folder": "Argo CD"},
+		}
+	})
+	for _, o := range opts {
+		o(a)
+	}
+	return a
+}
+
+func TestGetGrafanaDashboard(t *testing.T) {
+	t.Setenv("GRAFANA_DASHBOARD_PATH", "../../build/grafana/dashboards")
+	a := makeTestArgoCDWithDashboards()
+
+	uids := map[string]bool{}
+	for _, component := range grafanaDashboardComponents {
+		dashboard, err := getGrafanaDashboard(component, a)
+		assert.NoError(t, err)
+
+		parsed := struct {
+			UID    string `json:"uid"`
+			Title  string `json:"title"`
+			Panels []struct {
+				Targets []struct {
+					Expr         string `json:"expr"`
+					LegendFormat string `json:"legendFormat"`
+				} `json:"targets"`
+			} `json:"panels"`
+		}{}
+		assert.NoError(t, json.Unmarshal([]byte(dashboard), &parsed), component)
+		assert.LessOrEqual(t, len(parsed.UID), 40)
+		assert.Contains(t, parsed.Title, a.Namespace+"/"+a.Name)
+		assert.NotEmpty(t, parsed.Panels)
+		for _, p := range parsed.Panels {
+			for _, target := range p.Targets {
+				assert.Contains(t, target.Expr, `namespace="`+a.Namespace+`"`)
+				assert.False(t, strings.Contains(target.LegendFormat, `"`))
+			}
+		}
+		uids[parsed.UID] = true
+	}
+	assert.Len(t, uids, len(grafanaDashboardComponents))
+}
+
+func TestReconcileGrafanaDashboards(t *testing.T) {
+	t.Setenv("GRAFANA_DASHBOARD_PATH", "../../build/grafana/dashboards")
+	a := makeTestArgoCDWithDashboards()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileGrafanaDashboards(a))
+
+	for _, component := range grafanaDashboardComponents {
+		cm := &corev1.ConfigMap{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: getGrafanaDashboardName(component, a), Namespace: a.Namespace}, cm))
+		assert.Equal(t, "1", cm.Labels[grafanaDashboardLabel])
+		assert.Equal(t, "platform", cm.Labels["team"])
+		assert.Equal(t, "Argo CD", cm.Annotations["grafana_folder"])
+		assert.Contains(t, cm.Data, getGrafanaDashboardKey(component, a))
+	}
+
+	// dashboards are removed once disabled
+	a.Spec.Monitoring.Dashboards.Enabled = false
+	assert.NoError(t, r.reconcileGrafanaDashboards(a))
+
+	list := &corev1.ConfigMapList{}
+	assert.NoError(t, r.Client.List(context.TODO(), list, client.MatchingLabels{grafanaDashboardLabel: "1"}))
+	assert.Empty(t, list.Items)
+}
+
+func TestReconcileGrafanaDashboards_grafanaOperator(t *testing.T) {
+	t.Setenv("GRAFANA_DASHBOARD_PATH", "../../build/grafana/dashboards")
+	grafanaDashboardAPIFound = true
+	defer func() { grafanaDashboardAPIFound = false }()
+
+	a := makeTestArgoCDWithDashboards(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Monitoring.Dashboards.GrafanaOperator = &argoproj.ArgoCDGrafanaOperatorDashboardsSpec{
+			Enabled: true,
+			InstanceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"dashboards": "grafana"},
+			},
+			Folder: "Argo CD",
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileGrafanaDashboards(a))
+
+	name := getGrafanaDashboardName("server", a)
+	dashboard := &unstructured.Unstructured{}
+	dashboard.SetGroupVersionKind(grafanaDashboardGVK)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, dashboard))
+
+	ref, _, _ := unstructured.NestedStringMap(dashboard.Object, "spec", "configMapRef")
+	assert.Equal(t, map[string]string{"name": name, "key": getGrafanaDashboardKey("server", a)}, ref)
+	selector, _, _ := unstructured.NestedStringMap(dashboard.Object, "spec", "instanceSelector", "matchLabels")
+	assert.Equal(t, map[string]string{"dashboards": "grafana"}, selector)
+	folder, _, _ := unstructured.NestedString(dashboard.Object, "spec", "folder")
+	assert.Equal(t, "Argo CD", folder)
+
+	// fields defaulted by the grafana-operator are kept and the folder is removed once unset
+	assert.NoError(t, unstructured.SetNestedField(dashboard.Object, "5m", "spec", "resyncPeriod"))
+	assert.NoError(t, r.Client.Update(context.TODO(), dashboard))
+	a.Spec.Monitoring.Dashboards.GrafanaOperator.Folder = ""
+	assert.NoError(t, r.reconcileGrafanaDashboards(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, dashboard))
+	resync, _, _ := unstructured.NestedString(dashboard.Object, "spec", "resyncPeriod")
+	assert.Equal(t, "5m", resync)
+	_, found, _ := unstructured.NestedString(dashboard.Object, "spec", "folder")
+	assert.False(t, found)
+
+	// GrafanaDashboard resources are removed once disabled
+	a.Spec.Monitoring.Dashboards.GrafanaOperator.Enabled = false
+	assert.NoError(t, r.reconcileGrafanaDashboards(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, dashboard)
+	assert.Error(t, err)
+}
@@ -0,0 +1,278 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// registryHTTPClient is the client used to query the container registries.
+var registryHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// registryManifestMediaTypes are the manifest media types accepted from the container registries. The image indexes
+// are preferred, so that the digests are valid on every platform.
+var registryManifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// registryCredentials are the credentials used to authenticate to a container registry.
+type registryCredentials struct {
+	username string
+	password string
+}
+
+// getRegistryCredentials will return the registry credentials of the given docker config secrets, by registry.
+func getRegistryCredentials(secrets []corev1.Secret) map[string]registryCredentials {
+	type dockerAuth struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+
+	credentials := map[string]registryCredentials{}
+	for _, secret := range secrets {
+		auths := map[string]dockerAuth{}
+		switch secret.Type {
+		case corev1.SecretTypeDockerConfigJson:
+			config := struct {
+				Auths map[string]dockerAuth `json:"auths"`
+			}{}
+			if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+				log.Error(err, fmt.Sprintf("unable to parse image pull secret %s", secret.Name))
+				continue
+			}
+			auths = config.Auths
+		case corev1.SecretTypeDockercfg:
+			if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &auths); err != nil {
+				log.Error(err, fmt.Sprintf("unable to parse image pull secret %s", secret.Name))
+				continue
+			}
+		default:
+			continue
+		}
+
+		for server, auth := range auths {
+			creds := registryCredentials{username: auth.Username, password: auth.Password}
+			if auth.Auth != "" {
+				if decoded, err := base64.StdEncoding.DecodeString(auth.Auth); err == nil {
+					if username, password, ok := strings.Cut(string(decoded), ":"); ok {
+						creds = registryCredentials{username: username, password: password}
+					}
+				}
+			}
+			credentials[getRegistryHost(server)] = creds
+		}
+	}
+	return credentials
+}
+
+// getRegistryHost will return the registry host of the given docker config server, e.g. https://index.docker.io/v1/.
+func getRegistryHost(server string) string {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	server, _, _ = strings.Cut(server, "/")
+	switch server {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return server
+}
+
+// resolveImageDigest will query the registry of the given image reference for the digest of the manifest it points
+// to, using the given registry credentials.
+func resolveImageDigest(image string, credentials map[string]registryCredentials) (string, error) {
+	registry, repository, reference := argoutil.ParseImageReference(image)
+	host := registry
+	if registry == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repository, reference)
+	creds, hasCreds := credentials[registry]
+
+	authorization := ""
+	resp, err := headManifest(manifestURL, authorization)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err = getRegistryAuthorization(resp.Header.Get("WWW-Authenticate"), repository, creds, hasCreds)
+		if err != nil {
+			return "", fmt.Errorf("unable to authenticate to registry %s: %w", registry, err)
+		}
+		resp, err = headManifest(manifestURL, authorization)
+		if err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry %s returned %s for image %s", registry, resp.Status, image)
+	}
+
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	return getManifestDigest(manifestURL, authorization)
+}
+
+// getManifestDigest will download the given manifest and return its digest, for the registries that do not return
+// the digest of the manifests in a header.
+func getManifestDigest(manifestURL string, authorization string) (string, error) {
+	req, err := newManifestRequest(http.MethodGet, manifestURL, authorization)
+	if err != nil {
+		return "", err
+	}
+	resp, err := registryHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to get manifest %s: %s", manifestURL, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(body)), nil
+}
+
+// newManifestRequest will return a request for a manifest of a registry, with the given authorization header if set.
+func newManifestRequest(method string, manifestURL string, authorization string) (*http.Request, error) {
+	req, err := http.NewRequest(method, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(registryManifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return req, nil
+}
+
+// headManifest will send a HEAD request for a manifest to a registry, with the given authorization header if set.
+func headManifest(manifestURL string, authorization string) (*http.Response, error) {
+	req, err := newManifestRequest(http.MethodHead, manifestURL, authorization)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := registryHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// getRegistryAuthorization will return the authorization header answering the given authentication challenge of a
+// registry. Bearer tokens are requested from the token service of the registry, anonymously without credentials.
+func getRegistryAuthorization(challenge string, repository string, creds registryCredentials, hasCreds bool) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasCreds {
+			return "", fmt.Errorf("registry requires credentials, none found in the image pull secrets")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(creds.username+":"+creds.password)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	values := parseAuthenticationParams(params)
+	realm := values["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("authentication challenge %q has no realm", challenge)
+	}
+	query := url.Values{}
+	if service := values["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := values["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", repository)
+	}
+	query.Set("scope", scope)
+
+	req, err := http.NewRequest(http.MethodGet, realm+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	if hasCreds {
+		req.SetBasicAuth(creds.username, creds.password)
+	}
+	resp, err := registryHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token service %s returned %s", realm, resp.Status)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("token service %s returned no token", realm)
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// parseAuthenticationParams will parse the comma separated key="value" parameters of an authentication challenge.
+func parseAuthenticationParams(params string) map[string]string {
+	values := map[string]string{}
+	for len(params) > 0 {
+		params = strings.TrimLeft(params, " ,")
+		key, rest, ok := strings.Cut(params, "=")
+		if !ok {
+			break
+		}
+		value := ""
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		values[strings.ToLower(strings.TrimSpace(key))] = value
+		params = rest
+	}
+	return values
+}
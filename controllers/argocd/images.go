@@ -0,0 +1,166 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// imageDigestResolver returns the digest of the manifest the given image reference points to, using the given
+// registry credentials.
+type imageDigestResolver func(image string, credentials map[string]registryCredentials) (string, error)
+
+// setPodImagePullSecrets will set the image pull secrets of the given ArgoCD on the given pod spec.
+func setPodImagePullSecrets(cr *argoproj.ArgoCD, podSpec *corev1.PodSpec) {
+	podSpec.ImagePullSecrets = cr.Spec.ImagePullSecrets
+}
+
+// updatePodImagePullSecrets will copy the image pull secrets of the desired pod spec to the existing pod spec, and set
+// the changed flag when they differ.
+func updatePodImagePullSecrets(existing *corev1.PodSpec, desired *corev1.PodSpec, changed *bool) {
+	if len(existing.ImagePullSecrets) == 0 && len(desired.ImagePullSecrets) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(existing.ImagePullSecrets, desired.ImagePullSecrets) {
+		existing.ImagePullSecrets = desired.ImagePullSecrets
+		*changed = true
+	}
+}
+
+// getInstanceImages will return the image references of the workloads of the given ArgoCD, with the registry options
+// applied but not pinned to their digests.
+func (r *ReconcileArgoCD) getInstanceImages(cr *argoproj.ArgoCD) []string {
+	unpinned := cr.DeepCopy()
+	unpinned.Status.Images = nil
+
+	images := []string{
+		getArgoContainerImage(unpinned),
+		getRepoServerContainerImage(unpinned),
+	}
+	if unpinned.Spec.ApplicationSet != nil {
+		images = append(images, getApplicationSetContainerImage(unpinned))
+	}
+	if unpinned.Spec.HA.Enabled {
+		images = append(images, getRedisHAContainerImage(unpinned), getRedisHAProxyContainerImage(unpinned))
+	} else {
+		images = append(images, getRedisContainerImage(unpinned))
+	}
+	if UseDex(unpinned) {
+		images = append(images, getDexContainerImage(unpinned))
+	}
+	if unpinned.Spec.SSO != nil && unpinned.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak && !isKeycloakExternalMode(unpinned) {
+		if isKeycloakQuarkusMode(unpinned) {
+			images = append(images, getKeycloakQuarkusContainerImage(unpinned))
+		} else {
+			images = append(images, getKeycloakContainerImage(unpinned))
+		}
+	}
+	if hasServerExtensions(unpinned) {
+		images = append(images, argoutil.GetImageReference(unpinned, common.ArgoCDExtensionInstallerImage))
+	}
+	if export := r.getArgoCDExport(unpinned); export != nil {
+		images = append(images, getArgoImportContainerImage(unpinned, export))
+	}
+
+	unique := []string{}
+	for _, image := range images {
+		if !containsString(unique, image) {
+			unique = append(unique, image)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+// getImagePullCredentials will return the registry credentials of the image pull secrets of the given ArgoCD.
+func (r *ReconcileArgoCD) getImagePullCredentials(cr *argoproj.ArgoCD) map[string]registryCredentials {
+	secrets := []corev1.Secret{}
+	for _, ref := range cr.Spec.ImagePullSecrets {
+		secret := &corev1.Secret{}
+		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: cr.Namespace}, secret); err != nil {
+			log.Error(err, fmt.Sprintf("unable to read image pull secret %s in namespace %s", ref.Name, cr.Namespace))
+			continue
+		}
+		secrets = append(secrets, *secret)
+	}
+	return getRegistryCredentials(secrets)
+}
+
+// reconcileImageDigests will ensure that the digests of the images of the workloads of the given ArgoCD are recorded
+// in the status when they are resolved. A digest is only resolved once per image reference, the digests of the images
+// that are no longer used are removed. The registries are not queried while planning, the recorded digests are used.
+func (r *ReconcileArgoCD) reconcileImageDigests(cr *argoproj.ArgoCD) error {
+	if r.planning {
+		return nil
+	}
+
+	if cr.Spec.ImageRegistry == nil || !cr.Spec.ImageRegistry.ResolveDigests {
+		if len(cr.Status.Images) == 0 {
+			return nil
+		}
+		cr.Status.Images = nil
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+
+	resolve := r.digestResolver
+	if resolve == nil {
+		resolve = resolveImageDigest
+	}
+
+	var credentials map[string]registryCredentials
+	var images []argoproj.ArgoCDImageStatus
+	for _, image := range r.getInstanceImages(cr) {
+		if strings.Contains(image, "@") {
+			continue
+		}
+
+		digest := ""
+		for _, resolved := range cr.Status.Images {
+			if resolved.Image == image {
+				digest = resolved.Digest
+			}
+		}
+		if digest == "" {
+			if credentials == nil {
+				credentials = r.getImagePullCredentials(cr)
+			}
+			resolved, err := resolve(image, credentials)
+			if err != nil {
+				log.Error(err, fmt.Sprintf("unable to resolve the digest of image %s for ArgoCD %s in namespace %s", image, cr.Name, cr.Namespace))
+				continue
+			}
+			log.Info(fmt.Sprintf("resolved image %s to digest %s for ArgoCD %s in namespace %s", image, resolved, cr.Name, cr.Namespace))
+			digest = resolved
+		}
+		images = append(images, argoproj.ArgoCDImageStatus{Image: image, Digest: digest})
+	}
+
+	if reflect.DeepEqual(images, cr.Status.Images) {
+		return nil
+	}
+	cr.Status.Images = images
+	return r.Client.Status().Update(context.TODO(), cr)
+}
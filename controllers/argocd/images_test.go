@@ -0,0 +1,147 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestReconcileArgoCD_reconcileImageDigests(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Version = "v2.11.0"
+		cr.Spec.Redis.Version = "7.0.15-alpine"
+		cr.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "mirror-pull-secret"}}
+		cr.Spec.ImageRegistry = &argoproj.ArgoCDImageRegistrySpec{
+			Registry:       "registry.example.com",
+			ResolveDigests: true,
+		}
+	})
+	pullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "mirror-pull-secret", Namespace: a.Namespace},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths": {"registry.example.com": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("mirror:s3cr3t")) + `"}}}`),
+		},
+	}
+
+	resObjs := []client.Object{a, pullSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	resolved := []string{}
+	r.digestResolver = func(image string, credentials map[string]registryCredentials) (string, error) {
+		assert.Equal(t, registryCredentials{username: "mirror", password: "s3cr3t"}, credentials["registry.example.com"])
+		resolved = append(resolved, image)
+		return fmt.Sprintf("sha256:%d", len(resolved)), nil
+	}
+
+	assert.NoError(t, r.reconcileImageDigests(a))
+	argoImage := "registry.example.com/argoproj/argocd:v2.11.0"
+	redisImage := "registry.example.com/library/redis:7.0.15-alpine"
+	assert.ElementsMatch(t, []string{argoImage, redisImage}, resolved)
+	assert.Len(t, a.Status.Images, 2)
+
+	// the workloads use the resolved digests and the image pull secrets
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	deploy := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deploy))
+	assert.True(t, strings.HasPrefix(deploy.Spec.Template.Spec.Containers[0].Image, argoImage+"@sha256:"))
+	assert.Equal(t, a.Spec.ImagePullSecrets, deploy.Spec.Template.Spec.ImagePullSecrets)
+
+	// the digests are only resolved once
+	assert.NoError(t, r.reconcileImageDigests(a))
+	assert.Len(t, resolved, 2)
+
+	// the digests of the images no longer used are removed
+	a.Spec.Version = "v2.12.0"
+	assert.NoError(t, r.reconcileImageDigests(a))
+	assert.Len(t, resolved, 3)
+	assert.Equal(t, []argoproj.ArgoCDImageStatus{
+		{Image: "registry.example.com/argoproj/argocd:v2.12.0", Digest: "sha256:3"},
+		{Image: redisImage, Digest: a.Status.Images[1].Digest},
+	}, a.Status.Images)
+
+	// the digests are cleared when they are no longer resolved
+	a.Spec.ImageRegistry.ResolveDigests = false
+	assert.NoError(t, r.reconcileImageDigests(a))
+	assert.Empty(t, a.Status.Images)
+}
+
+func TestResolveImageDigest(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/token":
+			username, password, ok := req.BasicAuth()
+			if !ok || username != "mirror" || password != "s3cr3t" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, "repository:argoproj/argocd:pull", req.URL.Query().Get("scope"))
+			fmt.Fprint(w, `{"token": "t0k3n"}`)
+		case "/v2/argoproj/argocd/manifests/v2.11.0":
+			if req.Header.Get("Authorization") != "Bearer t0k3n" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:argoproj/argocd:pull"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", "sha256:abc")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	defaultClient := registryHTTPClient
+	registryHTTPClient = server.Client()
+	defer func() { registryHTTPClient = defaultClient }()
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	credentials := getRegistryCredentials([]corev1.Secret{{
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths": {"https://` + host + `/v1/": {"username": "mirror", "password": "s3cr3t"}}}`),
+		},
+	}})
+
+	digest, err := resolveImageDigest(host+"/argoproj/argocd:v2.11.0", credentials)
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256:abc", digest)
+
+	_, err = resolveImageDigest(host+"/argoproj/argocd:v2.11.0", nil)
+	assert.ErrorContains(t, err, "unable to authenticate to registry")
+
+	_, err = resolveImageDigest(host+"/argoproj/argocd:v0.0.0", credentials)
+	assert.ErrorContains(t, err, "404")
+}
@@ -81,6 +81,8 @@ const (
 )
 
 var (
+	// scopes requested by Argo CD from the argocd realm.
+	keycloakRequestedScopes = []string{"openid", "profile", "email", "groups"}
 	// client secret for keycloak, argocd and openshift-v4 IdP.
 	oAuthClientSecret       = generateRandomString(8)
 	graceTime         int64 = 75
@@ -130,9 +132,9 @@ func getKeycloakContainerImage(cr *argoproj.ArgoCD) string {
 		defaultTag = true
 	}
 	if e := os.Getenv(common.ArgoCDKeycloakImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
+		return argoutil.GetImageReference(cr, e)
 	}
-	return argoutil.CombineImageTag(img, tag)
+	return argoutil.GetImageReference(cr, argoutil.CombineImageTag(img, tag))
 }
 
 func getKeycloakConfigMapTemplate(ns string) *corev1.ConfigMap {
@@ -197,6 +199,14 @@ func getKeycloakResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 	return resources
 }
 
+// getKeycloakPatches will return the patches applied to the Keycloak Deployment for the given ArgoCD.
+func getKeycloakPatches(cr *argoproj.ArgoCD) []argoproj.ArgoCDResourcePatch {
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Keycloak != nil {
+		return cr.Spec.SSO.Keycloak.Patches
+	}
+	return nil
+}
+
 func getKeycloakContainer(cr *argoproj.ArgoCD) corev1.Container {
 	envVars := []corev1.EnvVar{
 		{Name: "SSO_HOSTNAME", Value: "${SSO_HOSTNAME}"},
@@ -620,6 +630,7 @@ func newKeycloakDeployment(cr *argoproj.ArgoCD) *k8sappsv1.Deployment {
 					},
 				},
 				Spec: corev1.PodSpec{
+					ImagePullSecrets: cr.Spec.ImagePullSecrets,
 					Containers: []corev1.Container{
 						{
 							Name:  defaultKeycloakIdentifier,
@@ -693,6 +704,9 @@ func (r *ReconcileArgoCD) newKeycloakInstance(cr *argoproj.ArgoCD) error {
 
 	if err != nil {
 		if errors.IsNotFound(err) {
+			if err := applyResourcePatches(dep, getKeycloakPatches(cr)); err != nil {
+				return err
+			}
 			if err := controllerutil.SetControllerReference(cr, dep, r.Scheme); err != nil {
 				return err
 			}
@@ -835,7 +849,56 @@ func (r *ReconcileArgoCD) prepareKeycloakConfigForK8s(cr *argoproj.ArgoCD) (*key
 // creates a keycloak realm configuration which when posted to keycloak using http client creates a keycloak realm.
 func createRealmConfig(cfg *keycloakConfig) ([]byte, error) {
 
-	ks := &CustomKeycloakAPIRealm{
+	ks := newArgoCDRealm(cfg, oAuthClientSecret)
+
+	// Add OpenShift-v4 as Identity Provider only for OpenShift environment.
+	// No Identity Provider is configured by default for non-openshift environments.
+	if CanUseKeycloakWithTemplate() {
+		baseURL := "https://kubernetes.default.svc.cluster.local"
+		if isProxyCluster() {
+			baseURL = getOpenShiftAPIURL()
+		}
+
+		ks.IdentityProviders = []*KeycloakIdentityProvider{
+			{
+				Alias:       "openshift-v4",
+				DisplayName: "Login with OpenShift",
+				ProviderID:  "openshift-v4",
+				Config: map[string]string{
+					"baseUrl":      baseURL,
+					"clientSecret": oAuthClientSecret,
+					"clientId":     getOAuthClient(cfg.ArgoNamespace),
+					"defaultScope": "user:full",
+					"syncMode":     "FORCE",
+				},
+			},
+		}
+		ks.IdentityProviderMappers = []*KeycloakIdentityProviderMapper{
+			{
+				Name:                   "groups",
+				IdentityProviderAlias:  "openshift-v4",
+				IdentityProviderMapper: "openshift-v4-user-attribute-mapper",
+				Config: map[string]string{
+					"syncMode":      "INHERIT",
+					"jsonField":     "groups",
+					"userAttribute": "groups",
+				},
+			},
+		}
+	}
+
+	json, err := json.Marshal(ks)
+	if err != nil {
+		return nil, err
+	}
+
+	return json, nil
+}
+
+// newArgoCDRealm returns the argocd realm with the argocd client using the given client secret.
+func newArgoCDRealm(cfg *keycloakConfig, clientSecret string) *CustomKeycloakAPIRealm {
+
+	return &CustomKeycloakAPIRealm{
 		Realm:       keycloakRealm,
 		Enabled:     true,
 		SslRequired: "external",
@@ -846,7 +909,7 @@ func createRealmConfig(cfg *keycloakConfig) ([]byte, error) {
 				RootURL:                 cfg.ArgoCDURL,
 				AdminURL:                cfg.ArgoCDURL,
 				ClientAuthenticatorType: "client-secret",
-				Secret:                  oAuthClientSecret,
+				Secret:                  clientSecret,
 				RedirectUris: []string{fmt.Sprintf("%s/%s",
 					cfg.ArgoCDURL, "auth/callback")},
 				WebOrigins: []string{cfg.ArgoCDURL},
@@ -911,49 +974,6 @@ func createRealmConfig(cfg *keycloakConfig) ([]byte, error) {
 			},
 		},
 	}
-
-	// Add OpenShift-v4 as Identity Provider only for OpenShift environment.
-	// No Identity Provider is configured by default for non-openshift environments.
-	if CanUseKeycloakWithTemplate() {
-		baseURL := "https://kubernetes.default.svc.cluster.local"
-		if isProxyCluster() {
-			baseURL = getOpenShiftAPIURL()
-		}
-
-		ks.IdentityProviders = []*KeycloakIdentityProvider{
-			{
-				Alias:       "openshift-v4",
-				DisplayName: "Login with OpenShift",
-				ProviderID:  "openshift-v4",
-				Config: map[string]string{
-					"baseUrl":      baseURL,
-					"clientSecret": oAuthClientSecret,
-					"clientId":     getOAuthClient(cfg.ArgoNamespace),
-					"defaultScope": "user:full",
-					"syncMode":     "FORCE",
-				},
-			},
-		}
-		ks.IdentityProviderMappers = []*KeycloakIdentityProviderMapper{
-			{
-				Name:                   "groups",
-				IdentityProviderAlias:  "openshift-v4",
-				IdentityProviderMapper: "openshift-v4-user-attribute-mapper",
-				Config: map[string]string{
-					"syncMode":      "INHERIT",
-					"jsonField":     "groups",
-					"userAttribute": "groups",
-				},
-			},
-		}
-	}
-
-	json, err := json.Marshal(ks)
-	if err != nil {
-		return nil, err
-	}
-
-	return json, nil
 }
 
 // Gets Keycloak Server cert. This cert is used to authenticate the api calls to the Keycloak service.
@@ -985,29 +1005,6 @@ func getOAuthClient(ns string) string {
 // Updates OIDC configuration for ArgoCD.
 func (r *ReconcileArgoCD) updateArgoCDConfiguration(cr *argoproj.ArgoCD, kRouteURL string) error {
 
-	// Update the ArgoCD client secret for OIDC in argocd-secret.
-	argoCDSecret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      common.ArgoCDSecretName,
-			Namespace: cr.Namespace,
-		},
-	}
-
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: argoCDSecret.Name, Namespace: argoCDSecret.Namespace}, argoCDSecret)
-	if err != nil {
-		log.Error(err, fmt.Sprintf("ArgoCD secret not found for ArgoCD %s in namespace %s",
-			cr.Name, cr.Namespace))
-		return err
-	}
-
-	argoCDSecret.Data["oidc.keycloak.clientSecret"] = []byte(oAuthClientSecret)
-	err = r.Client.Update(context.TODO(), argoCDSecret)
-	if err != nil {
-		log.Error(err, fmt.Sprintf("Error updating ArgoCD Secret for ArgoCD %s in namespace %s",
-			cr.Name, cr.Namespace))
-		return err
-	}
-
 	// Create openshift OAuthClient
 	if CanUseKeycloakWithTemplate() {
 		oAuthClient := &oauthv1.OAuthClient{
@@ -1025,7 +1022,7 @@ func (r *ReconcileArgoCD) updateArgoCDConfiguration(cr *argoproj.ArgoCD, kRouteU
 			GrantMethod: "prompt",
 		}
 
-		err = controllerutil.SetOwnerReference(cr, oAuthClient, r.Scheme)
+		err := controllerutil.SetOwnerReference(cr, oAuthClient, r.Scheme)
 		if err != nil {
 			return err
 		}
@@ -1041,18 +1038,47 @@ func (r *ReconcileArgoCD) updateArgoCDConfiguration(cr *argoproj.ArgoCD, kRouteU
 		}
 	}
 
+	return r.updateArgoCDOIDCConfiguration(cr, fmt.Sprintf("%s/auth/realms/%s", kRouteURL, keycloakRealm), oAuthClientSecret, keycloakRequestedScopes)
+}
+
+// Updates the client secret in argocd-secret, the oidc.config in argocd-cm and the
+// RBAC scopes in argocd-rbac-cm for the Keycloak realm with the given issuer URL.
+func (r *ReconcileArgoCD) updateArgoCDOIDCConfiguration(cr *argoproj.ArgoCD, issuer string, clientSecret string, scopes []string) error {
+
+	// Update the ArgoCD client secret for OIDC in argocd-secret.
+	argoCDSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDSecretName,
+			Namespace: cr.Namespace,
+		},
+	}
+
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: argoCDSecret.Name, Namespace: argoCDSecret.Namespace}, argoCDSecret)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("ArgoCD secret not found for ArgoCD %s in namespace %s",
+			cr.Name, cr.Namespace))
+		return err
+	}
+
+	argoCDSecret.Data["oidc.keycloak.clientSecret"] = []byte(clientSecret)
+	err = r.Client.Update(context.TODO(), argoCDSecret)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("Error updating ArgoCD Secret for ArgoCD %s in namespace %s",
+			cr.Name, cr.Namespace))
+		return err
+	}
+
 	// Update ArgoCD instance for OIDC Config with Keycloakrealm URL
 	rootCA := ""
-	if cr.Spec.SSO.Keycloak.RootCA != "" {
+	if cr.Spec.SSO.Keycloak != nil && cr.Spec.SSO.Keycloak.RootCA != "" {
 		rootCA = cr.Spec.SSO.Keycloak.RootCA
 	}
 	o, err := yaml.Marshal(oidcConfig{
-		Name: "Keycloak",
-		Issuer: fmt.Sprintf("%s/auth/realms/%s",
-			kRouteURL, keycloakRealm),
+		Name:           "Keycloak",
+		Issuer:         issuer,
 		ClientID:       keycloakClient,
 		ClientSecret:   "$oidc.keycloak.clientSecret",
-		RequestedScope: []string{"openid", "profile", "email", "groups"},
+		RequestedScope: scopes,
 		RootCA:         rootCA,
 	})
 
@@ -1131,6 +1157,21 @@ func handleKeycloakPodDeletion(dc *appsv1.DeploymentConfig) error {
 
 func (r *ReconcileArgoCD) reconcileKeycloakConfiguration(cr *argoproj.ArgoCD) error {
 
+	// Keycloak is configured through its own API, which cannot be planned.
+	if r.planning {
+		return nil
+	}
+
+	// External mode, manage only the argocd client in an existing realm.
+	if isKeycloakExternalMode(cr) {
+		return r.reconcileKeycloakExternal(cr)
+	}
+
+	// Quarkus mode, install keycloak using a Deployment that imports the realm at startup.
+	if isKeycloakQuarkusMode(cr) {
+		return r.reconcileKeycloakQuarkus(cr)
+	}
+
 	// TemplateAPI is available, Install keycloak using openshift templates.
 	if CanUseKeycloakWithTemplate() {
 		err := r.reconcileKeycloakForOpenShift(cr)
@@ -1147,10 +1188,24 @@ func (r *ReconcileArgoCD) reconcileKeycloakConfiguration(cr *argoproj.ArgoCD) er
 	return nil
 }
 
+// removeKeycloakConfiguration will delete the Keycloak configuration of the given ArgoCD, unless the reconciler only
+// plans the changes to its resources.
+func (r *ReconcileArgoCD) removeKeycloakConfiguration(cr *argoproj.ArgoCD) error {
+	if r.planning {
+		return nil
+	}
+	return deleteKeycloakConfiguration(cr)
+}
+
 func deleteKeycloakConfiguration(cr *argoproj.ArgoCD) error {
 
+	// Nothing is installed for an external Keycloak, the argocd client is left in the external realm.
+	if isKeycloakExternalMode(cr) {
+		return nil
+	}
+
 	// If SSO is installed using OpenShift templates.
-	if CanUseKeycloakWithTemplate() {
+	if CanUseKeycloakWithTemplate() && !isKeycloakQuarkusMode(cr) {
 		err := deleteKeycloakConfigForOpenShift(cr)
 		if err != nil {
 			return err
@@ -1290,6 +1345,12 @@ func deleteKeycloakConfigForK8s(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	// The realm import ConfigMap only exists when Keycloak was installed in quarkus mode.
+	err = clientset.CoreV1().ConfigMaps(cr.Namespace).Delete(context.TODO(), keycloakRealmImportConfigMapName, deleteOptions)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
 	return nil
 }
 
@@ -1444,20 +1505,32 @@ func (r *ReconcileArgoCD) reconcileKeycloak(cr *argoproj.ArgoCD) error {
 		log.Error(err, fmt.Sprintf("Keycloak Deployment not found or being created for ArgoCD %s in namespace %s",
 			cr.Name, cr.Namespace))
 	} else {
+		desired := newKeycloakDeployment(cr)
+		rendered := desired.DeepCopy()
+		if err := applyResourcePatches(desired, getKeycloakPatches(cr)); err != nil {
+			return err
+		}
+
 		changed := false
 		// Handle Image upgrades
-		desiredImage := getKeycloakContainerImage(cr)
+		desiredImage := desired.Spec.Template.Spec.Containers[0].Image
 		if existingDeployment.Spec.Template.Spec.Containers[0].Image != desiredImage {
 			existingDeployment.Spec.Template.Spec.Containers[0].Image = desiredImage
 			changed = true
 		}
 
-		desiredSecurityContext := restrictedContainerSecurityContext()
+		desiredSecurityContext := desired.Spec.Template.Spec.Containers[0].SecurityContext
 		if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].SecurityContext, desiredSecurityContext) {
 			existingDeployment.Spec.Template.Spec.Containers[0].SecurityContext = desiredSecurityContext
 			changed = true
 		}
 
+		updatePodImagePullSecrets(&existingDeployment.Spec.Template.Spec, &corev1.PodSpec{ImagePullSecrets: cr.Spec.ImagePullSecrets}, &changed)
+
+		if err := updatePatchedResource(existingDeployment, rendered, getKeycloakPatches(cr), &changed); err != nil {
+			return err
+		}
+
 		if changed {
 			err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 				return r.Client.Update(context.TODO(), existingDeployment)
@@ -70,9 +70,24 @@ func (h *httpclient) login(user, pass string) error {
 	form.Add("client_id", "admin-cli")
 	form.Add("grant_type", "password")
 
+	return h.requestToken(authURL, form)
+}
+
+// loginServiceAccount requests a new auth token for a service account client of the given realm.
+func (h *httpclient) loginServiceAccount(realm, clientID, clientSecret string) error {
+	form := url.Values{}
+	form.Add("client_id", clientID)
+	form.Add("client_secret", clientSecret)
+	form.Add("grant_type", "client_credentials")
+
+	return h.requestToken(fmt.Sprintf("/realms/%s/protocol/openid-connect/token", realm), form)
+}
+
+// requestToken posts the given form to the token endpoint and updates the auth token for httpclient.
+func (h *httpclient) requestToken(path string, form url.Values) error {
 	req, err := http.NewRequest(
 		"POST",
-		fmt.Sprintf("%s%s", h.URL, authURL),
+		fmt.Sprintf("%s%s", h.URL, path),
 		strings.NewReader(form.Encode()),
 	)
 	if err != nil {
@@ -98,7 +113,7 @@ func (h *httpclient) login(user, pass string) error {
 	}
 
 	if tokenRes.Error != "" {
-		return err
+		return errors.Errorf("token request failed: %s", tokenRes.Error)
 	}
 
 	h.token = tokenRes.AccessToken
@@ -128,6 +143,122 @@ func (h *httpclient) post(realmConfig []byte) (string, error) {
 	return response.Status, nil
 }
 
+// do sends a JSON request with the auth token to the given path of the Keycloak admin API. The response body
+// is decoded into out when given.
+func (h *httpclient) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(b)
+	}
+
+	request, err := http.NewRequest(method, fmt.Sprintf("%s%s", h.URL, path), body)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", h.token))
+
+	response, err := h.requester.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return errors.Errorf("%s %s returned %s", method, path, response.Status)
+	}
+
+	if out != nil {
+		return json.NewDecoder(response.Body).Decode(out)
+	}
+	return nil
+}
+
+// discover requests the OpenID Connect discovery document of the given realm, which Keycloak only serves
+// for an existing and enabled realm.
+func (h *httpclient) discover(realm string) error {
+	path := fmt.Sprintf("/realms/%s/.well-known/openid-configuration", realm)
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", h.URL, path), nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.requester.Do(request)
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return errors.Errorf("GET %s returned %s", path, response.Status)
+	}
+	return nil
+}
+
+// reconcileClient creates the given client in the realm, or updates it when a client with the
+// same client ID exists. The given protocol mappers are created or updated on the client by name.
+func (h *httpclient) reconcileClient(realm string, client *KeycloakAPIClient, mappers []KeycloakProtocolMapper) error {
+	clientsPath := fmt.Sprintf("/admin/realms/%s/clients", realm)
+
+	existing := []KeycloakAPIClient{}
+	if err := h.do(http.MethodGet, fmt.Sprintf("%s?clientId=%s", clientsPath, url.QueryEscape(client.ClientID)), nil, &existing); err != nil {
+		return err
+	}
+
+	if len(existing) == 0 {
+		if err := h.do(http.MethodPost, clientsPath, client, nil); err != nil {
+			return err
+		}
+		if err := h.do(http.MethodGet, fmt.Sprintf("%s?clientId=%s", clientsPath, url.QueryEscape(client.ClientID)), nil, &existing); err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			return errors.Errorf("client %s not found in realm %s after creation", client.ClientID, realm)
+		}
+	} else {
+		client.ID = existing[0].ID
+		if err := h.do(http.MethodPut, fmt.Sprintf("%s/%s", clientsPath, client.ID), client, nil); err != nil {
+			return err
+		}
+	}
+
+	mappersPath := fmt.Sprintf("%s/%s/protocol-mappers/models", clientsPath, existing[0].ID)
+	existingMappers := []KeycloakProtocolMapper{}
+	if err := h.do(http.MethodGet, mappersPath, nil, &existingMappers); err != nil {
+		return err
+	}
+
+	for i := range mappers {
+		mapper := mappers[i]
+		id := ""
+		for _, m := range existingMappers {
+			if m.Name == mapper.Name {
+				id = m.ID
+				break
+			}
+		}
+
+		if id == "" {
+			if err := h.do(http.MethodPost, mappersPath, mapper, nil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		mapper.ID = id
+		if err := h.do(http.MethodPut, fmt.Sprintf("%s/%s", mappersPath, id), mapper, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // defaultRequester returns a default client for requesting http endpoints.
 func defaultRequester(serverCert []byte, verifyTLS bool) (requester, error) {
 	tlsConfig, err := createTLSConfig(serverCert, verifyTLS)
@@ -0,0 +1,217 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+const (
+	// Annotation on the keycloak client Secret recording the configuration last applied to the external realm.
+	keycloakExternalConfigAnnotation = "argocd.argoproj.io/keycloak-client-config"
+	// Key of the service account client ID in the external credentials Secret.
+	keycloakExternalClientIDKey = "clientID"
+	// Key of the service account client secret in the external credentials Secret.
+	keycloakExternalClientSecretKey = "clientSecret"
+)
+
+// scopes requested by Argo CD from an external realm. The groups claim is added by a client mapper,
+// so no groups client scope is required in the realm.
+var keycloakExternalRequestedScopes = []string{"openid", "profile", "email"}
+
+// isKeycloakExternalMode returns true when the given ArgoCD uses an existing realm of an external Keycloak.
+func isKeycloakExternalMode(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.SSO != nil && cr.Spec.SSO.Keycloak != nil && cr.Spec.SSO.Keycloak.Mode == argoproj.KeycloakModeExternal
+}
+
+// validateKeycloakExternalSpec returns an error message when the external Keycloak configuration is incomplete.
+func validateKeycloakExternalSpec(cr *argoproj.ArgoCD) string {
+	ext := cr.Spec.SSO.Keycloak.External
+	if ext == nil || ext.URL == "" || ext.Realm == "" || ext.CredentialsSecret == "" {
+		return "must supply url, realm and credentialsSecret in .spec.sso.keycloak.external when keycloak mode is external"
+	}
+	return ""
+}
+
+// getKeycloakExternalURL returns the base URL of the external Keycloak without trailing slash.
+func getKeycloakExternalURL(cr *argoproj.ArgoCD) string {
+	return strings.TrimSuffix(cr.Spec.SSO.Keycloak.External.URL, "/")
+}
+
+// newKeycloakExternalHTTPClient returns a client for the external Keycloak, using the TLS settings of the given ArgoCD.
+func newKeycloakExternalHTTPClient(cr *argoproj.ArgoCD) (*httpclient, error) {
+	// By default TLS Verification should be enabled.
+	verifyTLS := cr.Spec.SSO.Keycloak.VerifyTLS == nil || *cr.Spec.SSO.Keycloak.VerifyTLS
+	var rootCA []byte
+	if cr.Spec.SSO.Keycloak.RootCA != "" {
+		rootCA = []byte(cr.Spec.SSO.Keycloak.RootCA)
+	}
+
+	req, err := defaultRequester(rootCA, verifyTLS)
+	if err != nil {
+		return nil, err
+	}
+	return &httpclient{
+		requester: req,
+		URL:       getKeycloakExternalURL(cr),
+	}, nil
+}
+
+// newKeycloakExternalClient returns the argocd client managed in the external realm.
+func newKeycloakExternalClient(cr *argoproj.ArgoCD, clientSecret string) *KeycloakAPIClient {
+	argoCDURL := fmt.Sprintf("https://%s", getArgoServerHost(cr))
+	return &KeycloakAPIClient{
+		ClientID:                keycloakClient,
+		Name:                    keycloakClient,
+		RootURL:                 argoCDURL,
+		AdminURL:                argoCDURL,
+		ClientAuthenticatorType: "client-secret",
+		Secret:                  clientSecret,
+		RedirectUris:            []string{fmt.Sprintf("%s/%s", argoCDURL, "auth/callback")},
+		WebOrigins:              []string{argoCDURL},
+		StandardFlowEnabled:     true,
+	}
+}
+
+// newKeycloakExternalClientMappers returns the protocol mappers managed on the argocd client.
+func newKeycloakExternalClientMappers() []KeycloakProtocolMapper {
+	return []KeycloakProtocolMapper{
+		{
+			Name:           "groups",
+			Protocol:       "openid-connect",
+			ProtocolMapper: "oidc-group-membership-mapper",
+			Config: map[string]string{
+				"full.path":            "false",
+				"userinfo.token.claim": "true",
+				"id.token.claim":       "true",
+				"access.token.claim":   "true",
+				"claim.name":           "groups",
+			},
+		},
+	}
+}
+
+// reconcileKeycloakExternal manages the argocd client and its group mapper in an existing realm of an
+// external Keycloak and configures Argo CD to use it. Keycloak is only contacted when the desired client
+// configuration differs from the one last applied.
+func (r *ReconcileArgoCD) reconcileKeycloakExternal(cr *argoproj.ArgoCD) error {
+	ext := cr.Spec.SSO.Keycloak.External
+
+	clientSecret, err := r.reconcileKeycloakSecret(cr, keycloakClientSecretName, map[string][]byte{
+		keycloakClientSecretKey: []byte(generateRandomString(16)),
+	})
+	if err != nil {
+		return err
+	}
+	secret := string(clientSecret.Data[keycloakClientSecretKey])
+
+	client := newKeycloakExternalClient(cr, secret)
+	mappers := newKeycloakExternalClientMappers()
+
+	desired, err := json.Marshal(struct {
+		URL     string
+		Realm   string
+		Client  *KeycloakAPIClient
+		Mappers []KeycloakProtocolMapper
+	}{getKeycloakExternalURL(cr), ext.Realm, client, mappers})
+	if err != nil {
+		return err
+	}
+	desiredHash := fmt.Sprintf("%x", sha256.Sum256(desired))
+
+	if clientSecret.Annotations[keycloakExternalConfigAnnotation] != desiredHash {
+		credentials := &corev1.Secret{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ext.CredentialsSecret, Namespace: cr.Namespace}, credentials)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Keycloak credentials secret %s not found for ArgoCD %s in namespace %s",
+				ext.CredentialsSecret, cr.Name, cr.Namespace))
+			return err
+		}
+
+		h, err := newKeycloakExternalHTTPClient(cr)
+		if err != nil {
+			return err
+		}
+
+		err = h.loginServiceAccount(ext.Realm, string(credentials.Data[keycloakExternalClientIDKey]), string(credentials.Data[keycloakExternalClientSecretKey]))
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Failed to login to external keycloak realm %s for ArgoCD %s in namespace %s",
+				ext.Realm, cr.Name, cr.Namespace))
+			return err
+		}
+
+		if err := h.reconcileClient(ext.Realm, client, mappers); err != nil {
+			log.Error(err, fmt.Sprintf("Failed to configure argocd client in external keycloak realm %s for ArgoCD %s in namespace %s",
+				ext.Realm, cr.Name, cr.Namespace))
+			return err
+		}
+		log.Info(fmt.Sprintf("Successfully configured argocd client in external keycloak realm %s for ArgoCD %s in namespace %s",
+			ext.Realm, cr.Name, cr.Namespace))
+
+		if clientSecret.Annotations == nil {
+			clientSecret.Annotations = map[string]string{}
+		}
+		clientSecret.Annotations[keycloakExternalConfigAnnotation] = desiredHash
+		if err := r.Client.Update(context.TODO(), clientSecret); err != nil {
+			return err
+		}
+	}
+
+	issuer := fmt.Sprintf("%s/realms/%s", getKeycloakExternalURL(cr), ext.Realm)
+	if err := r.updateArgoCDOIDCConfiguration(cr, issuer, secret, keycloakExternalRequestedScopes); err != nil {
+		log.Error(err, fmt.Sprintf("Failed to update OIDC Configuration for ArgoCD %s in namespace %s",
+			cr.Name, cr.Namespace))
+		return err
+	}
+	return nil
+}
+
+// getKeycloakExternalStatus returns the SSO status of the given ArgoCD in external Keycloak mode. The status is
+// Pending until the argocd client was configured in the external realm, and Running while the realm serves its
+// OpenID Connect discovery document. The realm is not queried while planning, the current status is kept.
+func (r *ReconcileArgoCD) getKeycloakExternalStatus(cr *argoproj.ArgoCD) string {
+	if r.planning {
+		return cr.Status.SSO
+	}
+	if validateKeycloakExternalSpec(cr) != "" {
+		return "Failed"
+	}
+
+	clientSecret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: keycloakClientSecretName, Namespace: cr.Namespace}, clientSecret)
+	if err != nil || clientSecret.Annotations[keycloakExternalConfigAnnotation] == "" {
+		return "Pending"
+	}
+
+	h, err := newKeycloakExternalHTTPClient(cr)
+	if err != nil {
+		return "Failed"
+	}
+	if err := h.discover(cr.Spec.SSO.Keycloak.External.Realm); err != nil {
+		log.Error(err, fmt.Sprintf("External keycloak realm %s of ArgoCD %s in namespace %s is not available",
+			cr.Spec.SSO.Keycloak.External.Realm, cr.Name, cr.Namespace))
+		return "Failed"
+	}
+	return "Running"
+}
@@ -0,0 +1,222 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// fakeKeycloakRealm serves the parts of the Keycloak admin API used to manage a client in a realm, and the
+// discovery document of the realm.
+type fakeKeycloakRealm struct {
+	clients  []KeycloakAPIClient
+	mappers  []KeycloakProtocolMapper
+	requests []string
+}
+
+func (f *fakeKeycloakRealm) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.requests = append(f.requests, req.Method+" "+req.URL.Path)
+
+	switch {
+	case req.URL.Path == "/realms/corp/protocol/openid-connect/token":
+		_ = req.ParseForm()
+		if req.Form.Get("grant_type") != "client_credentials" || req.Form.Get("client_secret") != "sa-secret" {
+			_ = json.NewEncoder(w).Encode(TokenResponse{Error: "unauthorized_client"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(TokenResponse{AccessToken: "token"})
+	case req.URL.Path == "/realms/corp/.well-known/openid-configuration":
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": "corp"})
+	case req.Header.Get("Authorization") != "Bearer token":
+		w.WriteHeader(http.StatusUnauthorized)
+	case req.URL.Path == "/admin/realms/corp/clients" && req.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(f.clients)
+	case req.URL.Path == "/admin/realms/corp/clients" && req.Method == http.MethodPost:
+		c := KeycloakAPIClient{}
+		_ = json.NewDecoder(req.Body).Decode(&c)
+		c.ID = "client-id"
+		f.clients = append(f.clients, c)
+		w.WriteHeader(http.StatusCreated)
+	case req.URL.Path == "/admin/realms/corp/clients/client-id" && req.Method == http.MethodPut:
+		c := KeycloakAPIClient{}
+		_ = json.NewDecoder(req.Body).Decode(&c)
+		f.clients[0] = c
+		w.WriteHeader(http.StatusNoContent)
+	case req.URL.Path == "/admin/realms/corp/clients/client-id/protocol-mappers/models" && req.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(f.mappers)
+	case req.URL.Path == "/admin/realms/corp/clients/client-id/protocol-mappers/models" && req.Method == http.MethodPost:
+		m := KeycloakProtocolMapper{}
+		_ = json.NewDecoder(req.Body).Decode(&m)
+		m.ID = "mapper-id"
+		f.mappers = append(f.mappers, m)
+		w.WriteHeader(http.StatusCreated)
+	case req.URL.Path == "/admin/realms/corp/clients/client-id/protocol-mappers/models/mapper-id" && req.Method == http.MethodPut:
+		m := KeycloakProtocolMapper{}
+		_ = json.NewDecoder(req.Body).Decode(&m)
+		f.mappers[0] = m
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestHTTPClient_reconcileClient(t *testing.T) {
+	realm := &fakeKeycloakRealm{}
+	server := httptest.NewServer(realm)
+	defer server.Close()
+
+	h := &httpclient{
+		requester: server.Client(),
+		URL:       server.URL,
+	}
+	assert.NoError(t, h.loginServiceAccount("corp", "argocd-operator", "sa-secret"))
+
+	a := makeTestArgoCD()
+	assert.NoError(t, h.reconcileClient("corp", newKeycloakExternalClient(a, "one"), newKeycloakExternalClientMappers()))
+	assert.Len(t, realm.clients, 1)
+	assert.Equal(t, "one", realm.clients[0].Secret)
+	assert.Len(t, realm.mappers, 1)
+
+	// an existing client is updated in place and mappers are not duplicated
+	assert.NoError(t, h.reconcileClient("corp", newKeycloakExternalClient(a, "two"), newKeycloakExternalClientMappers()))
+	assert.Len(t, realm.clients, 1)
+	assert.Equal(t, "two", realm.clients[0].Secret)
+	assert.Equal(t, "client-id", realm.clients[0].ID)
+	assert.Contains(t, realm.requests, "PUT /admin/realms/corp/clients/client-id/protocol-mappers/models/mapper-id")
+
+	h.token = ""
+	assert.Error(t, h.loginServiceAccount("corp", "argocd-operator", "wrong"))
+}
+
+func TestReconcileKeycloakExternal(t *testing.T) {
+	realm := &fakeKeycloakRealm{}
+	server := httptest.NewServer(realm)
+	defer server.Close()
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+			Keycloak: &argoproj.ArgoCDKeycloakSpec{
+				Mode: argoproj.KeycloakModeExternal,
+				External: &argoproj.ArgoCDKeycloakExternalSpec{
+					URL:               server.URL + "/",
+					Realm:             "corp",
+					CredentialsSecret: "keycloak-sa",
+				},
+			},
+		}
+	})
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keycloak-sa", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			keycloakExternalClientIDKey:     []byte("argocd-operator"),
+			keycloakExternalClientSecretKey: []byte("sa-secret"),
+		},
+	}
+	argoCDSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDSecretName, Namespace: a.Namespace},
+		Data:       map[string][]byte{common.ArgoCDKeyServerSecretKey: []byte("key")},
+	}
+	argoCDCM := newConfigMapWithName(common.ArgoCDConfigMapName, a)
+	argoCDCM.Data = map[string]string{"foo": "bar"}
+	rbacCM := newConfigMapWithName(common.ArgoCDRBACConfigMapName, a)
+	rbacCM.Data = map[string]string{"foo": "bar"}
+
+	resObjs := []client.Object{a, credentials, argoCDSecret, argoCDCM, rbacCM}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	// the status is pending until the client is configured in the realm
+	assert.NoError(t, r.reconcileStatusKeycloak(a))
+	assert.Equal(t, "Pending", a.Status.SSO)
+
+	assert.NoError(t, r.reconcileSSO(a))
+
+	// no keycloak is installed
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: defaultKeycloakIdentifier, Namespace: a.Namespace}, &corev1.Service{}))
+
+	clientSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: keycloakClientSecretName, Namespace: a.Namespace}, clientSecret))
+	assert.Len(t, realm.clients, 1)
+	assert.Equal(t, string(clientSecret.Data[keycloakClientSecretKey]), realm.clients[0].Secret)
+	assert.NotEmpty(t, clientSecret.Annotations[keycloakExternalConfigAnnotation])
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, argoCDCM))
+	oidc := oidcConfig{}
+	assert.NoError(t, yaml.Unmarshal([]byte(argoCDCM.Data[common.ArgoCDKeyOIDCConfig]), &oidc))
+	assert.Equal(t, server.URL+"/realms/corp", oidc.Issuer)
+	assert.Equal(t, keycloakClient, oidc.ClientID)
+	assert.Equal(t, keycloakExternalRequestedScopes, oidc.RequestedScope)
+
+	// the client is not configured again while its configuration is unchanged, only the realm is checked
+	requests := len(realm.requests)
+	assert.NoError(t, r.reconcileSSO(a))
+	for _, request := range realm.requests[requests:] {
+		assert.Equal(t, "GET /realms/corp/.well-known/openid-configuration", request)
+	}
+
+	// the status is running while the realm is available
+	assert.NoError(t, r.reconcileStatusKeycloak(a))
+	assert.Equal(t, "Running", a.Status.SSO)
+	assert.True(t, isSSOReady(a))
+
+	a.Spec.SSO.Keycloak.External.Realm = "missing"
+	assert.Equal(t, "Failed", r.getKeycloakExternalStatus(a))
+}
+
+func TestReconcileSSO_KeycloakExternalValidation(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+			Keycloak: &argoproj.ArgoCDKeycloakSpec{
+				Mode: argoproj.KeycloakModeExternal,
+				External: &argoproj.ArgoCDKeycloakExternalSpec{
+					URL: "https://sso.example.com",
+				},
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	err := r.reconcileSSO(a)
+	assert.ErrorContains(t, err, "must supply url, realm and credentialsSecret")
+}
@@ -0,0 +1,333 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// Name of the ConfigMap holding the argocd realm imported by Keycloak at startup.
+	keycloakRealmImportConfigMapName = "keycloak-realm-import"
+	// Key of the argocd realm in the realm import ConfigMap.
+	keycloakRealmImportKey = "argocd-realm.json"
+	// Directory scanned by Keycloak for realms to import at startup.
+	keycloakRealmImportPath = "/opt/keycloak/data/import"
+	// Default name of the Secret holding the Keycloak admin credentials.
+	defaultKeycloakAdminSecretName = "keycloak-admin-credentials"
+	// Name of the Secret holding the argocd client secret of the imported realm.
+	keycloakClientSecretName = "keycloak-argocd-client"
+	// Key of the argocd client secret in the keycloak client Secret.
+	keycloakClientSecretKey = "clientSecret"
+	// Environment variable referenced by the realm import for the argocd client secret.
+	keycloakClientSecretEnvName = "ARGOCD_CLIENT_SECRET"
+)
+
+// isKeycloakQuarkusMode returns true when the given ArgoCD requests the quarkus based Keycloak distribution.
+func isKeycloakQuarkusMode(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.SSO != nil && cr.Spec.SSO.Keycloak != nil && cr.Spec.SSO.Keycloak.Mode == argoproj.KeycloakModeQuarkus
+}
+
+// getKeycloakQuarkusContainerImage will return the container image for Keycloak in quarkus mode.
+//
+// The image and version from spec.sso.keycloak are preferred, the defaults are
+// common.ArgoCDKeycloakImage and common.ArgoCDKeycloakQuarkusVersion.
+func getKeycloakQuarkusContainerImage(cr *argoproj.ArgoCD) string {
+	img := cr.Spec.SSO.Keycloak.Image
+	if img == "" {
+		img = common.ArgoCDKeycloakImage
+	}
+
+	tag := cr.Spec.SSO.Keycloak.Version
+	if tag == "" {
+		tag = common.ArgoCDKeycloakQuarkusVersion
+	}
+	return argoutil.GetImageReference(cr, argoutil.CombineImageTag(img, tag))
+}
+
+// getKeycloakAdminSecretName returns the name of the Secret holding the Keycloak admin credentials.
+func getKeycloakAdminSecretName(cr *argoproj.ArgoCD) string {
+	if cr.Spec.SSO.Keycloak.AdminSecret != "" {
+		return cr.Spec.SSO.Keycloak.AdminSecret
+	}
+	return defaultKeycloakAdminSecretName
+}
+
+// getKeycloakQuarkusURL returns the external URL of Keycloak in quarkus mode.
+func getKeycloakQuarkusURL(cr *argoproj.ArgoCD) string {
+	return fmt.Sprintf("https://%s", getKeycloakIngressHost(cr.Spec.SSO.Keycloak))
+}
+
+func secretKeyRefEnvVar(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// getKeycloakQuarkusContainerEnv returns the environment of the Keycloak container in quarkus mode.
+func getKeycloakQuarkusContainerEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	adminSecret := getKeycloakAdminSecretName(cr)
+	env := []corev1.EnvVar{
+		secretKeyRefEnvVar("KEYCLOAK_ADMIN", adminSecret, corev1.BasicAuthUsernameKey),
+		secretKeyRefEnvVar("KEYCLOAK_ADMIN_PASSWORD", adminSecret, corev1.BasicAuthPasswordKey),
+		secretKeyRefEnvVar(keycloakClientSecretEnvName, keycloakClientSecretName, keycloakClientSecretKey),
+		{Name: "KC_HEALTH_ENABLED", Value: "true"},
+		{Name: "KC_HTTP_ENABLED", Value: "true"},
+		{Name: "KC_HOSTNAME_STRICT", Value: "false"},
+		{Name: "KC_PROXY_HEADERS", Value: "xforwarded"},
+	}
+
+	if db := cr.Spec.SSO.Keycloak.Database; db != nil {
+		env = append(env,
+			corev1.EnvVar{Name: "KC_DB", Value: db.Vendor},
+			secretKeyRefEnvVar("KC_DB_URL", db.SecretName, "url"),
+			secretKeyRefEnvVar("KC_DB_USERNAME", db.SecretName, corev1.BasicAuthUsernameKey),
+			secretKeyRefEnvVar("KC_DB_PASSWORD", db.SecretName, corev1.BasicAuthPasswordKey),
+		)
+	}
+	return env
+}
+
+// newKeycloakQuarkusDeployment returns the Keycloak Deployment for quarkus mode. The argocd realm
+// is imported from the realm import ConfigMap at startup.
+func newKeycloakQuarkusDeployment(cr *argoproj.ArgoCD) *k8sappsv1.Deployment {
+	dep := newKeycloakDeployment(cr)
+	delete(dep.ObjectMeta.Annotations, "argocd.argoproj.io/realm-created")
+
+	dep.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name:      defaultKeycloakIdentifier,
+			Image:     getKeycloakQuarkusContainerImage(cr),
+			Args:      []string{"start", "--import-realm"},
+			Env:       proxyEnvVars(getKeycloakQuarkusContainerEnv(cr)...),
+			Resources: getKeycloakResources(cr),
+			Ports: []corev1.ContainerPort{
+				{Name: "http", ContainerPort: httpPort},
+			},
+			SecurityContext: restrictedContainerSecurityContext(),
+			ReadinessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{
+						Path: "/health/ready",
+						Port: intstr.FromInt(int(httpPort)),
+					},
+				},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: keycloakRealmImportConfigMapName, MountPath: keycloakRealmImportPath, ReadOnly: true},
+			},
+		},
+	}
+	dep.Spec.Template.Spec.Volumes = []corev1.Volume{
+		{
+			Name: keycloakRealmImportConfigMapName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: keycloakRealmImportConfigMapName},
+				},
+			},
+		},
+	}
+	return dep
+}
+
+// newKeycloakRealmImportConfigMap returns the ConfigMap holding the argocd realm. The client secret is
+// not stored in the ConfigMap, Keycloak resolves it from the container environment during the import.
+func newKeycloakRealmImportConfigMap(cr *argoproj.ArgoCD) (*corev1.ConfigMap, error) {
+	cfg := &keycloakConfig{
+		ArgoName:      cr.Name,
+		ArgoNamespace: cr.Namespace,
+		ArgoCDURL:     fmt.Sprintf("https://%s", getArgoServerHost(cr)),
+	}
+	realm, err := json.Marshal(newArgoCDRealm(cfg, fmt.Sprintf("${%s}", keycloakClientSecretEnvName)))
+	if err != nil {
+		return nil, err
+	}
+
+	cm := newConfigMapWithName(keycloakRealmImportConfigMapName, cr)
+	cm.Data = map[string]string{
+		keycloakRealmImportKey: string(realm),
+	}
+	return cm, nil
+}
+
+// reconcileKeycloakSecret ensures that the Secret with the given name exists. A missing Secret is
+// created with the given data, an existing Secret is never modified so that credentials survive
+// restarts of both Keycloak and the operator.
+func (r *ReconcileArgoCD) reconcileKeycloakSecret(cr *argoproj.ArgoCD, name string, data map[string][]byte) (*corev1.Secret, error) {
+	secret := argoutil.NewSecretWithName(cr, name)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, secret)
+	if err == nil {
+		return secret, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	secret.Data = data
+	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Creating Keycloak secret %s for ArgoCD %s in namespace %s", name, cr.Name, cr.Namespace))
+	if err := r.Client.Create(context.TODO(), secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// reconcileKeycloakRealmImport ensures that the realm import ConfigMap matches the desired state.
+func (r *ReconcileArgoCD) reconcileKeycloakRealmImport(cr *argoproj.ArgoCD) error {
+	desired, err := newKeycloakRealmImportConfigMap(cr)
+	if err != nil {
+		return err
+	}
+
+	existing := &corev1.ConfigMap{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+			return err
+		}
+		return r.Client.Create(context.TODO(), desired)
+	}
+
+	if !reflect.DeepEqual(existing.Data, desired.Data) {
+		existing.Data = desired.Data
+		return r.Client.Update(context.TODO(), existing)
+	}
+	return nil
+}
+
+// reconcileKeycloakQuarkusDeployment ensures that the Keycloak Deployment for quarkus mode matches the desired state.
+func (r *ReconcileArgoCD) reconcileKeycloakQuarkusDeployment(cr *argoproj.ArgoCD) (*k8sappsv1.Deployment, error) {
+	desired := newKeycloakQuarkusDeployment(cr)
+	rendered := desired.DeepCopy()
+	if err := applyResourcePatches(desired, getKeycloakPatches(cr)); err != nil {
+		return nil, err
+	}
+
+	existing := &k8sappsv1.Deployment{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+			return nil, err
+		}
+		log.Info(fmt.Sprintf("Creating Keycloak deployment for ArgoCD %s in namespace %s", cr.Name, cr.Namespace))
+		return desired, r.Client.Create(context.TODO(), desired)
+	}
+
+	changed := false
+	// resource quantities read from the cluster carry a cached string form, compare them semantically.
+	if !equality.Semantic.DeepEqual(existing.Spec.Template.Spec.Containers, desired.Spec.Template.Spec.Containers) {
+		existing.Spec.Template.Spec.Containers = desired.Spec.Template.Spec.Containers
+		changed = true
+	}
+	if !reflect.DeepEqual(existing.Spec.Template.Spec.Volumes, desired.Spec.Template.Spec.Volumes) {
+		existing.Spec.Template.Spec.Volumes = desired.Spec.Template.Spec.Volumes
+		changed = true
+	}
+	if err := updatePatchedResource(existing, rendered, getKeycloakPatches(cr), &changed); err != nil {
+		return nil, err
+	}
+
+	if changed {
+		log.Info(fmt.Sprintf("Updating Keycloak deployment for ArgoCD %s in namespace %s", cr.Name, cr.Namespace))
+		return existing, r.Client.Update(context.TODO(), existing)
+	}
+	return existing, nil
+}
+
+// reconcileKeycloakQuarkus installs and configures the quarkus based Keycloak distribution.
+func (r *ReconcileArgoCD) reconcileKeycloakQuarkus(cr *argoproj.ArgoCD) error {
+	if _, err := r.reconcileKeycloakSecret(cr, getKeycloakAdminSecretName(cr), map[string][]byte{
+		corev1.BasicAuthUsernameKey: []byte(defaultKeycloakAdminUser),
+		corev1.BasicAuthPasswordKey: []byte(generateRandomString(16)),
+	}); err != nil {
+		return err
+	}
+
+	clientSecret, err := r.reconcileKeycloakSecret(cr, keycloakClientSecretName, map[string][]byte{
+		keycloakClientSecretKey: []byte(generateRandomString(16)),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := r.reconcileKeycloakRealmImport(cr); err != nil {
+		return err
+	}
+
+	for _, obj := range []client.Object{newKeycloakService(cr), newKeycloakIngress(cr)} {
+		err := r.Client.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)
+		if err == nil {
+			continue
+		}
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(cr, obj, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Client.Create(context.TODO(), obj); err != nil {
+			return err
+		}
+	}
+
+	dep, err := r.reconcileKeycloakQuarkusDeployment(cr)
+	if err != nil {
+		return err
+	}
+
+	// The realm is imported at startup, so Argo CD can be configured as soon as Keycloak is up and running.
+	if dep.Status.AvailableReplicas != expectedReplicas {
+		return nil
+	}
+
+	issuer := fmt.Sprintf("%s/realms/%s", getKeycloakQuarkusURL(cr), keycloakRealm)
+	if err := r.updateArgoCDOIDCConfiguration(cr, issuer, string(clientSecret.Data[keycloakClientSecretKey]), keycloakRequestedScopes); err != nil {
+		log.Error(err, fmt.Sprintf("Failed to update OIDC Configuration for ArgoCD %s in namespace %s",
+			cr.Name, cr.Namespace))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestArgoCDForKeycloakQuarkus(opts ...argoCDOpt) *argoproj.ArgoCD {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+			Keycloak: &argoproj.ArgoCDKeycloakSpec{
+				Mode: argoproj.KeycloakModeQuarkus,
+			},
+		}
+	})
+	for _, o := range opts {
+		o(a)
+	}
+	return a
+}
+
+func getEnvVar(env []corev1.EnvVar, name string) *corev1.EnvVar {
+	for i := range env {
+		if env[i].Name == name {
+			return &env[i]
+		}
+	}
+	return nil
+}
+
+func TestKeycloakQuarkusContainerImage(t *testing.T) {
+	a := makeTestArgoCDForKeycloakQuarkus()
+	assert.Equal(t, common.ArgoCDKeycloakImage+":"+common.ArgoCDKeycloakQuarkusVersion, getKeycloakQuarkusContainerImage(a))
+
+	a.Spec.SSO.Keycloak.Image = "registry.example.com/keycloak"
+	a.Spec.SSO.Keycloak.Version = "25.0.0"
+	assert.Equal(t, "registry.example.com/keycloak:25.0.0", getKeycloakQuarkusContainerImage(a))
+}
+
+func TestNewKeycloakQuarkusDeployment(t *testing.T) {
+	tests := []struct {
+		name            string
+		argoCD          *argoproj.ArgoCD
+		wantAdminSecret string
+		wantDB          bool
+	}{
+		{
+			name:            "default admin secret, no database",
+			argoCD:          makeTestArgoCDForKeycloakQuarkus(),
+			wantAdminSecret: defaultKeycloakAdminSecretName,
+		},
+		{
+			name: "custom admin secret and external database",
+			argoCD: makeTestArgoCDForKeycloakQuarkus(func(cr *argoproj.ArgoCD) {
+				cr.Spec.SSO.Keycloak.AdminSecret = "my-admin"
+				cr.Spec.SSO.Keycloak.Database = &argoproj.ArgoCDKeycloakDatabaseSpec{
+					Vendor:     "postgres",
+					SecretName: "keycloak-db",
+				}
+			}),
+			wantAdminSecret: "my-admin",
+			wantDB:          true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dep := newKeycloakQuarkusDeployment(test.argoCD)
+			container := dep.Spec.Template.Spec.Containers[0]
+
+			assert.Equal(t, []string{"start", "--import-realm"}, container.Args)
+			assert.Equal(t, "/health/ready", container.ReadinessProbe.HTTPGet.Path)
+			assert.Equal(t, keycloakRealmImportPath, container.VolumeMounts[0].MountPath)
+			assert.Equal(t, keycloakRealmImportConfigMapName, dep.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+			assert.NotContains(t, dep.Annotations, "argocd.argoproj.io/realm-created")
+
+			admin := getEnvVar(container.Env, "KEYCLOAK_ADMIN_PASSWORD")
+			assert.NotNil(t, admin)
+			assert.Equal(t, test.wantAdminSecret, admin.ValueFrom.SecretKeyRef.Name)
+
+			db := getEnvVar(container.Env, "KC_DB")
+			dbURL := getEnvVar(container.Env, "KC_DB_URL")
+			if test.wantDB {
+				assert.Equal(t, "postgres", db.Value)
+				assert.Equal(t, "keycloak-db", dbURL.ValueFrom.SecretKeyRef.Name)
+				assert.Equal(t, "url", dbURL.ValueFrom.SecretKeyRef.Key)
+			} else {
+				assert.Nil(t, db)
+				assert.Nil(t, dbURL)
+			}
+		})
+	}
+}
+
+func TestNewKeycloakRealmImportConfigMap(t *testing.T) {
+	a := makeTestArgoCDForKeycloakQuarkus(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Server.Host = "argocd.example.com"
+	})
+
+	cm, err := newKeycloakRealmImportConfigMap(a)
+	assert.NoError(t, err)
+
+	realm := &CustomKeycloakAPIRealm{}
+	assert.NoError(t, json.Unmarshal([]byte(cm.Data[keycloakRealmImportKey]), realm))
+	assert.Equal(t, keycloakRealm, realm.Realm)
+	assert.Equal(t, "https://argocd.example.com", realm.Clients[0].RootURL)
+	assert.Equal(t, "${"+keycloakClientSecretEnvName+"}", realm.Clients[0].Secret)
+	assert.Empty(t, realm.IdentityProviders)
+}
+
+func TestReconcileKeycloakQuarkus(t *testing.T) {
+	a := makeTestArgoCDForKeycloakQuarkus()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileKeycloakConfiguration(a))
+
+	adminSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: defaultKeycloakAdminSecretName, Namespace: a.Namespace}, adminSecret))
+	assert.Equal(t, defaultKeycloakAdminUser, string(adminSecret.Data[corev1.BasicAuthUsernameKey]))
+	assert.NotEmpty(t, adminSecret.Data[corev1.BasicAuthPasswordKey])
+
+	clientSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: keycloakClientSecretName, Namespace: a.Namespace}, clientSecret))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: keycloakRealmImportConfigMapName, Namespace: a.Namespace}, cm))
+
+	dep := &k8sappsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: defaultKeycloakIdentifier, Namespace: a.Namespace}, dep))
+
+	// existing credentials are kept across reconciliations
+	password := adminSecret.Data[corev1.BasicAuthPasswordKey]
+	assert.NoError(t, r.reconcileKeycloakConfiguration(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: defaultKeycloakAdminSecretName, Namespace: a.Namespace}, adminSecret))
+	assert.Equal(t, password, adminSecret.Data[corev1.BasicAuthPasswordKey])
+
+	// argo cd is configured once keycloak is available
+	argoCDSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDSecretName, Namespace: a.Namespace},
+		Data:       map[string][]byte{common.ArgoCDKeyServerSecretKey: []byte("key")},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), argoCDSecret))
+	for _, name := range []string{common.ArgoCDConfigMapName, common.ArgoCDRBACConfigMapName} {
+		cm := newConfigMapWithName(name, a)
+		cm.Data = map[string]string{"foo": "bar"}
+		assert.NoError(t, r.Client.Create(context.TODO(), cm))
+	}
+
+	dep.Status.AvailableReplicas = expectedReplicas
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), dep))
+	assert.NoError(t, r.reconcileKeycloakConfiguration(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, argoCDSecret))
+	assert.Equal(t, clientSecret.Data[keycloakClientSecretKey], argoCDSecret.Data["oidc.keycloak.clientSecret"])
+
+	argoCDCM := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, argoCDCM))
+	oidc := oidcConfig{}
+	assert.NoError(t, yaml.Unmarshal([]byte(argoCDCM.Data[common.ArgoCDKeyOIDCConfig]), &oidc))
+	assert.Equal(t, "https://"+keycloakIngressHost+"/realms/"+keycloakRealm, oidc.Issuer)
+}
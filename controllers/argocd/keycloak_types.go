@@ -1,6 +1,9 @@
 package argocd
 
 type KeycloakAPIClient struct {
+	// Client internal ID.
+	// +optional
+	ID string `json:"id,omitempty"`
 	// Client ID.
 	// +kubebuilder:validation:Required
 	ClientID string `json:"clientId"`
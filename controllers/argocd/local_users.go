@@ -0,0 +1,413 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	argopass "github.com/argoproj/argo-cd/v2/util/password"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// localUserSecretSuffix is the suffix of the names of the Secrets of the local users, followed by the user name.
+	localUserSecretSuffix = "local-user-"
+
+	// localUserTokenIssuer is the issuer of the tokens minted by the Argo CD server, which it expects in the API tokens.
+	localUserTokenIssuer = "argocd"
+)
+
+// localUserToken is an API token of a local account, as listed in the Argo CD Secret.
+type localUserToken struct {
+	ID        string `json:"id"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// localUserTokenClaims are the claims of an API token of a local account.
+type localUserTokenClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	ID        string `json:"jti"`
+	IssuedAt  int64  `json:"iat"`
+	NotBefore int64  `json:"nbf"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// getLocalUsers will return the local users of the given ArgoCD. The users named admin, sharing the name of one of the
+// Accounts or duplicated are ignored.
+func getLocalUsers(cr *argoproj.ArgoCD) []argoproj.ArgoCDLocalUser {
+	names := map[string]bool{common.ArgoCDDefaultAdminUsername: true}
+	for _, account := range cr.Spec.Accounts {
+		names[account.Name] = true
+	}
+
+	var users []argoproj.ArgoCDLocalUser
+	for _, user := range cr.Spec.LocalUsers {
+		if names[user.Name] {
+			log.Info(fmt.Sprintf("ignoring local user %s of ArgoCD %s in namespace %s, the account is already declared", user.Name, cr.Name, cr.Namespace))
+			continue
+		}
+		names[user.Name] = true
+		users = append(users, user)
+	}
+	return users
+}
+
+// getLocalUserCapabilities will return the capabilities of the given local user, with the apiKey capability when an
+// API token is requested.
+func getLocalUserCapabilities(user argoproj.ArgoCDLocalUser) []argoproj.ArgoCDAccountCapability {
+	capabilities := append([]argoproj.ArgoCDAccountCapability{}, user.Capabilities...)
+	if user.APIToken == nil {
+		return capabilities
+	}
+	for _, capability := range capabilities {
+		if capability == argoproj.ArgoCDAccountCapabilityAPIKey {
+			return capabilities
+		}
+	}
+	return append(capabilities, argoproj.ArgoCDAccountCapabilityAPIKey)
+}
+
+// isLocalUserTokenAutoRenewed returns true when the API token of the given local user is renewed once expired.
+func isLocalUserTokenAutoRenewed(user argoproj.ArgoCDLocalUser) bool {
+	return user.APIToken != nil && (user.APIToken.AutoRenew == nil || *user.APIToken.AutoRenew)
+}
+
+// newLocalUserSecret returns the Secret holding the password and API token of the given local user.
+func newLocalUserSecret(cr *argoproj.ArgoCD, name string) *corev1.Secret {
+	secret := argoutil.NewSecretWithSuffix(cr, localUserSecretSuffix+name)
+	secret.Labels[common.ArgoCDLocalUserLabel] = name
+	return secret
+}
+
+// getLocalUserSecretKey returns the key of the given setting of the given local account in the Argo CD Secret.
+func getLocalUserSecretKey(name string, suffix string) string {
+	return common.ArgoCDKeyAccountPrefix + name + suffix
+}
+
+// reconcileLocalUsers will ensure that the passwords and API tokens of the local users of the given ArgoCD are
+// generated in their Secrets and set in the Argo CD Secret, and that the removed users are cleaned up.
+func (r *ReconcileArgoCD) reconcileLocalUsers(cr *argoproj.ArgoCD) error {
+	argoSecret := argoutil.NewSecretWithName(cr, common.ArgoCDSecretName)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, argoSecret.Name, argoSecret) {
+		log.Info(fmt.Sprintf("argo secret [%s] not found, waiting to reconcile the local users", argoSecret.Name))
+		return nil
+	}
+	if argoSecret.Data == nil {
+		argoSecret.Data = make(map[string][]byte)
+	}
+
+	changed := false
+	declared := map[string]bool{}
+	for _, user := range getLocalUsers(cr) {
+		declared[user.Name] = true
+		userChanged, err := r.reconcileLocalUser(cr, user, argoSecret)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile local user %s: %w", user.Name, err)
+		}
+		if userChanged {
+			changed = true
+		}
+	}
+
+	// remove the users which are no longer declared
+	secrets := &corev1.SecretList{}
+	if err := r.Client.List(context.TODO(), secrets, client.InNamespace(cr.Namespace), client.HasLabels{common.ArgoCDLocalUserLabel}); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		name := secret.Labels[common.ArgoCDLocalUserLabel]
+		if declared[name] || !metav1.IsControlledBy(secret, cr) {
+			continue
+		}
+		for _, suffix := range []string{common.ArgoCDKeyAccountPasswordSuffix, common.ArgoCDKeyAccountPasswordMTimeSuffix, common.ArgoCDKeyAccountTokensSuffix} {
+			if _, ok := argoSecret.Data[getLocalUserSecretKey(name, suffix)]; ok {
+				delete(argoSecret.Data, getLocalUserSecretKey(name, suffix))
+				changed = true
+			}
+		}
+		log.Info(fmt.Sprintf("deleting secret %s of removed local user %s", secret.Name, name))
+		if err := r.Client.Delete(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+
+	if changed {
+		log.Info("updating argo secret with the local users")
+		return r.Client.Update(context.TODO(), argoSecret)
+	}
+	return nil
+}
+
+// reconcileLocalUser will ensure that the Secret of the given local user holds its password and requested API token,
+// and that they are set in the given Argo CD Secret. It returns true when the Argo CD Secret was changed.
+func (r *ReconcileArgoCD) reconcileLocalUser(cr *argoproj.ArgoCD, user argoproj.ArgoCDLocalUser, argoSecret *corev1.Secret) (bool, error) {
+	userSecret := newLocalUserSecret(cr, user.Name)
+	exists := argoutil.IsObjectFound(r.Client, cr.Namespace, userSecret.Name, userSecret)
+	if userSecret.Data == nil {
+		userSecret.Data = make(map[string][]byte)
+	}
+	userChanged := false
+
+	if string(userSecret.Data[common.ArgoCDKeyLocalUserName]) != user.Name {
+		userSecret.Data[common.ArgoCDKeyLocalUserName] = []byte(user.Name)
+		userChanged = true
+	}
+	if len(userSecret.Data[common.ArgoCDKeyLocalUserPassword]) == 0 {
+		password, err := generateArgoAdminPassword()
+		if err != nil {
+			return false, err
+		}
+		userSecret.Data[common.ArgoCDKeyLocalUserPassword] = password
+		userChanged = true
+	}
+
+	// the password of the Secret of the user takes precedence over the one set with the CLI
+	changed := false
+	passwordKey := getLocalUserSecretKey(user.Name, common.ArgoCDKeyAccountPasswordSuffix)
+	password := strings.TrimRight(string(userSecret.Data[common.ArgoCDKeyLocalUserPassword]), "\n")
+	if valid, _ := argopass.VerifyPassword(password, string(argoSecret.Data[passwordKey])); !valid {
+		hashedPassword, err := argopass.HashPassword(password)
+		if err != nil {
+			return false, err
+		}
+		argoSecret.Data[passwordKey] = []byte(hashedPassword)
+		argoSecret.Data[getLocalUserSecretKey(user.Name, common.ArgoCDKeyAccountPasswordMTimeSuffix)] = nowBytes()
+		changed = true
+	}
+
+	tokensChanged, tokenChanged, err := reconcileLocalUserToken(user, userSecret, argoSecret, changed)
+	if err != nil {
+		return false, err
+	}
+	if tokensChanged {
+		changed = true
+	}
+	if tokenChanged {
+		userChanged = true
+	}
+
+	if !exists {
+		if err := controllerutil.SetControllerReference(cr, userSecret, r.Scheme); err != nil {
+			return false, err
+		}
+		log.Info(fmt.Sprintf("creating secret %s of local user %s", userSecret.Name, user.Name))
+		if err := r.Client.Create(context.TODO(), userSecret); err != nil {
+			return false, err
+		}
+	} else if userChanged {
+		log.Info(fmt.Sprintf("updating secret %s of local user %s", userSecret.Name, user.Name))
+		if err := r.Client.Update(context.TODO(), userSecret); err != nil {
+			return false, err
+		}
+	}
+	return changed, nil
+}
+
+// reconcileLocalUserToken will ensure that the given Secret of the given local user holds its requested API token, and
+// that the token is listed in the given Argo CD Secret. A new token is minted when the token is missing, revoked,
+// signed with another key or issued before the password changed, when its lifetime changed and once it expired when it
+// is auto renewed. It returns whether the Argo CD Secret and the Secret of the user were changed.
+func reconcileLocalUserToken(user argoproj.ArgoCDLocalUser, userSecret *corev1.Secret, argoSecret *corev1.Secret, passwordChanged bool) (bool, bool, error) {
+	tokensKey := getLocalUserSecretKey(user.Name, common.ArgoCDKeyAccountTokensSuffix)
+	tokens := []localUserToken{}
+	if data := argoSecret.Data[tokensKey]; len(data) > 0 {
+		if err := json.Unmarshal(data, &tokens); err != nil {
+			log.Info(fmt.Sprintf("ignoring invalid API tokens of local user %s: %s", user.Name, err))
+			tokens = []localUserToken{}
+		}
+	}
+
+	id := string(userSecret.Data[common.ArgoCDKeyLocalUserAPITokenID])
+	current := -1
+	for i := range tokens {
+		if id != "" && tokens[i].ID == id {
+			current = i
+		}
+	}
+
+	tokensChanged := false
+	userChanged := false
+	if user.APIToken == nil {
+		if current >= 0 {
+			tokens = append(tokens[:current], tokens[current+1:]...)
+			tokensChanged = true
+		}
+		for _, key := range []string{common.ArgoCDKeyLocalUserAPIToken, common.ArgoCDKeyLocalUserAPITokenID, common.ArgoCDKeyLocalUserAPITokenExpiresAt} {
+			if _, ok := userSecret.Data[key]; ok {
+				delete(userSecret.Data, key)
+				userChanged = true
+			}
+		}
+	} else if needsLocalUserToken(user, userSecret, argoSecret, tokens, current, passwordChanged) {
+		if current >= 0 {
+			tokens = append(tokens[:current], tokens[current+1:]...)
+		}
+		token, entry, err := newLocalUserAPIToken(user, argoSecret.Data[common.ArgoCDKeyServerSecretKey], time.Now())
+		if err != nil {
+			return false, false, err
+		}
+		log.Info(fmt.Sprintf("minting API token %s of local user %s", entry.ID, user.Name))
+		tokens = append(tokens, entry)
+		tokensChanged = true
+
+		userSecret.Data[common.ArgoCDKeyLocalUserAPIToken] = []byte(token)
+		userSecret.Data[common.ArgoCDKeyLocalUserAPITokenID] = []byte(entry.ID)
+		delete(userSecret.Data, common.ArgoCDKeyLocalUserAPITokenExpiresAt)
+		if entry.ExpiresAt > 0 {
+			userSecret.Data[common.ArgoCDKeyLocalUserAPITokenExpiresAt] = []byte(time.Unix(entry.ExpiresAt, 0).UTC().Format(time.RFC3339))
+		}
+		userChanged = true
+	}
+
+	if tokensChanged {
+		data, err := json.Marshal(tokens)
+		if err != nil {
+			return false, false, err
+		}
+		argoSecret.Data[tokensKey] = data
+	}
+	return tokensChanged, userChanged, nil
+}
+
+// needsLocalUserToken returns true when a new API token must be minted for the given local user.
+func needsLocalUserToken(user argoproj.ArgoCDLocalUser, userSecret *corev1.Secret, argoSecret *corev1.Secret, tokens []localUserToken, current int, passwordChanged bool) bool {
+	token := string(userSecret.Data[common.ArgoCDKeyLocalUserAPIToken])
+	if passwordChanged || current < 0 || token == "" {
+		return true
+	}
+	if !verifyLocalUserAPIToken(token, argoSecret.Data[common.ArgoCDKeyServerSecretKey]) {
+		return true
+	}
+
+	entry := tokens[current]
+	var lifetime int64
+	if user.APIToken.Lifetime != nil {
+		lifetime = int64(user.APIToken.Lifetime.Seconds())
+	}
+	if (entry.ExpiresAt == 0 && lifetime > 0) || (entry.ExpiresAt > 0 && entry.ExpiresAt-entry.IssuedAt != lifetime) {
+		return true
+	}
+	return entry.ExpiresAt > 0 && entry.ExpiresAt <= time.Now().Unix() && isLocalUserTokenAutoRenewed(user)
+}
+
+// newLocalUserAPIToken will return a new API token of the given local user, signed with the given server key as the
+// Argo CD server does, and its entry in the Argo CD Secret.
+func newLocalUserAPIToken(user argoproj.ArgoCDLocalUser, key []byte, now time.Time) (string, localUserToken, error) {
+	if len(key) == 0 {
+		return "", localUserToken{}, fmt.Errorf("the argo secret has no server key to sign the API token")
+	}
+	id, err := generateLocalUserTokenID()
+	if err != nil {
+		return "", localUserToken{}, err
+	}
+
+	claims := localUserTokenClaims{
+		Issuer:    localUserTokenIssuer,
+		Subject:   fmt.Sprintf("%s:%s", user.Name, argoproj.ArgoCDAccountCapabilityAPIKey),
+		ID:        id,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+	}
+	if user.APIToken.Lifetime != nil && user.APIToken.Lifetime.Duration > 0 {
+		claims.ExpiresAt = now.Add(user.APIToken.Lifetime.Duration).Unix()
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", localUserToken{}, err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", localUserToken{}, err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	token := unsigned + "." + base64.RawURLEncoding.EncodeToString(signLocalUserAPIToken(unsigned, key))
+	return token, localUserToken{ID: id, IssuedAt: claims.IssuedAt, ExpiresAt: claims.ExpiresAt}, nil
+}
+
+// verifyLocalUserAPIToken returns true when the given API token is signed with the given server key.
+func verifyLocalUserAPIToken(token string, key []byte) bool {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(token[i+1:])
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(signature, signLocalUserAPIToken(token[:i], key))
+}
+
+// signLocalUserAPIToken returns the HS256 signature of the given unsigned token.
+func signLocalUserAPIToken(unsigned string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+// generateLocalUserTokenID will generate a random ID for an API token.
+func generateLocalUserTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// getLocalUserTokenRenewal will return the delay until the first API token of the local users of the given ArgoCD to
+// be renewed expires, or zero when no token is renewed.
+func (r *ReconcileArgoCD) getLocalUserTokenRenewal(cr *argoproj.ArgoCD) time.Duration {
+	var renewal time.Duration
+	for _, user := range getLocalUsers(cr) {
+		if !isLocalUserTokenAutoRenewed(user) || user.APIToken.Lifetime == nil {
+			continue
+		}
+		secret := newLocalUserSecret(cr, user.Name)
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+			continue
+		}
+		expiresAt, err := time.Parse(time.RFC3339, string(secret.Data[common.ArgoCDKeyLocalUserAPITokenExpiresAt]))
+		if err != nil {
+			continue
+		}
+		delay := time.Until(expiresAt)
+		if delay < time.Second {
+			delay = time.Second
+		}
+		if renewal == 0 || delay < renewal {
+			renewal = delay
+		}
+	}
+	return renewal
+}
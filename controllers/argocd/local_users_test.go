@@ -0,0 +1,174 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	argopass "github.com/argoproj/argo-cd/v2/util/password"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcileLocalUsers(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.LocalUsers = []argoproj.ArgoCDLocalUser{
+			{Name: "alice", Capabilities: []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityLogin}},
+			{
+				Name:         "ci",
+				Capabilities: []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityLogin},
+				APIToken:     &argoproj.ArgoCDLocalUserAPIToken{Lifetime: &metav1.Duration{Duration: time.Hour}},
+			},
+		}
+	})
+	argoSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDSecretName, Namespace: a.Namespace},
+		Data:       map[string][]byte{common.ArgoCDKeyServerSecretKey: []byte("s3cr3t")},
+	}
+
+	resObjs := []client.Object{a, argoSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileLocalUsers(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: argoSecret.Name, Namespace: a.Namespace}, argoSecret))
+
+	// the generated password is hashed into the argo secret
+	alice := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-local-user-alice", Namespace: a.Namespace}, alice))
+	assert.Equal(t, "alice", string(alice.Data[common.ArgoCDKeyLocalUserName]))
+	assert.Equal(t, "alice", alice.Labels[common.ArgoCDLocalUserLabel])
+	valid, _ := argopass.VerifyPassword(string(alice.Data[common.ArgoCDKeyLocalUserPassword]), string(argoSecret.Data["accounts.alice.password"]))
+	assert.True(t, valid)
+	assert.NotEmpty(t, argoSecret.Data["accounts.alice.passwordMtime"])
+	assert.NotContains(t, alice.Data, common.ArgoCDKeyLocalUserAPIToken)
+
+	// the minted API token is listed in the argo secret
+	ci := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-local-user-ci", Namespace: a.Namespace}, ci))
+	token := string(ci.Data[common.ArgoCDKeyLocalUserAPIToken])
+	assert.True(t, verifyLocalUserAPIToken(token, []byte("s3cr3t")))
+	assert.False(t, verifyLocalUserAPIToken(token, []byte("another")))
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[1])
+	assert.NoError(t, err)
+	claims := localUserTokenClaims{}
+	assert.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, "ci:apiKey", claims.Subject)
+	assert.Equal(t, "argocd", claims.Issuer)
+	assert.Equal(t, int64(3600), claims.ExpiresAt-claims.IssuedAt)
+
+	tokens := []localUserToken{}
+	assert.NoError(t, json.Unmarshal(argoSecret.Data["accounts.ci.tokens"], &tokens))
+	assert.Equal(t, []localUserToken{{ID: claims.ID, IssuedAt: claims.IssuedAt, ExpiresAt: claims.ExpiresAt}}, tokens)
+	assert.Equal(t, claims.ID, string(ci.Data[common.ArgoCDKeyLocalUserAPITokenID]))
+	assert.NotEmpty(t, ci.Data[common.ArgoCDKeyLocalUserAPITokenExpiresAt])
+	assert.Greater(t, r.getLocalUserTokenRenewal(a), 59*time.Minute)
+
+	// the token is kept while it is valid
+	assert.NoError(t, r.reconcileLocalUsers(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ci.Name, Namespace: a.Namespace}, ci))
+	assert.Equal(t, token, string(ci.Data[common.ArgoCDKeyLocalUserAPIToken]))
+
+	// a new password is hashed, and a new token minted as the previous one is invalidated by the change
+	ci.Data[common.ArgoCDKeyLocalUserPassword] = []byte("n3w-p4ssw0rd")
+	assert.NoError(t, r.Client.Update(context.TODO(), ci))
+	assert.NoError(t, r.reconcileLocalUsers(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: argoSecret.Name, Namespace: a.Namespace}, argoSecret))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ci.Name, Namespace: a.Namespace}, ci))
+	valid, _ = argopass.VerifyPassword("n3w-p4ssw0rd", string(argoSecret.Data["accounts.ci.password"]))
+	assert.True(t, valid)
+	assert.NotEqual(t, claims.ID, string(ci.Data[common.ArgoCDKeyLocalUserAPITokenID]))
+	assert.NoError(t, json.Unmarshal(argoSecret.Data["accounts.ci.tokens"], &tokens))
+	if assert.Len(t, tokens, 1) {
+		assert.Equal(t, string(ci.Data[common.ArgoCDKeyLocalUserAPITokenID]), tokens[0].ID)
+	}
+
+	// the removed users are cleaned up
+	a.Spec.LocalUsers = a.Spec.LocalUsers[:1]
+	assert.NoError(t, r.reconcileLocalUsers(a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), types.NamespacedName{Name: ci.Name, Namespace: a.Namespace}, ci)))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: argoSecret.Name, Namespace: a.Namespace}, argoSecret))
+	assert.NotContains(t, argoSecret.Data, "accounts.ci.password")
+	assert.NotContains(t, argoSecret.Data, "accounts.ci.tokens")
+	assert.Contains(t, argoSecret.Data, "accounts.alice.password")
+}
+
+func TestNeedsLocalUserToken(t *testing.T) {
+	user := argoproj.ArgoCDLocalUser{
+		Name:     "ci",
+		APIToken: &argoproj.ArgoCDLocalUserAPIToken{Lifetime: &metav1.Duration{Duration: time.Hour}},
+	}
+	key := []byte("s3cr3t")
+	argoSecret := &corev1.Secret{Data: map[string][]byte{common.ArgoCDKeyServerSecretKey: key}}
+	token, entry, err := newLocalUserAPIToken(user, key, time.Now().Add(-2*time.Hour))
+	assert.NoError(t, err)
+	userSecret := &corev1.Secret{Data: map[string][]byte{
+		common.ArgoCDKeyLocalUserAPIToken:   []byte(token),
+		common.ArgoCDKeyLocalUserAPITokenID: []byte(entry.ID),
+	}}
+	tokens := []localUserToken{entry}
+
+	// the expired token is renewed, unless auto renewal is disabled
+	assert.True(t, needsLocalUserToken(user, userSecret, argoSecret, tokens, 0, false))
+	user.APIToken.AutoRenew = boolPtr(false)
+	assert.False(t, needsLocalUserToken(user, userSecret, argoSecret, tokens, 0, false))
+
+	// a token is minted when it is revoked, its lifetime changes or the server key changes
+	assert.True(t, needsLocalUserToken(user, userSecret, argoSecret, tokens, -1, false))
+	user.APIToken.Lifetime = nil
+	assert.True(t, needsLocalUserToken(user, userSecret, argoSecret, tokens, 0, false))
+	user.APIToken.Lifetime = &metav1.Duration{Duration: time.Hour}
+	argoSecret.Data[common.ArgoCDKeyServerSecretKey] = []byte("another")
+	assert.True(t, needsLocalUserToken(user, userSecret, argoSecret, tokens, 0, false))
+}
+
+func TestGetAccountSettings_LocalUsers(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Accounts = []argoproj.ArgoCDAccount{
+			{Name: "alice", Capabilities: []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityLogin}},
+		}
+		cr.Spec.LocalUsers = []argoproj.ArgoCDLocalUser{
+			{Name: "alice", Capabilities: []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityAPIKey}},
+			{
+				Name:         "ci",
+				Capabilities: []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityLogin},
+				Enabled:      boolPtr(false),
+				APIToken:     &argoproj.ArgoCDLocalUserAPIToken{},
+			},
+		}
+	})
+
+	assert.Equal(t, map[string]string{
+		"accounts.alice":      "login",
+		"accounts.ci":         "login,apiKey",
+		"accounts.ci.enabled": "false",
+	}, getAccountSettings(a))
+}
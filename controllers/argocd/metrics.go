@@ -1,8 +1,14 @@
 package argocd
 
 import (
+	"context"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 )
 
 var (
@@ -36,8 +42,148 @@ var (
 		Help:    "Length of time per reconciliation per instance",
 		Buckets: []float64{0.05, 0.075, 0.1, 0.15, 0.2, 0.22, 0.24, 0.26, 0.28, 0.3, 0.32, 0.34, 0.37, 0.4, 0.42, 0.44, 0.48, 0.5, 0.55, 0.6, 0.75, 0.9, 1.00},
 	}, []string{"namespace"})
+
+	// ReconcileStepTime is a prometheus metric which keeps track of the duration
+	// of each step of the reconciliation of a given instance
+	ReconcileStepTime = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "argocd_operator_reconcile_step_duration_seconds",
+		Help:    "Length of time per reconciliation step per instance",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	}, []string{"namespace", "step"})
+
+	ReconcileStepErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_operator_reconcile_step_errors_total",
+			Help: "Number of failed reconciliation steps per instance",
+		},
+		[]string{"namespace", "step"},
+	)
+
+	APIRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_operator_api_requests_total",
+			Help: "Number of create, update, patch and delete API requests made by the operator per resource kind",
+		},
+		[]string{"verb", "kind"},
+	)
+
+	ManagedNamespacesTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_operator_managed_namespaces",
+			Help: "Number of namespaces managed by a given instance",
+		},
+		[]string{"namespace"},
+	)
 )
 
 func init() {
-	metrics.Registry.MustRegister(ActiveInstancesTotal, ActiveInstancesByPhase, ActiveInstanceReconciliationCount, ReconcileTime)
+	metrics.Registry.MustRegister(ActiveInstancesTotal, ActiveInstancesByPhase, ActiveInstanceReconciliationCount, ReconcileTime,
+		ReconcileStepTime, ReconcileStepErrors, APIRequestsTotal, ManagedNamespacesTotal)
+}
+
+// reconcileStep runs a step of the reconciliation of the given ArgoCD, recording its duration and whether it failed.
+func reconcileStep(cr *argoproj.ArgoCD, step string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	ReconcileStepTime.WithLabelValues(cr.Namespace, step).Observe(time.Since(start).Seconds())
+	if err != nil {
+		ReconcileStepErrors.WithLabelValues(cr.Namespace, step).Inc()
+	}
+	return err
+}
+
+// deleteInstanceMetrics removes the per instance metrics of an ArgoCD in the given namespace.
+func deleteInstanceMetrics(namespace string) {
+	ActiveInstanceReconciliationCount.DeleteLabelValues(namespace)
+	ReconcileTime.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	ReconcileStepTime.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	ReconcileStepErrors.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	ManagedNamespacesTotal.DeleteLabelValues(namespace)
+}
+
+// metricsClient is a client that counts the create, update, patch and delete requests it makes by resource kind.
+type metricsClient struct {
+	client.Client
+}
+
+// newMetricsClient returns a client counting the write requests made through the given client.
+func newMetricsClient(c client.Client) client.Client {
+	if _, ok := c.(*metricsClient); ok {
+		return c
+	}
+	return &metricsClient{Client: c}
+}
+
+// countAPIRequest increments the API request counter for the kind of the given object.
+func countAPIRequest(c client.Client, verb string, obj client.Object) {
+	kind := "unknown"
+	if gvk, err := c.GroupVersionKindFor(obj); err == nil {
+		kind = gvk.Kind
+	}
+	APIRequestsTotal.WithLabelValues(verb, kind).Inc()
+}
+
+func (c *metricsClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	countAPIRequest(c.Client, "create", obj)
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *metricsClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	countAPIRequest(c.Client, "update", obj)
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *metricsClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	countAPIRequest(c.Client, "patch", obj)
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *metricsClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	countAPIRequest(c.Client, "delete", obj)
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *metricsClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	countAPIRequest(c.Client, "delete", obj)
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *metricsClient) Status() client.SubResourceWriter {
+	return &metricsSubResourceWriter{SubResourceWriter: c.Client.Status(), client: c.Client}
+}
+
+func (c *metricsClient) SubResource(subResource string) client.SubResourceClient {
+	return &metricsSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), client: c.Client}
+}
+
+// metricsSubResourceWriter counts the update and patch requests made to a sub-resource by resource kind.
+type metricsSubResourceWriter struct {
+	client.SubResourceWriter
+	client client.Client
+}
+
+func (w *metricsSubResourceWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	countAPIRequest(w.client, "update", obj)
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (w *metricsSubResourceWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	countAPIRequest(w.client, "patch", obj)
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
+// metricsSubResourceClient counts the update and patch requests made to a sub-resource by resource kind.
+type metricsSubResourceClient struct {
+	client.SubResourceClient
+	client client.Client
+}
+
+func (s *metricsSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	countAPIRequest(s.client, "update", obj)
+	return s.SubResourceClient.Update(ctx, obj, opts...)
+}
+
+func (s *metricsSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	countAPIRequest(s.client, "patch", obj)
+	return s.SubResourceClient.Patch(ctx, obj, patch, opts...)
 }
@@ -0,0 +1,75 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestReconcileStep(t *testing.T) {
+	a := makeTestArgoCD()
+	deleteInstanceMetrics(a.Namespace)
+	defer deleteInstanceMetrics(a.Namespace)
+	series := testutil.CollectAndCount(ReconcileStepTime)
+
+	assert.NoError(t, reconcileStep(a, "reconcileRoles", func() error { return nil }))
+	assert.ErrorContains(t, reconcileStep(a, "reconcileTLSCerts", func() error { return errors.New("failed") }), "failed")
+
+	assert.Equal(t, series+2, testutil.CollectAndCount(ReconcileStepTime))
+	assert.Equal(t, float64(0), testutil.ToFloat64(ReconcileStepErrors.WithLabelValues(a.Namespace, "reconcileRoles")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(ReconcileStepErrors.WithLabelValues(a.Namespace, "reconcileTLSCerts")))
+
+	deleteInstanceMetrics(a.Namespace)
+	assert.Equal(t, series, testutil.CollectAndCount(ReconcileStepTime))
+}
+
+func TestMetricsClient(t *testing.T) {
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := newMetricsClient(makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs))
+	assert.Equal(t, cl, newMetricsClient(cl))
+
+	creates := testutil.ToFloat64(APIRequestsTotal.WithLabelValues("create", "ConfigMap"))
+	updates := testutil.ToFloat64(APIRequestsTotal.WithLabelValues("update", "ConfigMap"))
+	deletes := testutil.ToFloat64(APIRequestsTotal.WithLabelValues("delete", "ConfigMap"))
+	statusUpdates := testutil.ToFloat64(APIRequestsTotal.WithLabelValues("update", "ArgoCD"))
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: a.Namespace}}
+	assert.NoError(t, cl.Create(context.TODO(), cm))
+	cm.Data = map[string]string{"foo": "bar"}
+	assert.NoError(t, cl.Update(context.TODO(), cm))
+	assert.NoError(t, cl.Delete(context.TODO(), cm))
+	assert.NoError(t, cl.Status().Update(context.TODO(), a))
+
+	assert.Equal(t, creates+1, testutil.ToFloat64(APIRequestsTotal.WithLabelValues("create", "ConfigMap")))
+	assert.Equal(t, updates+1, testutil.ToFloat64(APIRequestsTotal.WithLabelValues("update", "ConfigMap")))
+	assert.Equal(t, deletes+1, testutil.ToFloat64(APIRequestsTotal.WithLabelValues("delete", "ConfigMap")))
+	assert.Equal(t, statusUpdates+1, testutil.ToFloat64(APIRequestsTotal.WithLabelValues("update", "ArgoCD")))
+}
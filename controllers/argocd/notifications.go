@@ -327,6 +327,9 @@ func (r *ReconcileArgoCD) reconcileNotificationsRoleBinding(cr *argoproj.ArgoCD,
 }
 
 func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoproj.ArgoCD, sa *corev1.ServiceAccount) error {
+	if skipSuspendedComponent(cr, argoproj.ArgoCDSuspendableComponentNotifications, "deployment") {
+		return nil
+	}
 
 	desiredDeployment := newDeploymentWithSuffix("notifications-controller", "controller", cr)
 
@@ -410,6 +413,13 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoproj.ArgoCD,
 		WorkingDir: "/app",
 	}}
 
+	setPodScheduling(cr, &desiredDeployment.Spec.Template.Spec, cr.Spec.Notifications.Scheduling, nil)
+	setPodImagePullSecrets(cr, &desiredDeployment.Spec.Template.Spec)
+	rendered := desiredDeployment.DeepCopy()
+	if err := applyResourcePatches(desiredDeployment, cr.Spec.Notifications.Patches); err != nil {
+		return err
+	}
+
 	// fetch existing deployment by name
 	deploymentChanged := false
 	existingDeployment := &appsv1.Deployment{}
@@ -503,6 +513,10 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoproj.ArgoCD,
 		deploymentChanged = true
 	}
 
+	if err := updatePatchedResource(existingDeployment, rendered, cr.Spec.Notifications.Patches, &deploymentChanged); err != nil {
+		return err
+	}
+
 	if deploymentChanged {
 		return r.Client.Update(context.TODO(), existingDeployment)
 	}
@@ -0,0 +1,144 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// scopes requested from the oidc SSO provider when none are configured.
+var oidcDefaultRequestedScopes = []string{"openid", "profile", "email"}
+
+// oidcProviderConfig is the oidc.config rendered for the oidc SSO provider.
+type oidcProviderConfig struct {
+	Name                   string                    `yaml:"name"`
+	Issuer                 string                    `yaml:"issuer"`
+	ClientID               string                    `yaml:"clientID"`
+	ClientSecret           string                    `yaml:"clientSecret"`
+	RequestedScopes        []string                  `yaml:"requestedScopes"`
+	RequestedIDTokenClaims map[string]oidcTokenClaim `yaml:"requestedIDTokenClaims,omitempty"`
+	RootCA                 string                    `yaml:"rootCA,omitempty"`
+}
+
+// oidcTokenClaim describes how a claim is requested from the oidc SSO provider.
+type oidcTokenClaim struct {
+	Essential bool `yaml:"essential"`
+}
+
+// isOIDCProvider returns true when the given ArgoCD authenticates users directly against an external OIDC provider.
+func isOIDCProvider(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.SSO != nil && cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeOIDC
+}
+
+// validateOIDCSpec returns an error message when the oidc SSO provider configuration is incomplete.
+func validateOIDCSpec(cr *argoproj.ArgoCD) string {
+	oidc := cr.Spec.SSO.OIDC
+	if oidc == nil || oidc.Issuer == "" || oidc.ClientID == "" || oidc.ClientSecretRef == nil {
+		return "must supply issuer, clientID and clientSecretRef in .spec.sso.oidc when requested SSO provider is oidc"
+	}
+	if cr.Spec.OIDCConfig != "" {
+		return "cannot supply .spec.oidcConfig when requested SSO provider is oidc"
+	}
+	return ""
+}
+
+// getOIDCGroupsClaim returns the groups claim of the oidc SSO provider, if any.
+func getOIDCGroupsClaim(cr *argoproj.ArgoCD) string {
+	if !isOIDCProvider(cr) || cr.Spec.SSO.OIDC == nil {
+		return ""
+	}
+	return cr.Spec.SSO.OIDC.GroupsClaim
+}
+
+// isOIDCClientSecret returns true when the named Secret holds the client secret of the oidc SSO provider.
+func isOIDCClientSecret(cr *argoproj.ArgoCD, name string) bool {
+	return isOIDCProvider(cr) && cr.Spec.SSO.OIDC != nil && cr.Spec.SSO.OIDC.ClientSecretRef != nil &&
+		cr.Spec.SSO.OIDC.ClientSecretRef.Name == name
+}
+
+// isOIDCRootCAConfigMap returns true when the named ConfigMap holds the root CA of the oidc SSO provider.
+func isOIDCRootCAConfigMap(cr *argoproj.ArgoCD, name string) bool {
+	return isOIDCProvider(cr) && cr.Spec.SSO.OIDC != nil && cr.Spec.SSO.OIDC.RootCARef != nil &&
+		cr.Spec.SSO.OIDC.RootCARef.Name == name
+}
+
+// getOIDCClientSecret returns the client secret of the oidc SSO provider from the referenced Secret.
+func (r *ReconcileArgoCD) getOIDCClientSecret(cr *argoproj.ArgoCD) ([]byte, error) {
+	if errMsg := validateOIDCSpec(cr); errMsg != "" {
+		return nil, errors.New(errMsg)
+	}
+
+	ref := cr.Spec.SSO.OIDC.ClientSecretRef
+	secret := &corev1.Secret{}
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, ref.Name, secret); err != nil {
+		return nil, fmt.Errorf("failed to get oidc client secret %s: %w", ref.Name, err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in oidc client secret %s", ref.Key, ref.Name)
+	}
+	return value, nil
+}
+
+// getOIDCProviderConfig will return the oidc.config for the oidc SSO provider of the given ArgoCD.
+func (r *ReconcileArgoCD) getOIDCProviderConfig(cr *argoproj.ArgoCD) (string, error) {
+	if errMsg := validateOIDCSpec(cr); errMsg != "" {
+		return "", errors.New(errMsg)
+	}
+
+	oidc := cr.Spec.SSO.OIDC
+
+	cfg := oidcProviderConfig{
+		Name:            oidc.Name,
+		Issuer:          oidc.Issuer,
+		ClientID:        oidc.ClientID,
+		ClientSecret:    "$" + common.ArgoCDOIDCSecretKey,
+		RequestedScopes: oidc.RequestedScopes,
+	}
+	if cfg.Name == "" {
+		cfg.Name = "OIDC"
+	}
+	if len(cfg.RequestedScopes) == 0 {
+		cfg.RequestedScopes = oidcDefaultRequestedScopes
+	}
+	if oidc.GroupsClaim != "" {
+		cfg.RequestedIDTokenClaims = map[string]oidcTokenClaim{
+			oidc.GroupsClaim: {Essential: true},
+		}
+	}
+
+	if ref := oidc.RootCARef; ref != nil {
+		cm := &corev1.ConfigMap{}
+		if err := argoutil.FetchObject(r.Client, cr.Namespace, ref.Name, cm); err != nil {
+			return "", fmt.Errorf("failed to get oidc root CA configmap %s: %w", ref.Name, err)
+		}
+		rootCA, ok := cm.Data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("key %s not found in oidc root CA configmap %s", ref.Key, ref.Name)
+		}
+		cfg.RootCA = rootCA
+	}
+
+	bytes, err := yaml.Marshal(cfg)
+	return string(bytes), err
+}
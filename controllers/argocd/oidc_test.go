@@ -0,0 +1,243 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestArgoCDForOIDC(opts ...argoCDOpt) *argoproj.ArgoCD {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeOIDC,
+			OIDC: &argoproj.ArgoCDOIDCSpec{
+				Issuer:   "https://idp.example.com",
+				ClientID: "argocd",
+				ClientSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "oidc-client"},
+					Key:                  "clientSecret",
+				},
+			},
+		}
+	})
+	for _, o := range opts {
+		o(a)
+	}
+	return a
+}
+
+func TestReconcileSSO_OIDCValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		argoCD  *argoproj.ArgoCD
+		wantErr string
+	}{
+		{
+			name:   "valid oidc provider",
+			argoCD: makeTestArgoCDForOIDC(),
+		},
+		{
+			name: "missing client secret reference",
+			argoCD: makeTestArgoCDForOIDC(func(cr *argoproj.ArgoCD) {
+				cr.Spec.SSO.OIDC.ClientSecretRef = nil
+			}),
+			wantErr: "must supply issuer, clientID and clientSecretRef in .spec.sso.oidc when requested SSO provider is oidc",
+		},
+		{
+			name: "oidc config supplied",
+			argoCD: makeTestArgoCDForOIDC(func(cr *argoproj.ArgoCD) {
+				cr.Spec.OIDCConfig = "name: other"
+			}),
+			wantErr: "cannot supply .spec.oidcConfig when requested SSO provider is oidc",
+		},
+		{
+			name: "dex supplied",
+			argoCD: makeTestArgoCDForOIDC(func(cr *argoproj.ArgoCD) {
+				cr.Spec.SSO.Dex = &argoproj.ArgoCDDexSpec{OpenShiftOAuth: true}
+			}),
+			wantErr: "cannot supply dex or keycloak configuration when requested SSO provider is oidc",
+		},
+		{
+			name: "oidc supplied for dex provider",
+			argoCD: makeTestArgoCDForOIDC(func(cr *argoproj.ArgoCD) {
+				cr.Spec.SSO.Provider = argoproj.SSOProviderTypeDex
+				cr.Spec.SSO.Dex = &argoproj.ArgoCDDexSpec{OpenShiftOAuth: true}
+			}),
+			wantErr: "cannot supply oidc configuration in .spec.sso.oidc when requested SSO provider is dex",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resObjs := []client.Object{test.argoCD}
+			subresObjs := []client.Object{test.argoCD}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			assert.NoError(t, createNamespace(r, test.argoCD.Namespace, ""))
+
+			err := r.reconcileSSO(test.argoCD)
+			if test.wantErr == "" {
+				// cleanup of keycloak artifacts expects a live cluster, only the validation outcome is checked
+				assert.Equal(t, ssoLegalSuccess, ssoConfigLegalStatus)
+			} else {
+				assert.Equal(t, ssoLegalFailed, ssoConfigLegalStatus)
+				assert.ErrorContains(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetOIDCProviderConfig(t *testing.T) {
+	a := makeTestArgoCDForOIDC(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO.OIDC.GroupsClaim = "roles"
+		cr.Spec.SSO.OIDC.RootCARef = &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "oidc-ca"},
+			Key:                  "ca.crt",
+		}
+	})
+	ca := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "oidc-ca", Namespace: a.Namespace},
+		Data:       map[string]string{"ca.crt": "certificate"},
+	}
+
+	resObjs := []client.Object{a, ca}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	cfgStr, err := r.getOIDCProviderConfig(a)
+	assert.NoError(t, err)
+
+	cfg := oidcProviderConfig{}
+	assert.NoError(t, yaml.Unmarshal([]byte(cfgStr), &cfg))
+	assert.Equal(t, oidcProviderConfig{
+		Name:                   "OIDC",
+		Issuer:                 "https://idp.example.com",
+		ClientID:               "argocd",
+		ClientSecret:           "$" + common.ArgoCDOIDCSecretKey,
+		RequestedScopes:        oidcDefaultRequestedScopes,
+		RequestedIDTokenClaims: map[string]oidcTokenClaim{"roles": {Essential: true}},
+		RootCA:                 "certificate",
+	}, cfg)
+	assert.Equal(t, "[roles]", getRBACScopes(a))
+
+	// a missing root CA is reported
+	assert.NoError(t, r.Client.Delete(context.TODO(), ca))
+	_, err = r.getOIDCProviderConfig(a)
+	assert.ErrorContains(t, err, "failed to get oidc root CA configmap oidc-ca")
+}
+
+func TestReconcileArgoCD_oidcRootCAConfigMapMapper(t *testing.T) {
+	a := makeTestArgoCDForOIDC(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO.OIDC.RootCARef = &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "oidc-ca"},
+			Key:                  "ca.crt",
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	requests := r.configMapResourceMapper(context.TODO(), newConfigMapWithName("oidc-ca", a))
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, a.Name, requests[0].Name)
+	}
+
+	requests = r.configMapResourceMapper(context.TODO(), newConfigMapWithName("other-ca", a))
+	assert.Empty(t, requests)
+}
+
+func TestReconcileExistingArgoSecret_OIDCClientSecret(t *testing.T) {
+	a := makeTestArgoCDForOIDC()
+	clientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oidc-client", Namespace: a.Namespace},
+		Data:       map[string][]byte{"clientSecret": []byte("one")},
+	}
+	argoCDSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDSecretName, Namespace: a.Namespace},
+		Data:       map[string][]byte{common.ArgoCDKeyServerSecretKey: []byte("key")},
+	}
+	server := newDeploymentWithSuffix("server", "server", a)
+	server.Spec.Template.Labels = map[string]string{"foo": "bar"}
+
+	resObjs := []client.Object{a, clientSecret, argoCDSecret, server}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileExistingArgoSecret(a, argoCDSecret, &corev1.Secret{}, &corev1.Secret{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, argoCDSecret))
+	assert.Equal(t, "one", string(argoCDSecret.Data[common.ArgoCDOIDCSecretKey]))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: server.Name, Namespace: a.Namespace}, deployment))
+	rollout := deployment.Spec.Template.Labels["oidc.client.secret.changed"]
+	assert.NotEmpty(t, rollout)
+
+	// an unchanged client secret does not roll the server
+	assert.NoError(t, r.reconcileExistingArgoSecret(a, argoCDSecret, &corev1.Secret{}, &corev1.Secret{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: server.Name, Namespace: a.Namespace}, deployment))
+	assert.Equal(t, rollout, deployment.Spec.Template.Labels["oidc.client.secret.changed"])
+
+	// the client secret is removed when another provider is used
+	a.Spec.SSO = nil
+	assert.NoError(t, r.reconcileExistingArgoSecret(a, argoCDSecret, &corev1.Secret{}, &corev1.Secret{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, argoCDSecret))
+	assert.NotContains(t, argoCDSecret.Data, common.ArgoCDOIDCSecretKey)
+}
+
+func TestIsUserManagedSecret_OIDCClientSecret(t *testing.T) {
+	a := makeTestArgoCDForOIDC()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "oidc-client", Namespace: a.Namespace}}
+	key, ok := r.isUserManagedSecret(context.TODO(), secret)
+	assert.True(t, ok)
+	assert.Equal(t, a.Name, key.Name)
+
+	secret.Name = "other"
+	_, ok = r.isUserManagedSecret(context.TODO(), secret)
+	assert.False(t, ok)
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// applyResourcePatches will apply the given patches in order to the given object, in place.
+func applyResourcePatches(obj client.Object, patches []argoproj.ArgoCDResourcePatch) error {
+	if len(patches) == 0 {
+		return nil
+	}
+
+	content, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	for i, patch := range patches {
+		patchJSON, err := yaml.ToJSON([]byte(patch.Patch))
+		if err != nil {
+			return fmt.Errorf("invalid patch %d of %s %s: %w", i, reflect.TypeOf(obj).Elem().Name(), obj.GetName(), err)
+		}
+
+		switch patch.Type {
+		case argoproj.ArgoCDResourcePatchTypeJSON:
+			decoded, err := jsonpatch.DecodePatch(patchJSON)
+			if err == nil {
+				content, err = decoded.Apply(content)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to apply JSON patch %d to %s %s: %w", i, reflect.TypeOf(obj).Elem().Name(), obj.GetName(), err)
+			}
+		default:
+			content, err = strategicpatch.StrategicMergePatch(content, patchJSON, obj)
+			if err != nil {
+				return fmt.Errorf("failed to apply strategic merge patch %d to %s %s: %w", i, reflect.TypeOf(obj).Elem().Name(), obj.GetName(), err)
+			}
+		}
+	}
+
+	// the object is reset first, so that the fields removed by the patches are not kept
+	patched := reflect.New(reflect.TypeOf(obj).Elem())
+	if err := json.Unmarshal(content, patched.Interface()); err != nil {
+		return err
+	}
+	reflect.ValueOf(obj).Elem().Set(patched.Elem())
+	return nil
+}
+
+// updatePatchedResource will apply the changes made by the given patches to the given rendered object onto an
+// existing object, so that the fields they set are kept in sync even when the operator does not compare them. The
+// patches are applied to the rendered object rather than to the existing one, as JSON patches such as remove or add
+// operations are not idempotent. The changed flag is set when the object is changed.
+func updatePatchedResource(existing, rendered client.Object, patches []argoproj.ArgoCDResourcePatch, changed *bool) error {
+	if len(patches) == 0 {
+		return nil
+	}
+
+	patched, ok := rendered.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unable to copy %T", rendered)
+	}
+	if err := applyResourcePatches(patched, patches); err != nil {
+		return err
+	}
+
+	renderedJSON, err := json.Marshal(rendered)
+	if err != nil {
+		return err
+	}
+	patchedJSON, err := json.Marshal(patched)
+	if err != nil {
+		return err
+	}
+	delta, err := strategicpatch.CreateTwoWayMergePatch(renderedJSON, patchedJSON, existing)
+	if err != nil {
+		return fmt.Errorf("failed to compute the changes of the patches to %s %s: %w", reflect.TypeOf(existing).Elem().Name(), existing.GetName(), err)
+	}
+
+	existingJSON, err := json.Marshal(existing)
+	if err != nil {
+		return err
+	}
+	content, err := strategicpatch.StrategicMergePatch(existingJSON, delta, existing)
+	if err != nil {
+		return fmt.Errorf("failed to apply the changes of the patches to %s %s: %w", reflect.TypeOf(existing).Elem().Name(), existing.GetName(), err)
+	}
+	updated := reflect.New(reflect.TypeOf(existing).Elem())
+	if err := json.Unmarshal(content, updated.Interface()); err != nil {
+		return err
+	}
+	if !equality.Semantic.DeepEqual(existing, updated.Interface()) {
+		reflect.ValueOf(existing).Elem().Set(updated.Elem())
+		*changed = true
+	}
+	return nil
+}
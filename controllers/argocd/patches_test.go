@@ -0,0 +1,276 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestApplyResourcePatches(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-server", Labels: map[string]string{"foo": "bar"}},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "argocd-server", Image: "argocd:v1"},
+						{Name: "sidecar", Image: "sidecar:v1"},
+					},
+				},
+			},
+		},
+	}
+
+	assert.NoError(t, applyResourcePatches(deploy, []argoproj.ArgoCDResourcePatch{
+		{
+			Patch: `
+metadata:
+  labels:
+    foo: null
+spec:
+  template:
+    spec:
+      priorityClassName: argocd-critical
+      containers:
+      - name: sidecar
+        image: sidecar:v2
+`,
+		},
+		{
+			Type:  argoproj.ArgoCDResourcePatchTypeJSON,
+			Patch: `[{"op": "replace", "path": "/spec/template/spec/dnsPolicy", "value": "None"}]`,
+		},
+	}))
+
+	assert.Empty(t, deploy.Labels)
+	assert.Equal(t, "argocd-critical", deploy.Spec.Template.Spec.PriorityClassName)
+	assert.Equal(t, corev1.DNSNone, deploy.Spec.Template.Spec.DNSPolicy)
+	assert.Len(t, deploy.Spec.Template.Spec.Containers, 2)
+	assert.Equal(t, "argocd:v1", deploy.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "sidecar:v2", deploy.Spec.Template.Spec.Containers[1].Image)
+
+	err := applyResourcePatches(deploy, []argoproj.ArgoCDResourcePatch{
+		{Type: argoproj.ArgoCDResourcePatchTypeJSON, Patch: `[{"op": "remove", "path": "/spec/unknown"}]`},
+	})
+	assert.ErrorContains(t, err, "failed to apply JSON patch 0 to Deployment argocd-server")
+}
+
+func TestReconcileArgoCD_reconcileServerDeployment_patches(t *testing.T) {
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+
+	// fields that are not otherwise managed by the operator are patched into the existing deployment
+	a.Spec.Server.Patches = []argoproj.ArgoCDResourcePatch{{
+		Patch: `{"spec": {"template": {"spec": {"priorityClassName": "argocd-critical", "dnsConfig": {"options": [{"name": "ndots", "value": "2"}]}}}}}`,
+	}}
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+
+	deploy := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deploy))
+	assert.Equal(t, "argocd-critical", deploy.Spec.Template.Spec.PriorityClassName)
+	assert.Equal(t, "ndots", deploy.Spec.Template.Spec.DNSConfig.Options[0].Name)
+
+	// reconciling the patched deployment again does not update it
+	resourceVersion := deploy.ResourceVersion
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deploy))
+	assert.Equal(t, resourceVersion, deploy.ResourceVersion)
+
+	// an invalid patch is reported
+	a.Spec.Server.Patches = []argoproj.ArgoCDResourcePatch{{Patch: "spec: ["}}
+	assert.Error(t, r.reconcileServerDeployment(a, false))
+}
+
+func TestReconcileArgoCD_reconcileServerDeployment_jsonPatches(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Server.Patches = []argoproj.ArgoCDResourcePatch{{
+			Type: argoproj.ArgoCDResourcePatchTypeJSON,
+			Patch: `[
+  {"op": "remove", "path": "/spec/template/spec/containers/0/readinessProbe"},
+  {"op": "add", "path": "/spec/template/spec/containers/0/command/-", "value": "--enable-gzip"}
+]`,
+		}}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+
+	deploy := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deploy))
+	command := deploy.Spec.Template.Spec.Containers[0].Command
+	assert.Equal(t, "--enable-gzip", command[len(command)-1])
+	assert.Nil(t, deploy.Spec.Template.Spec.Containers[0].ReadinessProbe)
+
+	// the patches are applied to the rendered deployment, so reconciling again neither fails nor appends again
+	resourceVersion := deploy.ResourceVersion
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deploy))
+	assert.Equal(t, resourceVersion, deploy.ResourceVersion)
+	assert.Equal(t, command, deploy.Spec.Template.Spec.Containers[0].Command)
+	assert.Nil(t, deploy.Spec.Template.Spec.Containers[0].ReadinessProbe)
+
+	// the managed command is restored once the patches are dropped
+	a.Spec.Server.Patches = nil
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deploy))
+	assert.NotContains(t, deploy.Spec.Template.Spec.Containers[0].Command, "--enable-gzip")
+}
+
+func TestReconcileArgoCD_reconcileWorkloads_imagePatches(t *testing.T) {
+	imagePatch := func(container string) []argoproj.ArgoCDResourcePatch {
+		return []argoproj.ArgoCDResourcePatch{{
+			Patch: `{"spec": {"template": {"spec": {"containers": [{"name": "` + container + `", "image": "registry.example.com/patched:v1"}]}}}}`,
+		}}
+	}
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Server.Patches = imagePatch("argocd-server")
+		cr.Spec.Controller.Patches = imagePatch("argocd-application-controller")
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	server := &appsv1.Deployment{}
+	serverKey := types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), serverKey, server))
+	assert.Equal(t, "registry.example.com/patched:v1", server.Spec.Template.Spec.Containers[0].Image)
+	controller := &appsv1.StatefulSet{}
+	controllerKey := types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), controllerKey, controller))
+	assert.Equal(t, "registry.example.com/patched:v1", controller.Spec.Template.Spec.Containers[0].Image)
+
+	// the patched image is the desired one, so reconciling again neither resets it nor rolls the pods
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	reconciledServer := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), serverKey, reconciledServer))
+	assert.Equal(t, server.ResourceVersion, reconciledServer.ResourceVersion)
+	assert.NotContains(t, reconciledServer.Spec.Template.Labels, "image.upgraded")
+	reconciledController := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), controllerKey, reconciledController))
+	assert.Equal(t, controller.ResourceVersion, reconciledController.ResourceVersion)
+	assert.NotContains(t, reconciledController.Spec.Template.Labels, "image.upgraded")
+}
+
+func TestReconcileArgoCD_reconcileRedisHA_patches(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.HA.Enabled = true
+		cr.Spec.HA.RedisProxyPatches = []argoproj.ArgoCDResourcePatch{{
+			Patch: `{"spec": {"template": {"spec": {"priorityClassName": "redis-proxy"}}}}`,
+		}}
+		cr.Spec.HA.RedisPatches = []argoproj.ArgoCDResourcePatch{{
+			Type:  argoproj.ArgoCDResourcePatchTypeJSON,
+			Patch: `[{"op": "replace", "path": "/spec/template/spec/terminationGracePeriodSeconds", "value": 120}]`,
+		}}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRedisHAProxyDeployment(a))
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+
+	proxy := &appsv1.Deployment{}
+	proxyKey := types.NamespacedName{Name: "argocd-redis-ha-haproxy", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), proxyKey, proxy))
+	assert.Equal(t, "redis-proxy", proxy.Spec.Template.Spec.PriorityClassName)
+	server := &appsv1.StatefulSet{}
+	serverKey := types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), serverKey, server))
+	assert.Equal(t, int64(120), *server.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	// the patched fields are restored when changed manually
+	proxy.Spec.Template.Spec.PriorityClassName = ""
+	assert.NoError(t, r.Client.Update(context.TODO(), proxy))
+	server.Spec.Template.Spec.TerminationGracePeriodSeconds = int64Ptr(10)
+	assert.NoError(t, r.Client.Update(context.TODO(), server))
+	assert.NoError(t, r.reconcileRedisHAProxyDeployment(a))
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), proxyKey, proxy))
+	assert.Equal(t, "redis-proxy", proxy.Spec.Template.Spec.PriorityClassName)
+	assert.NoError(t, r.Client.Get(context.TODO(), serverKey, server))
+	assert.Equal(t, int64(120), *server.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
+
+func TestReconcileArgoCD_reconcileKeycloakQuarkusDeployment_patches(t *testing.T) {
+	a := makeTestArgoCDForKeycloakQuarkus(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO.Keycloak.Patches = []argoproj.ArgoCDResourcePatch{{
+			Patch: `{"spec": {"template": {"spec": {"containers": [{"name": "keycloak", "image": "registry.example.com/keycloak:v1"}]}}}}`,
+		}}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	deploy, err := r.reconcileKeycloakQuarkusDeployment(a)
+	assert.NoError(t, err)
+	assert.Equal(t, "registry.example.com/keycloak:v1", deploy.Spec.Template.Spec.Containers[0].Image)
+
+	existing := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: defaultKeycloakIdentifier, Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, existing))
+	resourceVersion := existing.ResourceVersion
+
+	_, err = r.reconcileKeycloakQuarkusDeployment(a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), key, existing))
+	assert.Equal(t, resourceVersion, existing.ResourceVersion)
+	assert.Equal(t, "registry.example.com/keycloak:v1", existing.Spec.Template.Spec.Containers[0].Image)
+}
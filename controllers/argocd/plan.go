@@ -0,0 +1,379 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	// maxPlannedChanges is the maximum number of changes listed for a planned operation.
+	maxPlannedChanges = 20
+)
+
+// isPlanMode returns true when the given ArgoCD is annotated to only plan the changes to its spec.
+func isPlanMode(cr *argoproj.ArgoCD) bool {
+	return strings.EqualFold(cr.Annotations[common.ArgoCDPlanAnnotation], "true")
+}
+
+// planClient is a client that reads from the cluster and records the write requests made through it instead of
+// performing them. Objects created or updated through it are returned by later reads, so that the reconciliation can
+// carry on with the state it would have produced.
+type planClient struct {
+	client.Client
+	operations []argoproj.ArgoCDPlannedOperation
+	objects    map[string]client.Object
+}
+
+// newPlanClient returns a client recording the write requests made through it, reading through the given client.
+func newPlanClient(c client.Client) *planClient {
+	return &planClient{Client: c, objects: map[string]client.Object{}}
+}
+
+// objectKey returns the key of the given object in the planned state.
+func (c *planClient) objectKey(obj client.Object) string {
+	kind := "unknown"
+	if gvk, err := c.GroupVersionKindFor(obj); err == nil {
+		kind = gvk.Kind
+	}
+	return fmt.Sprintf("%s/%s/%s", kind, obj.GetNamespace(), obj.GetName())
+}
+
+// store keeps a copy of the given object as its planned state.
+func (c *planClient) store(obj client.Object) {
+	if planned, ok := obj.DeepCopyObject().(client.Object); ok {
+		c.objects[c.objectKey(obj)] = planned
+	}
+}
+
+// isEvent returns true when the given object is an Event, which is not part of the plan.
+func (c *planClient) isEvent(obj client.Object) bool {
+	gvk, err := c.GroupVersionKindFor(obj)
+	return err == nil && gvk.Kind == "Event"
+}
+
+// record adds an operation on the given object to the plan. An operation that is planned more than once is only
+// listed once, with the changes of the last request.
+func (c *planClient) record(action string, obj client.Object, changes []string) {
+	kind := "unknown"
+	if gvk, err := c.GroupVersionKindFor(obj); err == nil {
+		kind = gvk.Kind
+	}
+
+	name := obj.GetName()
+	if name == "" && obj.GetGenerateName() != "" {
+		name = obj.GetGenerateName() + "*"
+	}
+	op := argoproj.ArgoCDPlannedOperation{
+		Action:    action,
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      name,
+		Changes:   changes,
+	}
+	for i, existing := range c.operations {
+		if existing.Action == op.Action && existing.Kind == op.Kind && existing.Namespace == op.Namespace && existing.Name == op.Name {
+			c.operations[i] = op
+			return
+		}
+	}
+	c.operations = append(c.operations, op)
+}
+
+// isPlannedCreate returns true when the creation of the given object is already part of the plan.
+func (c *planClient) isPlannedCreate(obj client.Object) bool {
+	key := c.objectKey(obj)
+	for _, op := range c.operations {
+		if op.Action == "create" && fmt.Sprintf("%s/%s/%s", op.Kind, op.Namespace, op.Name) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *planClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	obj.SetNamespace(key.Namespace)
+	obj.SetName(key.Name)
+	planned, ok := c.objects[c.objectKey(obj)]
+	if !ok {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	if planned == nil {
+		gvk, _ := c.GroupVersionKindFor(obj)
+		return apierrors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, key.Name)
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(planned)
+	if err != nil {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(content, obj)
+}
+
+func (c *planClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if c.isEvent(obj) {
+		return nil
+	}
+	c.record("create", obj, nil)
+	if obj.GetName() != "" {
+		c.store(obj)
+	}
+	return nil
+}
+
+func (c *planClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if c.isPlannedCreate(obj) {
+		c.store(obj)
+		return nil
+	}
+	live, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unable to copy %T", obj)
+	}
+	if err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		return err
+	}
+	changes, err := diffObjects(live, obj)
+	if err != nil {
+		return err
+	}
+	if len(changes) > 0 {
+		c.record("update", obj, changes)
+	}
+	c.store(obj)
+	return nil
+}
+
+func (c *planClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.record("patch", obj, nil)
+	return nil
+}
+
+func (c *planClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.record("delete", obj, nil)
+	c.objects[c.objectKey(obj)] = nil
+	return nil
+}
+
+func (c *planClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	c.record("delete", obj, nil)
+	return nil
+}
+
+// Status returns a writer that drops status updates, which are not part of the plan.
+func (c *planClient) Status() client.SubResourceWriter {
+	return &planSubResourceClient{SubResourceClient: c.Client.SubResource("status")}
+}
+
+// SubResource returns a client that reads sub-resources and drops the writes to them.
+func (c *planClient) SubResource(subResource string) client.SubResourceClient {
+	return &planSubResourceClient{SubResourceClient: c.Client.SubResource(subResource)}
+}
+
+// planSubResourceClient reads sub-resources and drops the writes to them.
+type planSubResourceClient struct {
+	client.SubResourceClient
+}
+
+func (s *planSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return nil
+}
+
+func (s *planSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return nil
+}
+
+func (s *planSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return nil
+}
+
+// diffObjects returns the fields that differ between the live and the desired state of an object. Server managed
+// metadata and the status are ignored.
+func diffObjects(live, desired client.Object) ([]string, error) {
+	liveMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		return nil, err
+	}
+	desiredMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range []map[string]interface{}{liveMap, desiredMap} {
+		delete(m, "status")
+		delete(m, "apiVersion")
+		delete(m, "kind")
+		if metadata, ok := m["metadata"].(map[string]interface{}); ok {
+			for _, field := range []string{"resourceVersion", "managedFields", "generation", "creationTimestamp", "uid", "selfLink"} {
+				delete(metadata, field)
+			}
+		}
+	}
+
+	changes := []string{}
+	diffValues("", liveMap, desiredMap, &changes)
+	if len(changes) > maxPlannedChanges {
+		changes = append(changes[:maxPlannedChanges], fmt.Sprintf("and %d more", len(changes)-maxPlannedChanges))
+	}
+	return changes, nil
+}
+
+// diffValues appends the paths that differ between the given live and desired values to changes. Lists of objects
+// with a unique name are compared by name.
+func diffValues(path string, live, desired interface{}, changes *[]string) {
+	if reflect.DeepEqual(live, desired) {
+		return
+	}
+	if live == nil {
+		*changes = append(*changes, fmt.Sprintf("%s added", path))
+		return
+	}
+	if desired == nil {
+		*changes = append(*changes, fmt.Sprintf("%s removed", path))
+		return
+	}
+
+	switch l := live.(type) {
+	case map[string]interface{}:
+		d, ok := desired.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := []string{}
+		for k := range l {
+			keys = append(keys, k)
+		}
+		for k := range d {
+			if _, ok := l[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			diffValues(joinPlanPath(path, k), l[k], d[k], changes)
+		}
+		return
+	case []interface{}:
+		d, ok := desired.([]interface{})
+		if !ok {
+			break
+		}
+		liveByName, liveOK := listByName(l)
+		desiredByName, desiredOK := listByName(d)
+		if !liveOK || !desiredOK {
+			break
+		}
+		names := []string{}
+		for name := range liveByName {
+			names = append(names, name)
+		}
+		for name := range desiredByName {
+			if _, ok := liveByName[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			diffValues(fmt.Sprintf("%s[%s]", path, name), liveByName[name], desiredByName[name], changes)
+		}
+		return
+	}
+	*changes = append(*changes, fmt.Sprintf("%s changed", path))
+}
+
+// listByName indexes a list of objects by their name. It returns false when an element is not an object with a
+// unique name.
+func listByName(list []interface{}) (map[string]interface{}, bool) {
+	byName := map[string]interface{}{}
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := m["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		if _, exists := byName[name]; exists {
+			return nil, false
+		}
+		byName[name] = m
+	}
+	return byName, true
+}
+
+// joinPlanPath appends a field to the path of a planned change.
+func joinPlanPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// reconcilePlan will compute the operations needed to apply the spec of the given ArgoCD without performing them,
+// and report them in its status.
+func (r *ReconcileArgoCD) reconcilePlan(cr *argoproj.ArgoCD) error {
+	pc := newPlanClient(r.Client)
+	planner := &ReconcileArgoCD{
+		Client:         pc,
+		Scheme:         r.Scheme,
+		LabelSelector:  r.LabelSelector,
+		planning:       true,
+		digestResolver: r.digestResolver,
+	}
+
+	plan := &argoproj.ArgoCDPlanStatus{ObservedGeneration: cr.Generation}
+	desired := cr.DeepCopy()
+	err := planner.setManagedNamespaces(desired)
+	if err == nil {
+		err = planner.setManagedSourceNamespaces(desired)
+	}
+	if err == nil {
+		err = planner.setManagedApplicationSetSourceNamespaces(desired)
+	}
+	if err == nil {
+		err = planner.reconcileResources(desired)
+	}
+	if err != nil {
+		plan.Error = err.Error()
+	}
+	plan.Operations = pc.operations
+
+	if reflect.DeepEqual(cr.Status.Plan, plan) {
+		return nil
+	}
+	log.Info(fmt.Sprintf("planned %d operations for ArgoCD %s in namespace %s", len(plan.Operations), cr.Name, cr.Namespace))
+	cr.Status.Plan = plan
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// reconcileStatusPlan will ensure that the plan is removed from the status of the given ArgoCD once it leaves plan mode.
+func (r *ReconcileArgoCD) reconcileStatusPlan(cr *argoproj.ArgoCD) error {
+	if cr.Status.Plan == nil {
+		return nil
+	}
+	cr.Status.Plan = nil
+	return r.Client.Status().Update(context.TODO(), cr)
+}
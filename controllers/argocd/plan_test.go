@@ -0,0 +1,231 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func findPlannedOperation(plan *argoproj.ArgoCDPlanStatus, action, kind, name string) *argoproj.ArgoCDPlannedOperation {
+	for i, op := range plan.Operations {
+		if op.Action == action && op.Kind == kind && op.Name == name {
+			return &plan.Operations[i]
+		}
+	}
+	return nil
+}
+
+func TestDiffObjects(t *testing.T) {
+	live := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-server", ResourceVersion: "1"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "argocd-server", Command: []string{"argocd-server"}},
+						{Name: "sidecar", Image: "sidecar:v1"},
+					},
+				},
+			},
+		},
+	}
+	desired := live.DeepCopy()
+	desired.ResourceVersion = "2"
+	desired.Labels = map[string]string{"foo": "bar"}
+	desired.Spec.Template.Spec.Containers[0].Command = []string{"argocd-server", "--insecure"}
+	desired.Spec.Template.Spec.Containers = desired.Spec.Template.Spec.Containers[:1]
+
+	changes, err := diffObjects(live, desired)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"metadata.labels added",
+		"spec.template.spec.containers[argocd-server].command changed",
+		"spec.template.spec.containers[sidecar] removed",
+	}, changes)
+
+	changes, err = diffObjects(live, live.DeepCopy())
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestReconcileArgoCD_reconcilePlan(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Annotations = map[string]string{common.ArgoCDPlanAnnotation: "true"}
+		cr.Spec.Server.Insecure = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	// nothing is created while planning
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, &appsv1.Deployment{})
+	assert.Error(t, err)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	assert.NotNil(t, a.Status.Plan)
+	assert.NotNil(t, findPlannedOperation(a.Status.Plan, "create", "Deployment", "argocd-server"))
+	assert.NotNil(t, findPlannedOperation(a.Status.Plan, "create", "StatefulSet", "argocd-application-controller"))
+
+	// the operations are applied once the annotation is removed, and the plan is cleared
+	a.Annotations = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	server := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, server))
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	assert.Nil(t, a.Status.Plan)
+
+	// changes to existing resources are reported per field
+	a.Annotations = map[string]string{common.ArgoCDPlanAnnotation: "true"}
+	a.Spec.Server.Insecure = false
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	op := findPlannedOperation(a.Status.Plan, "update", "Deployment", "argocd-server")
+	if assert.NotNil(t, op) {
+		assert.Contains(t, op.Changes, "spec.template.spec.containers[argocd-server].command changed")
+	}
+	assert.Nil(t, findPlannedOperation(a.Status.Plan, "create", "Deployment", "argocd-server"))
+
+	// the live deployment is left untouched
+	live := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, live))
+	assert.Equal(t, server.Spec.Template.Spec.Containers[0].Command, live.Spec.Template.Spec.Containers[0].Command)
+}
+
+func TestReconcileArgoCD_reconcilePlan_noSideEffects(t *testing.T) {
+	realm := &fakeKeycloakRealm{}
+	server := httptest.NewServer(realm)
+	defer server.Close()
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Annotations = map[string]string{common.ArgoCDPlanAnnotation: "true"}
+		cr.Finalizers = []string{common.ArgoCDDeletionFinalizer}
+		cr.Spec.Version = "v2.11.0"
+		cr.Spec.ImageRegistry = &argoproj.ArgoCDImageRegistrySpec{ResolveDigests: true}
+		cr.Spec.Tenancy = &argoproj.ArgoCDTenancySpec{
+			Owners:          []string{"alice"},
+			OwnerAnnotation: testOwnerAnnotation,
+		}
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+			Keycloak: &argoproj.ArgoCDKeycloakSpec{
+				Mode: argoproj.KeycloakModeExternal,
+				External: &argoproj.ArgoCDKeycloakExternalSpec{
+					URL:               server.URL + "/",
+					Realm:             "corp",
+					CredentialsSecret: "keycloak-sa",
+				},
+			},
+		}
+	})
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keycloak-sa", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			keycloakExternalClientIDKey:     []byte("argocd-operator"),
+			keycloakExternalClientSecretKey: []byte("sa-secret"),
+		},
+	}
+	clientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        keycloakClientSecretName,
+			Namespace:   a.Namespace,
+			Annotations: map[string]string{keycloakExternalConfigAnnotation: "configured"},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: a.Namespace}}
+	foreign := makeTestTenantNamespace("foreign", time.Hour, nil, nil)
+
+	resObjs := []client.Object{a, credentials, clientSecret, namespace, foreign}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	writes := []string{}
+	record := func(action string, obj client.Object) {
+		writes = append(writes, fmt.Sprintf("%s %T %s", action, obj, obj.GetName()))
+	}
+	cl := interceptor.NewClient(makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs).(client.WithWatch), interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			record("create", obj)
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			record("update", obj)
+			return c.Update(ctx, obj, opts...)
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			record("patch", obj)
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			record("delete", obj)
+			return c.Delete(ctx, obj, opts...)
+		},
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			record("update "+subResourceName, obj)
+			return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+		},
+	})
+	r := makeTestReconciler(cl, sch)
+	resolved := 0
+	r.digestResolver = func(image string, credentials map[string]registryCredentials) (string, error) {
+		resolved++
+		return "sha256:1", nil
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	// only the plan is written, and neither the registries nor the realm are queried
+	assert.Equal(t, []string{fmt.Sprintf("update status *v1beta1.ArgoCD %s", a.Name)}, writes)
+	assert.Zero(t, resolved)
+	assert.Empty(t, realm.requests)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	if assert.NotNil(t, a.Status.Plan) {
+		assert.Empty(t, a.Status.Plan.Error)
+	}
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+	assert.Empty(t, events.Items)
+}
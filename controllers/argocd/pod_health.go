@@ -0,0 +1,309 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	podHealthReasonCrashLoopBackOff = "CrashLoopBackOff"
+	podHealthReasonOOMKilled        = "OOMKilled"
+	podHealthReasonUnschedulable    = "Unschedulable"
+	podHealthReasonErrImagePull     = "ErrImagePull"
+	podHealthReasonImagePullBackOff = "ImagePullBackOff"
+	podHealthReasonInvalidImageName = "InvalidImageName"
+	podHealthReasonProbeFailing     = "ProbeFailing"
+
+	// podHealthEventReasonRecovered is the reason of the Event emitted once the pods of a workload are healthy again.
+	podHealthEventReasonRecovered = "WorkloadRecovered"
+
+	// probeFailureGracePeriod is how long a running container may fail its readiness probe before it is reported.
+	probeFailureGracePeriod = 2 * time.Minute
+
+	// podHealthRecheckInterval is how often the pods are inspected again while some of them are failing or not ready,
+	// as pods are not watched.
+	podHealthRecheckInterval = 30 * time.Second
+)
+
+// inspectedWorkload is a workload whose pods are inspected by the pod health inspector.
+type inspectedWorkload struct {
+	component argoproj.ArgoCDSuspendableComponent
+	object    client.Object
+}
+
+// getPodIssue will return the reason and message of the failure of the given pod, or empty strings when the pod is
+// healthy. Readiness probe failures are only reported once a container has been running for the grace period.
+func getPodIssue(pod *corev1.Pod, now time.Time) (string, string) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+			return podHealthReasonUnschedulable, condition.Message
+		}
+	}
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if waiting := cs.State.Waiting; waiting != nil {
+			switch waiting.Reason {
+			case podHealthReasonCrashLoopBackOff:
+				if terminated := cs.LastTerminationState.Terminated; terminated != nil && terminated.Reason == podHealthReasonOOMKilled {
+					return podHealthReasonOOMKilled, fmt.Sprintf("container %s was killed for exceeding its memory limit", cs.Name)
+				}
+				return podHealthReasonCrashLoopBackOff, withPodMessage(fmt.Sprintf("container %s is crash looping", cs.Name), waiting.Message)
+			case podHealthReasonErrImagePull, podHealthReasonImagePullBackOff, podHealthReasonInvalidImageName:
+				return waiting.Reason, withPodMessage(fmt.Sprintf("container %s cannot pull image %s", cs.Name, cs.Image), waiting.Message)
+			}
+		}
+		if terminated := cs.State.Terminated; terminated != nil && terminated.Reason == podHealthReasonOOMKilled {
+			return podHealthReasonOOMKilled, fmt.Sprintf("container %s was killed for exceeding its memory limit", cs.Name)
+		}
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Ready || cs.State.Running == nil {
+			continue
+		}
+		if now.Sub(cs.State.Running.StartedAt.Time) >= probeFailureGracePeriod {
+			return podHealthReasonProbeFailing, fmt.Sprintf("container %s has not passed its readiness probe for more than %s", cs.Name, probeFailureGracePeriod)
+		}
+	}
+	return "", ""
+}
+
+// getPodRecheck will return how long to wait before the given pod is inspected again, or zero when it is ready. A
+// container failing its readiness probe is inspected again once its grace period is over.
+func getPodRecheck(pod *corev1.Pod, now time.Time) time.Duration {
+	if reason, _ := getPodIssue(pod, now); reason != "" {
+		return podHealthRecheckInterval
+	}
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return podHealthRecheckInterval
+	}
+
+	var recheck time.Duration
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Ready {
+			continue
+		}
+		delay := podHealthRecheckInterval
+		if cs.State.Running != nil {
+			delay = cs.State.Running.StartedAt.Add(probeFailureGracePeriod).Sub(now)
+			if delay < time.Second {
+				delay = time.Second
+			}
+		}
+		if recheck == 0 || delay < recheck {
+			recheck = delay
+		}
+	}
+	return recheck
+}
+
+// withPodMessage will append the given message of the kubelet to the given description, when set.
+func withPodMessage(description string, message string) string {
+	if message == "" {
+		return description
+	}
+	return description + ": " + message
+}
+
+// getInspectedWorkloads will return the workloads of the enabled components of the given ArgoCD.
+func getInspectedWorkloads(cr *argoproj.ArgoCD) []inspectedWorkload {
+	workloads := []inspectedWorkload{
+		{argoproj.ArgoCDSuspendableComponentApplicationController, newStatefulSetWithSuffix("application-controller", "application-controller", cr)},
+		{argoproj.ArgoCDSuspendableComponentRepo, newDeploymentWithSuffix("repo-server", "repo-server", cr)},
+		{argoproj.ArgoCDSuspendableComponentServer, newDeploymentWithSuffix("server", "server", cr)},
+	}
+	if cr.Spec.HA.Enabled {
+		workloads = append(workloads,
+			inspectedWorkload{argoproj.ArgoCDSuspendableComponentRedis, newStatefulSetWithSuffix("redis-ha-server", "redis-ha-server", cr)},
+			inspectedWorkload{argoproj.ArgoCDSuspendableComponentRedis, newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)},
+		)
+	} else {
+		workloads = append(workloads, inspectedWorkload{argoproj.ArgoCDSuspendableComponentRedis, newDeploymentWithSuffix("redis", "redis", cr)})
+	}
+	if cr.Spec.ApplicationSet != nil {
+		workloads = append(workloads, inspectedWorkload{argoproj.ArgoCDSuspendableComponentApplicationSet, newDeploymentWithSuffix("applicationset-controller", "controller", cr)})
+	}
+	if cr.Spec.Notifications.Enabled {
+		workloads = append(workloads, inspectedWorkload{argoproj.ArgoCDSuspendableComponentNotifications, newDeploymentWithSuffix("notifications-controller", "controller", cr)})
+	}
+	if UseDex(cr) {
+		workloads = append(workloads, inspectedWorkload{argoproj.ArgoCDSuspendableComponentDex, newDeploymentWithSuffix("dex-server", "dex-server", cr)})
+	}
+	return workloads
+}
+
+// listWorkloadPods will return the pods selected by the given label selector, sorted by name.
+func (r *ReconcileArgoCD) listWorkloadPods(namespace string, selector *metav1.LabelSelector) ([]corev1.Pod, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	podList := &corev1.PodList{}
+	if err := r.Client.List(context.TODO(), podList, client.InNamespace(namespace), client.MatchingLabels(selector.MatchLabels)); err != nil {
+		return nil, err
+	}
+	sort.Slice(podList.Items, func(i, j int) bool { return podList.Items[i].Name < podList.Items[j].Name })
+	return podList.Items, nil
+}
+
+// getWorkloadPods will return the pods of the given workload, or none when the workload does not exist.
+func (r *ReconcileArgoCD) getWorkloadPods(cr *argoproj.ArgoCD, workload inspectedWorkload) ([]corev1.Pod, error) {
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, workload.object.GetName(), workload.object) {
+		return nil, nil
+	}
+
+	var selector *metav1.LabelSelector
+	switch obj := workload.object.(type) {
+	case *appsv1.Deployment:
+		selector = obj.Spec.Selector
+	case *appsv1.StatefulSet:
+		selector = obj.Spec.Selector
+	}
+	return r.listWorkloadPods(cr.Namespace, selector)
+}
+
+// inspectWorkload will return the failure of the first failing pod of the given workload, or nil when its pods are
+// healthy or the workload does not exist.
+func (r *ReconcileArgoCD) inspectWorkload(cr *argoproj.ArgoCD, workload inspectedWorkload) (*argoproj.ArgoCDUnhealthyWorkload, error) {
+	pods, err := r.getWorkloadPods(cr, workload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range pods {
+		reason, message := getPodIssue(&pods[i], now)
+		if reason == "" {
+			continue
+		}
+		return &argoproj.ArgoCDUnhealthyWorkload{
+			Component: string(workload.component),
+			Workload:  workload.object.GetName(),
+			Pod:       pods[i].Name,
+			Reason:    reason,
+			Message:   message,
+		}, nil
+	}
+	return nil, nil
+}
+
+// getPodHealthRecheck will return how long to wait before the pods of the given ArgoCD are inspected again, or zero
+// when all of them are ready.
+func (r *ReconcileArgoCD) getPodHealthRecheck(cr *argoproj.ArgoCD) time.Duration {
+	var recheck time.Duration
+	now := time.Now()
+	for _, workload := range getInspectedWorkloads(cr) {
+		pods, err := r.getWorkloadPods(cr, workload)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("failed to list the pods of %s", workload.object.GetName()))
+			continue
+		}
+		for i := range pods {
+			if delay := getPodRecheck(&pods[i], now); delay > 0 && (recheck == 0 || delay < recheck) {
+				recheck = delay
+			}
+		}
+	}
+	return recheck
+}
+
+// reconcileStatusUnhealthyWorkloads will ensure that the workloads with failing pods are reported in the status of the
+// given ArgoCD. An Event is emitted when a workload starts failing, or fails for another reason, and once it recovers.
+func (r *ReconcileArgoCD) reconcileStatusUnhealthyWorkloads(cr *argoproj.ArgoCD) error {
+	var unhealthy []argoproj.ArgoCDUnhealthyWorkload
+	for _, workload := range getInspectedWorkloads(cr) {
+		issue, err := r.inspectWorkload(cr, workload)
+		if err != nil {
+			return err
+		}
+		if issue != nil {
+			unhealthy = append(unhealthy, *issue)
+		}
+	}
+
+	for _, issue := range unhealthy {
+		if previous := getUnhealthyWorkload(cr, issue.Workload); previous != nil && previous.Reason == issue.Reason {
+			continue
+		}
+		message := fmt.Sprintf("pod %s of %s: %s", issue.Pod, issue.Workload, issue.Message)
+		log.Info(fmt.Sprintf("ArgoCD %s in namespace %s: %s", cr.Name, cr.Namespace, message))
+		if err := argoutil.CreateEvent(r.Client, corev1.EventTypeWarning, "Inspect", message, issue.Reason, cr.ObjectMeta, cr.TypeMeta); err != nil {
+			log.Error(err, fmt.Sprintf("failed to create event for unhealthy workload %s", issue.Workload))
+		}
+	}
+	for _, previous := range cr.Status.UnhealthyWorkloads {
+		if containsUnhealthyWorkload(unhealthy, previous.Workload) {
+			continue
+		}
+		message := fmt.Sprintf("the pods of %s are no longer failing", previous.Workload)
+		log.Info(fmt.Sprintf("ArgoCD %s in namespace %s: %s", cr.Name, cr.Namespace, message))
+		if err := argoutil.CreateEvent(r.Client, corev1.EventTypeNormal, "Inspect", message, podHealthEventReasonRecovered, cr.ObjectMeta, cr.TypeMeta); err != nil {
+			log.Error(err, fmt.Sprintf("failed to create event for recovered workload %s", previous.Workload))
+		}
+	}
+
+	if reflect.DeepEqual(cr.Status.UnhealthyWorkloads, unhealthy) {
+		return nil
+	}
+	cr.Status.UnhealthyWorkloads = unhealthy
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// getUnhealthyWorkload will return the failure reported in the status of the given ArgoCD for the given workload.
+func getUnhealthyWorkload(cr *argoproj.ArgoCD, workload string) *argoproj.ArgoCDUnhealthyWorkload {
+	for i := range cr.Status.UnhealthyWorkloads {
+		if cr.Status.UnhealthyWorkloads[i].Workload == workload {
+			return &cr.Status.UnhealthyWorkloads[i]
+		}
+	}
+	return nil
+}
+
+// containsUnhealthyWorkload returns true when the given failures contain the given workload.
+func containsUnhealthyWorkload(unhealthy []argoproj.ArgoCDUnhealthyWorkload, workload string) bool {
+	for _, issue := range unhealthy {
+		if issue.Workload == workload {
+			return true
+		}
+	}
+	return false
+}
+
+// getWorkloadStatus will return Failed when the pods of one of the given workloads are reported as failing in the
+// status of the given ArgoCD, and the given status of the component otherwise.
+func getWorkloadStatus(cr *argoproj.ArgoCD, status string, workloads ...string) string {
+	if status == "Unknown" {
+		return status
+	}
+	for _, workload := range workloads {
+		if getUnhealthyWorkload(cr, workload) != nil {
+			return "Failed"
+		}
+	}
+	return status
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestGetPodIssue(t *testing.T) {
+	now := time.Now()
+	waiting := func(reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: "argocd-repo-server", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}}}
+	}
+	running := func(started time.Time, ready bool) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: "argocd-server", Ready: ready, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(started)}}}
+	}
+	oomKilled := waiting(podHealthReasonCrashLoopBackOff)
+	oomKilled.LastTerminationState.Terminated = &corev1.ContainerStateTerminated{Reason: podHealthReasonOOMKilled}
+
+	tests := []struct {
+		name   string
+		status corev1.PodStatus
+		reason string
+	}{
+		{"healthy", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{running(now.Add(-time.Hour), true)}}, ""},
+		{"crash loop", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{waiting(podHealthReasonCrashLoopBackOff)}}, podHealthReasonCrashLoopBackOff},
+		{"oom killed", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{oomKilled}}, podHealthReasonOOMKilled},
+		{"image pull", corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{waiting(podHealthReasonImagePullBackOff)}}, podHealthReasonImagePullBackOff},
+		{"unschedulable", corev1.PodStatus{Conditions: []corev1.PodCondition{{
+			Type:   corev1.PodScheduled,
+			Status: corev1.ConditionFalse,
+			Reason: corev1.PodReasonUnschedulable,
+		}}}, podHealthReasonUnschedulable},
+		{"starting", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{running(now.Add(-time.Minute), false)}}, ""},
+		{"probe failing", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{running(now.Add(-time.Hour), false)}}, podHealthReasonProbeFailing},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reason, _ := getPodIssue(&corev1.Pod{Status: test.status}, now)
+			assert.Equal(t, test.reason, reason)
+		})
+	}
+}
+
+func TestGetPodRecheck(t *testing.T) {
+	now := time.Now()
+	running := func(started time.Time, ready bool) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: "argocd-server", Ready: ready, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(started)}}}
+	}
+
+	tests := []struct {
+		name    string
+		status  corev1.PodStatus
+		recheck time.Duration
+	}{
+		{"ready", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{running(now.Add(-time.Hour), true)}}, 0},
+		{"pending", corev1.PodStatus{}, podHealthRecheckInterval},
+		{"crash loop", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "argocd-server",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: podHealthReasonCrashLoopBackOff}},
+		}}}, podHealthRecheckInterval},
+		{"starting", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{running(now.Add(-time.Minute), false)}}, time.Minute},
+		{"probe failing", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{running(now.Add(-time.Hour), false)}}, podHealthRecheckInterval},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.recheck, getPodRecheck(&corev1.Pod{Status: test.status}, now))
+		})
+	}
+}
+
+func TestReconcileArgoCD_getPodHealthRecheck(t *testing.T) {
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.Zero(t, r.getPodHealthRecheck(a))
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "argocd-server-7d9f8b6c5-x2x4z",
+			Namespace: a.Namespace,
+			Labels:    map[string]string{common.ArgoCDKeyName: "argocd-server"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "argocd-server",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: podHealthReasonCrashLoopBackOff}},
+			}},
+		},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), pod))
+
+	// a failing pod is inspected again
+	assert.Equal(t, podHealthRecheckInterval, r.getPodHealthRecheck(a))
+
+	// a ready pod is not
+	pod.Status.ContainerStatuses[0] = corev1.ContainerStatus{
+		Name:  "argocd-server",
+		Ready: true,
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), pod))
+	assert.Zero(t, r.getPodHealthRecheck(a))
+}
+
+func TestReconcileArgoCD_reconcileStatusUnhealthyWorkloads(t *testing.T) {
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "argocd-server-7d9f8b6c5-x2x4z",
+			Namespace: a.Namespace,
+			Labels:    map[string]string{common.ArgoCDKeyName: "argocd-server"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "argocd-server",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: podHealthReasonCrashLoopBackOff}},
+			}},
+		},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), pod))
+
+	// the failing pod is reported, and the server component is failed
+	assert.NoError(t, r.reconcileStatus(a))
+	assert.Equal(t, []argoproj.ArgoCDUnhealthyWorkload{{
+		Component: string(argoproj.ArgoCDSuspendableComponentServer),
+		Workload:  "argocd-server",
+		Pod:       pod.Name,
+		Reason:    podHealthReasonCrashLoopBackOff,
+		Message:   "container argocd-server is crash looping",
+	}}, a.Status.UnhealthyWorkloads)
+	assert.Equal(t, "Failed", a.Status.Server)
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+	if assert.Len(t, events.Items, 1) {
+		assert.Equal(t, corev1.EventTypeWarning, events.Items[0].Type)
+		assert.Equal(t, podHealthReasonCrashLoopBackOff, events.Items[0].Reason)
+	}
+
+	// the same failure is only reported once
+	assert.NoError(t, r.reconcileStatus(a))
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+	assert.Len(t, events.Items, 1)
+
+	// the recovery is reported
+	pod.Status.ContainerStatuses[0] = corev1.ContainerStatus{
+		Name:  "argocd-server",
+		Ready: true,
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), pod))
+	assert.NoError(t, r.reconcileStatus(a))
+	assert.Empty(t, a.Status.UnhealthyWorkloads)
+	assert.Equal(t, "Pending", a.Status.Server)
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+	if assert.Len(t, events.Items, 2) {
+		reasons := []string{events.Items[0].Reason, events.Items[1].Reason}
+		assert.Contains(t, reasons, podHealthEventReasonRecovered)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"golang.org/x/mod/semver"
 
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
 
 	v1 "k8s.io/api/rbac/v1"
@@ -374,7 +375,7 @@ func policyRuleForServerClusterRole() []v1.PolicyRule {
 	}
 }
 
-func getPolicyRuleList(client client.Client) []struct {
+func getPolicyRuleList(client client.Client, cr *argoproj.ArgoCD) []struct {
 	name       string
 	policyRule []v1.PolicyRule
 } {
@@ -390,7 +391,7 @@ func getPolicyRuleList(client client.Client) []struct {
 			policyRule: policyRuleForDexServer(),
 		}, {
 			name:       common.ArgoCDServerComponent,
-			policyRule: policyRuleForServer(),
+			policyRule: appendServerExecPolicyRule(policyRuleForServer(), cr),
 		}, {
 			name:       common.ArgoCDRedisHAComponent,
 			policyRule: policyRuleForRedisHa(client),
@@ -401,7 +402,7 @@ func getPolicyRuleList(client client.Client) []struct {
 	}
 }
 
-func getPolicyRuleClusterRoleList() []struct {
+func getPolicyRuleClusterRoleList(cr *argoproj.ArgoCD) []struct {
 	name       string
 	policyRule []v1.PolicyRule
 } {
@@ -414,7 +415,7 @@ func getPolicyRuleClusterRoleList() []struct {
 			policyRule: policyRuleForApplicationController(),
 		}, {
 			name:       common.ArgoCDServerComponent,
-			policyRule: policyRuleForServerClusterRole(),
+			policyRule: appendServerExecPolicyRule(policyRuleForServerClusterRole(), cr),
 		}, {
 			name:       common.ArgoCDApplicationControllerComponentView,
 			policyRule: policyRuleForApplicationControllerView(),
@@ -425,6 +426,25 @@ func getPolicyRuleClusterRoleList() []struct {
 	}
 }
 
+// appendServerExecPolicyRule will append the rule allowing the Argo CD server to open a terminal in the pods, when the
+// web-based terminal is enabled for the given ArgoCD.
+func appendServerExecPolicyRule(rules []v1.PolicyRule, cr *argoproj.ArgoCD) []v1.PolicyRule {
+	if cr.Spec.Exec == nil || !cr.Spec.Exec.Enabled {
+		return rules
+	}
+	return append(rules, v1.PolicyRule{
+		APIGroups: []string{
+			"",
+		},
+		Resources: []string{
+			"pods/exec",
+		},
+		Verbs: []string{
+			"create",
+		},
+	})
+}
+
 func appendOpenShiftNonRootSCC(rules []v1.PolicyRule, client client.Client) []v1.PolicyRule {
 	if IsVersionAPIAvailable() {
 		// Starting with OpenShift 4.11, we need to use the resource name "nonroot-v2" instead of "nonroot"
@@ -17,6 +17,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -395,3 +396,151 @@ func newPrometheusRule(namespace, alertRuleName string) *monitoringv1.Prometheus
 	}
 	return promRule
 }
+
+// alertGroupDefaults holds the defaults of a group of the alert catalogue.
+type alertGroupDefaults struct {
+	threshold string
+	severity  string
+	duration  string
+	// disabled is set for the groups that are only created when enabled explicitly.
+	disabled bool
+}
+
+// newAlertRule returns an alert of the catalogue, applying the configuration of its alert group.
+func newAlertRule(cr *argoproj.ArgoCD, alerts *argoproj.ArgoCDAlertsSpec, group *argoproj.ArgoCDAlertGroupSpec, defaults alertGroupDefaults, alert, expr, message string) monitoringv1.Rule {
+	threshold, severity, duration := defaults.threshold, defaults.severity, defaults.duration
+	labels := map[string]string{}
+	for k, v := range alerts.AdditionalLabels {
+		labels[k] = v
+	}
+	if group != nil {
+		if group.Threshold != "" {
+			threshold = group.Threshold
+		}
+		if group.Severity != "" {
+			severity = group.Severity
+		}
+		if group.For != "" {
+			duration = group.For
+		}
+		for k, v := range group.Labels {
+			labels[k] = v
+		}
+	}
+	labels["severity"] = severity
+
+	return monitoringv1.Rule{
+		Alert: alert,
+		Annotations: map[string]string{
+			"message": fmt.Sprintf(message, cr.Namespace, threshold),
+		},
+		Expr: intstr.IntOrString{
+			Type:   intstr.String,
+			StrVal: fmt.Sprintf(expr, threshold),
+		},
+		For:    duration,
+		Labels: labels,
+	}
+}
+
+// getAlertRuleGroups returns the enabled groups of the alert catalogue for the given ArgoCD, or none when the
+// catalogue is not enabled.
+func getAlertRuleGroups(cr *argoproj.ArgoCD) []monitoringv1.RuleGroup {
+	alerts := cr.Spec.Monitoring.Alerts
+	if alerts == nil || !alerts.Enabled {
+		return nil
+	}
+
+	controller := fmt.Sprintf("namespace=\"%s\", job=\"%s\"", cr.Namespace, nameWithSuffix("metrics", cr))
+	repoServer := fmt.Sprintf("namespace=\"%s\", job=\"%s\"", cr.Namespace, nameWithSuffix("repo-server", cr))
+	redis := fmt.Sprintf("namespace=\"%s\", pod=~\"%s.*\", container=\"redis\"", cr.Namespace, nameWithSuffix("redis", cr))
+
+	groups := []monitoringv1.RuleGroup{}
+	add := func(name string, group *argoproj.ArgoCDAlertGroupSpec, defaults alertGroupDefaults, alert, expr, message string) {
+		if group.IsEnabled(!defaults.disabled) {
+			rule := newAlertRule(cr, alerts, group, defaults, alert, expr, message)
+			groups = append(groups, monitoringv1.RuleGroup{Name: name, Rules: []monitoringv1.Rule{rule}})
+		}
+	}
+
+	add("ArgoCDApplicationSync", alerts.ApplicationSync,
+		alertGroupDefaults{threshold: "0.1", severity: "warning", duration: "10m"},
+		"ApplicationSyncFailureRatioHigh",
+		"sum(increase(argocd_app_sync_total{"+controller+", phase=~\"Error|Failed\"}[1h])) / sum(increase(argocd_app_sync_total{"+controller+"}[1h])) > %s",
+		"more than %[2]s of the application syncs of the Argo CD instance in namespace %[1]s failed in the last hour")
+
+	add("ArgoCDApplicationHealth", alerts.ApplicationHealth,
+		alertGroupDefaults{threshold: "0.1", severity: "warning", duration: "10m"},
+		"ApplicationDegradedRatioHigh",
+		"count(argocd_app_info{"+controller+", health_status=\"Degraded\"}) / count(argocd_app_info{"+controller+"}) > %s",
+		"more than %[2]s of the applications of the Argo CD instance in namespace %[1]s are degraded")
+
+	add("ArgoCDManifestGeneration", alerts.ManifestGeneration,
+		alertGroupDefaults{threshold: "30", severity: "warning", duration: "10m", disabled: true},
+		"ManifestGenerationLatencyHigh",
+		"histogram_quantile(0.95, sum(rate(grpc_server_handling_seconds_bucket{"+repoServer+", grpc_method=\"GenerateManifest\"}[5m])) by (le)) > %s",
+		"95th percentile of the manifest generation latency of the repo server of the Argo CD instance in namespace %[1]s is above %[2]s seconds")
+
+	add("ArgoCDControllerQueue", alerts.ControllerQueue,
+		alertGroupDefaults{threshold: "100", severity: "warning", duration: "10m"},
+		"ApplicationControllerQueueDepthHigh",
+		"sum(workqueue_depth{"+controller+", name=~\"app_.*\"}) by (name) > %s",
+		"a reconciliation queue of the application controller of the Argo CD instance in namespace %[1]s holds more than %[2]s items")
+
+	add("ArgoCDRedisMemory", alerts.RedisMemory,
+		alertGroupDefaults{threshold: "0.9", severity: "warning", duration: "10m"},
+		"RedisMemoryUsageHigh",
+		"max(container_memory_working_set_bytes{"+redis+"} / (container_spec_memory_limit_bytes{"+redis+"} > 0)) > %s",
+		"redis of the Argo CD instance in namespace %[1]s uses more than %[2]s of its memory limit")
+
+	add("ArgoCDGitRequests", alerts.GitRequests,
+		alertGroupDefaults{threshold: "0", severity: "warning", duration: "10m"},
+		"GitFetchFailures",
+		"sum(increase(argocd_git_fetch_fail_total{"+repoServer+"}[10m])) > %s",
+		"more than %[2]s git fetches of the repo server of the Argo CD instance in namespace %[1]s failed in the last 10 minutes")
+
+	add("ArgoCDCertificateExpiry", alerts.CertificateExpiry,
+		alertGroupDefaults{threshold: "14", severity: "warning", duration: "1h"},
+		"CertificateExpiringSoon",
+		"(certmanager_certificate_expiration_timestamp_seconds{exported_namespace=\""+cr.Namespace+"\"} - time()) / 86400 < %s",
+		"a certificate in namespace %[1]s of the Argo CD instance expires in less than %[2]s days")
+
+	return groups
+}
+
+// reconcileAlertCatalogue reconciles the PrometheusRule holding the alert catalogue of the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileAlertCatalogue(cr *argoproj.ArgoCD) error {
+	promRule := newPrometheusRule(cr.Namespace, nameWithSuffix("alerts", cr))
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, promRule.Name, promRule)
+
+	groups := []monitoringv1.RuleGroup{}
+	if cr.Spec.Monitoring.Enabled {
+		groups = getAlertRuleGroups(cr)
+	}
+
+	if len(groups) == 0 {
+		if found {
+			log.Info("alert catalogue disabled, deleting alert catalogue prometheusRule")
+			return r.Client.Delete(context.TODO(), promRule)
+		}
+		return nil
+	}
+
+	if found {
+		if reflect.DeepEqual(promRule.Spec.Groups, groups) {
+			return nil
+		}
+		promRule.Spec.Groups = groups
+		log.Info("updating alert catalogue prometheusRule")
+		return r.Client.Update(context.TODO(), promRule)
+	}
+
+	promRule.Labels = argoutil.LabelsForCluster(cr)
+	promRule.Spec.Groups = groups
+	if err := controllerutil.SetControllerReference(cr, promRule, r.Scheme); err != nil {
+		return err
+	}
+
+	log.Info("instance monitoring enabled, creating alert catalogue prometheusRule")
+	return r.Client.Create(context.TODO(), promRule)
+}
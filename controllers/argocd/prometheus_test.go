@@ -3,6 +3,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
@@ -208,3 +209,76 @@ func TestReconcileWorkloadStatusAlertRule(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileAlertCatalogue(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Monitoring.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// the catalogue is not created unless enabled
+	assert.NoError(t, r.reconcileAlertCatalogue(a))
+	rule := &monitoringv1.PrometheusRule{}
+	key := types.NamespacedName{Name: a.Name + "-alerts", Namespace: a.Namespace}
+	assert.Error(t, r.Client.Get(context.TODO(), key, rule))
+
+	a.Spec.Monitoring.Alerts = &argoproj.ArgoCDAlertsSpec{Enabled: true}
+	assert.NoError(t, r.reconcileAlertCatalogue(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, rule))
+
+	names := []string{}
+	for _, g := range rule.Spec.Groups {
+		names = append(names, g.Name)
+	}
+	assert.Equal(t, []string{
+		"ArgoCDApplicationSync",
+		"ArgoCDApplicationHealth",
+		"ArgoCDControllerQueue",
+		"ArgoCDRedisMemory",
+		"ArgoCDGitRequests",
+		"ArgoCDCertificateExpiry",
+	}, names)
+
+	health := rule.Spec.Groups[1].Rules[0]
+	assert.Equal(t, "ApplicationDegradedRatioHigh", health.Alert)
+	assert.Equal(t, fmt.Sprintf("count(argocd_app_info{namespace=\"%[1]s\", job=\"%[2]s-metrics\", health_status=\"Degraded\"}) / count(argocd_app_info{namespace=\"%[1]s\", job=\"%[2]s-metrics\"}) > 0.1", a.Namespace, a.Name), health.Expr.StrVal)
+	assert.Equal(t, "10m", health.For)
+	assert.Equal(t, map[string]string{"severity": "warning"}, health.Labels)
+
+	// thresholds, severities and labels are configurable and groups can be switched on and off
+	enabled, disabled := true, false
+	a.Spec.Monitoring.Alerts = &argoproj.ArgoCDAlertsSpec{
+		Enabled:          true,
+		AdditionalLabels: map[string]string{"team": "platform"},
+		ApplicationHealth: &argoproj.ArgoCDAlertGroupSpec{
+			Threshold: "0.25",
+			Severity:  "critical",
+			For:       "30m",
+			Labels:    map[string]string{"page": "true"},
+		},
+		ManifestGeneration: &argoproj.ArgoCDAlertGroupSpec{Enabled: &enabled},
+		GitRequests:        &argoproj.ArgoCDAlertGroupSpec{Enabled: &disabled},
+		CertificateExpiry:  &argoproj.ArgoCDAlertGroupSpec{Enabled: &disabled},
+	}
+	assert.NoError(t, r.reconcileAlertCatalogue(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, rule))
+	assert.Len(t, rule.Spec.Groups, 5)
+	assert.Equal(t, "ArgoCDManifestGeneration", rule.Spec.Groups[2].Name)
+
+	health = rule.Spec.Groups[1].Rules[0]
+	assert.True(t, strings.HasSuffix(health.Expr.StrVal, "> 0.25"))
+	assert.Equal(t, "30m", health.For)
+	assert.Equal(t, map[string]string{"severity": "critical", "team": "platform", "page": "true"}, health.Labels)
+	assert.Equal(t, map[string]string{"severity": "warning", "team": "platform"}, rule.Spec.Groups[0].Rules[0].Labels)
+
+	// the catalogue is removed when monitoring is disabled
+	a.Spec.Monitoring.Enabled = false
+	assert.NoError(t, r.reconcileAlertCatalogue(a))
+	assert.Error(t, r.Client.Get(context.TODO(), key, rule))
+}
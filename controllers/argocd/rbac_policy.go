@@ -0,0 +1,342 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	rbacPolicyReasonValid    = "Valid"
+	rbacPolicyReasonInvalid  = "InvalidPolicy"
+	rbacPolicyReasonConflict = "PolicyConflict"
+)
+
+// rbacPolicySource is a named piece of RBAC policy CSV merged into the argocd-rbac-cm ConfigMap.
+type rbacPolicySource struct {
+	name   string
+	key    string
+	policy string
+}
+
+// getRBACRoleSubject returns the casbin subject of the given role name.
+func getRBACRoleSubject(role string) string {
+	if strings.HasPrefix(role, "role:") {
+		return role
+	}
+	return "role:" + role
+}
+
+// renderRBACPolicy will return the RBAC policy CSV for the typed roles and bindings of the given ArgoCD.
+func renderRBACPolicy(cr *argoproj.ArgoCD) string {
+	if len(cr.Spec.RBAC.Roles) == 0 && len(cr.Spec.RBAC.Bindings) == 0 {
+		return ""
+	}
+
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	for _, role := range cr.Spec.RBAC.Roles {
+		for _, policy := range role.Policies {
+			object := policy.Object
+			if object == "" {
+				object = "*"
+			}
+			effect := policy.Effect
+			if effect == "" {
+				effect = "allow"
+			}
+			_ = w.Write([]string{"p", getRBACRoleSubject(role.Name), policy.Resource, policy.Action, object, effect})
+		}
+	}
+	for _, binding := range cr.Spec.RBAC.Bindings {
+		for _, subject := range binding.Subjects {
+			_ = w.Write([]string{"g", subject, getRBACRoleSubject(binding.Role)})
+		}
+	}
+	w.Flush()
+	return buf.String()
+}
+
+// parseRBACPolicy splits the given RBAC policy CSV into its rules. Blank lines and comments are skipped, and an
+// error message is returned for every line that is not a valid casbin policy or grouping rule.
+func parseRBACPolicy(policy string) ([][]string, []string) {
+	rules := [][]string{}
+	errs := []string{}
+	for i, line := range strings.Split(policy, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		r := csv.NewReader(strings.NewReader(line))
+		r.TrimLeadingSpace = true
+		r.FieldsPerRecord = -1
+		fields, err := r.Read()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", i+1, err))
+			continue
+		}
+		for j := range fields {
+			fields[j] = strings.TrimSpace(fields[j])
+		}
+
+		switch fields[0] {
+		case "p":
+			if len(fields) != 5 && len(fields) != 6 {
+				errs = append(errs, fmt.Sprintf("line %d: policy rule must have the form p, subject, resource, action, object[, effect]", i+1))
+				continue
+			}
+			if len(fields) == 6 && fields[5] != "allow" && fields[5] != "deny" {
+				errs = append(errs, fmt.Sprintf("line %d: policy effect must be allow or deny", i+1))
+				continue
+			}
+		case "g":
+			if len(fields) != 3 {
+				errs = append(errs, fmt.Sprintf("line %d: grouping rule must have the form g, subject, role", i+1))
+				continue
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("line %d: unknown rule type %q", i+1, fields[0]))
+			continue
+		}
+
+		for _, f := range fields[1:] {
+			if f == "" {
+				errs = append(errs, fmt.Sprintf("line %d: rule fields must not be empty", i+1))
+				fields = nil
+				break
+			}
+		}
+		if fields != nil {
+			rules = append(rules, fields)
+		}
+	}
+	return rules, errs
+}
+
+// findRBACPolicyConflicts returns a message for every policy rule that is allowed by one source and denied by another.
+func findRBACPolicyConflicts(sources []rbacPolicySource) []string {
+	type effects struct {
+		allow string
+		deny  string
+	}
+
+	keys := []string{}
+	rules := map[string]*effects{}
+	for _, source := range sources {
+		parsed, _ := parseRBACPolicy(source.policy)
+		for _, rule := range parsed {
+			if rule[0] != "p" {
+				continue
+			}
+			key := strings.Join(rule[1:5], ", ")
+			e, ok := rules[key]
+			if !ok {
+				e = &effects{}
+				rules[key] = e
+				keys = append(keys, key)
+			}
+			if len(rule) == 6 && rule[5] == "deny" {
+				if e.deny == "" {
+					e.deny = source.name
+				}
+			} else if e.allow == "" {
+				e.allow = source.name
+			}
+		}
+	}
+
+	conflicts := []string{}
+	for _, key := range keys {
+		e := rules[key]
+		if e.allow != "" && e.deny != "" && e.allow != e.deny {
+			conflicts = append(conflicts, fmt.Sprintf("p, %s is allowed by %s and denied by %s", key, e.allow, e.deny))
+		}
+	}
+	return conflicts
+}
+
+// getRBACPolicyFragments will return the ConfigMaps selected by the policy fragment selector of the given ArgoCD,
+// sorted by name.
+func (r *ReconcileArgoCD) getRBACPolicyFragments(cr *argoproj.ArgoCD) ([]corev1.ConfigMap, error) {
+	if cr.Spec.RBAC.PolicyFragmentSelector == nil {
+		return nil, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(cr.Spec.RBAC.PolicyFragmentSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid .spec.rbac.policyFragmentSelector: %w", err)
+	}
+
+	list := &corev1.ConfigMapList{}
+	if err := r.Client.List(context.TODO(), list, client.InNamespace(cr.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list RBAC policy fragments: %w", err)
+	}
+
+	fragments := []corev1.ConfigMap{}
+	for _, cm := range list.Items {
+		if cm.Name == common.ArgoCDRBACConfigMapName {
+			continue
+		}
+		fragments = append(fragments, cm)
+	}
+	sort.Slice(fragments, func(i, j int) bool { return fragments[i].Name < fragments[j].Name })
+	return fragments, nil
+}
+
+// getDesiredRBACPolicies will return the policy keys of the argocd-rbac-cm ConfigMap rendered from the typed roles,
+// bindings and policy fragments of the given ArgoCD, along with the RBACPolicyValid condition describing them.
+// Invalid policy fragments are left out and reported in the condition.
+func (r *ReconcileArgoCD) getDesiredRBACPolicies(cr *argoproj.ArgoCD) (map[string]string, metav1.Condition, error) {
+	policies := map[string]string{}
+	problems := []string{}
+	invalid := false
+
+	sources := []rbacPolicySource{{name: ".spec.rbac.policy", key: common.ArgoCDKeyRBACPolicyCSV, policy: getRBACPolicy(cr)}}
+	if policy := renderRBACPolicy(cr); policy != "" {
+		sources = append(sources, rbacPolicySource{name: ".spec.rbac.roles", key: common.ArgoCDKeyRBACPolicySpecCSV, policy: policy})
+	}
+
+	fragments, err := r.getRBACPolicyFragments(cr)
+	if err != nil {
+		return nil, metav1.Condition{}, err
+	}
+	for _, cm := range fragments {
+		name := fmt.Sprintf("configmap %s", cm.Name)
+		key := fmt.Sprintf("policy.%s.csv", cm.Name)
+		policy, ok := cm.Data[common.ArgoCDKeyRBACPolicyCSV]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s: missing key %s", name, common.ArgoCDKeyRBACPolicyCSV))
+			invalid = true
+		case key == common.ArgoCDKeyRBACPolicySpecCSV:
+			problems = append(problems, fmt.Sprintf("%s: key %s is reserved for .spec.rbac.roles", name, key))
+			invalid = true
+		default:
+			sources = append(sources, rbacPolicySource{name: name, key: key, policy: policy})
+		}
+	}
+
+	valid := []rbacPolicySource{}
+	for _, source := range sources {
+		_, errs := parseRBACPolicy(source.policy)
+		for _, e := range errs {
+			problems = append(problems, fmt.Sprintf("%s: %s", source.name, e))
+		}
+		if len(errs) > 0 {
+			invalid = true
+			// the policy of the spec is applied as is for compatibility, invalid fragments are skipped
+			if strings.HasPrefix(source.name, "configmap ") {
+				continue
+			}
+		}
+		valid = append(valid, source)
+		if source.key != common.ArgoCDKeyRBACPolicyCSV {
+			policies[source.key] = source.policy
+		}
+	}
+
+	conflicts := findRBACPolicyConflicts(valid)
+	problems = append(problems, conflicts...)
+
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeRBACPolicyValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             rbacPolicyReasonValid,
+		Message:            "RBAC policies are valid",
+		ObservedGeneration: cr.Generation,
+	}
+	if len(problems) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = rbacPolicyReasonConflict
+		if invalid {
+			condition.Reason = rbacPolicyReasonInvalid
+		}
+		condition.Message = strings.Join(problems, "; ")
+	}
+	return policies, condition, nil
+}
+
+// setRBACPolicies will update the managed policy keys of the given RBAC ConfigMap, removing the keys of policies
+// that are no longer desired. It returns true when the ConfigMap was changed.
+func setRBACPolicies(cm *corev1.ConfigMap, policies map[string]string) bool {
+	changed := false
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+
+	if managed, ok := cm.Annotations[common.ArgoCDKeyRBACPolicyKeys]; ok {
+		for _, key := range strings.Split(managed, ",") {
+			if _, ok := policies[key]; !ok {
+				if _, ok := cm.Data[key]; ok {
+					delete(cm.Data, key)
+					changed = true
+				}
+			}
+		}
+	}
+
+	keys := []string{}
+	for key, policy := range policies {
+		keys = append(keys, key)
+		if current, ok := cm.Data[key]; !ok || current != policy {
+			cm.Data[key] = policy
+			changed = true
+		}
+	}
+	sort.Strings(keys)
+
+	managed := strings.Join(keys, ",")
+	if cm.Annotations[common.ArgoCDKeyRBACPolicyKeys] != managed {
+		if managed == "" {
+			delete(cm.Annotations, common.ArgoCDKeyRBACPolicyKeys)
+		} else {
+			if cm.Annotations == nil {
+				cm.Annotations = map[string]string{}
+			}
+			cm.Annotations[common.ArgoCDKeyRBACPolicyKeys] = managed
+		}
+		changed = true
+	}
+	return changed
+}
+
+// reconcileStatusRBACPolicy will ensure that the RBACPolicyValid condition is updated for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusRBACPolicy(cr *argoproj.ArgoCD, condition metav1.Condition) error {
+	current := meta.FindStatusCondition(cr.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason &&
+		current.Message == condition.Message && current.ObservedGeneration == condition.ObservedGeneration {
+		return nil
+	}
+
+	if condition.Status == metav1.ConditionFalse {
+		log.Info(fmt.Sprintf("RBAC policy of ArgoCD %s in namespace %s is not valid: %s", cr.Name, cr.Namespace, condition.Message))
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	return r.Client.Status().Update(context.TODO(), cr)
+}
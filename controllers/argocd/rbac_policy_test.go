@@ -0,0 +1,226 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestRBACPolicyFragment(name, policy string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{"rbac": "fragment"},
+		},
+		Data: map[string]string{common.ArgoCDKeyRBACPolicyCSV: policy},
+	}
+}
+
+func makeTestArgoCDForRBACPolicy() *argoproj.ArgoCD {
+	return makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.RBAC.Roles = []argoproj.ArgoCDRBACRole{
+			{
+				Name: "dev",
+				Policies: []argoproj.ArgoCDRBACPolicy{
+					{Resource: "applications", Action: "get", Object: "dev/*"},
+					{Resource: "applications", Action: "sync", Object: "dev/*"},
+				},
+			},
+		}
+		cr.Spec.RBAC.Bindings = []argoproj.ArgoCDRBACBinding{
+			{Role: "dev", Subjects: []string{"dev-team"}},
+			{Role: "role:admin", Subjects: []string{"ops"}},
+		}
+		cr.Spec.RBAC.PolicyFragmentSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"rbac": "fragment"},
+		}
+	})
+}
+
+func TestRenderRBACPolicy(t *testing.T) {
+	a := makeTestArgoCDForRBACPolicy()
+	assert.Equal(t, `p,role:dev,applications,get,dev/*,allow
+p,role:dev,applications,sync,dev/*,allow
+g,dev-team,role:dev
+g,ops,role:admin
+`, renderRBACPolicy(a))
+
+	assert.Equal(t, "", renderRBACPolicy(makeTestArgoCD()))
+}
+
+func TestParseRBACPolicy(t *testing.T) {
+	rules, errs := parseRBACPolicy(`# comment
+p, role:dev, applications, get, */*, allow
+
+g, dev-team, role:dev
+p, role:dev, applications, "action/apps/Deployment/restart", "*/*"
+`)
+	assert.Empty(t, errs)
+	assert.Equal(t, [][]string{
+		{"p", "role:dev", "applications", "get", "*/*", "allow"},
+		{"g", "dev-team", "role:dev"},
+		{"p", "role:dev", "applications", "action/apps/Deployment/restart", "*/*"},
+	}, rules)
+
+	_, errs = parseRBACPolicy(`p, role:dev, applications, get
+p, role:dev, applications, get, */*, maybe
+g, dev-team
+x, role:dev
+p, , applications, get, */*`)
+	assert.Equal(t, []string{
+		"line 1: policy rule must have the form p, subject, resource, action, object[, effect]",
+		"line 2: policy effect must be allow or deny",
+		"line 3: grouping rule must have the form g, subject, role",
+		`line 4: unknown rule type "x"`,
+		"line 5: rule fields must not be empty",
+	}, errs)
+}
+
+func TestGetDesiredRBACPolicies(t *testing.T) {
+	a := makeTestArgoCDForRBACPolicy()
+	teamA := makeTestRBACPolicyFragment("team-a", "p, role:dev, applications, sync, dev/*, deny\n")
+	teamB := makeTestRBACPolicyFragment("team-b", "p, role:team-b, applications\n")
+	unselected := makeTestRBACPolicyFragment("other", "g, other, role:admin\n")
+	unselected.Labels = nil
+
+	resObjs := []client.Object{a, teamA, teamB, unselected}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	policies, condition, err := r.getDesiredRBACPolicies(a)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		common.ArgoCDKeyRBACPolicySpecCSV: renderRBACPolicy(a),
+		"policy.team-a.csv":               teamA.Data[common.ArgoCDKeyRBACPolicyCSV],
+	}, policies)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, rbacPolicyReasonInvalid, condition.Reason)
+	assert.Equal(t, "configmap team-b: line 1: policy rule must have the form p, subject, resource, action, object[, effect]; "+
+		"p, role:dev, applications, sync, dev/* is allowed by .spec.rbac.roles and denied by configmap team-a", condition.Message)
+
+	// only the conflict remains once the invalid fragment is fixed
+	teamB.Data[common.ArgoCDKeyRBACPolicyCSV] = "p, role:team-b, applications, get, team-b/*, allow\n"
+	assert.NoError(t, r.Client.Update(context.TODO(), teamB))
+	policies, condition, err = r.getDesiredRBACPolicies(a)
+	assert.NoError(t, err)
+	assert.Contains(t, policies, "policy.team-b.csv")
+	assert.Equal(t, rbacPolicyReasonConflict, condition.Reason)
+
+	// a valid policy
+	assert.NoError(t, r.Client.Delete(context.TODO(), teamA))
+	_, condition, err = r.getDesiredRBACPolicies(a)
+	assert.NoError(t, err)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, rbacPolicyReasonValid, condition.Reason)
+}
+
+func TestReconcileRBAC_policyFragments(t *testing.T) {
+	a := makeTestArgoCDForRBACPolicy()
+	teamA := makeTestRBACPolicyFragment("team-a", "p, role:team-a, applications, *, team-a/*, allow\n")
+
+	resObjs := []client.Object{a, teamA}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRBAC(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, renderRBACPolicy(a), cm.Data[common.ArgoCDKeyRBACPolicySpecCSV])
+	assert.Equal(t, teamA.Data[common.ArgoCDKeyRBACPolicyCSV], cm.Data["policy.team-a.csv"])
+	assert.Equal(t, "policy.spec.csv,policy.team-a.csv", cm.Annotations[common.ArgoCDKeyRBACPolicyKeys])
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeRBACPolicyValid)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+	// removed roles and fragments are removed from the configmap, other keys are kept
+	cm.Data["policy.manual.csv"] = "g, manual, role:admin\n"
+	assert.NoError(t, r.Client.Update(context.TODO(), cm))
+	assert.NoError(t, r.Client.Delete(context.TODO(), teamA))
+	a.Spec.RBAC.Roles = nil
+	a.Spec.RBAC.Bindings = nil
+
+	assert.NoError(t, r.reconcileRBAC(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: a.Namespace}, cm))
+	assert.NotContains(t, cm.Data, common.ArgoCDKeyRBACPolicySpecCSV)
+	assert.NotContains(t, cm.Data, "policy.team-a.csv")
+	assert.Contains(t, cm.Data, "policy.manual.csv")
+	assert.NotContains(t, cm.Annotations, common.ArgoCDKeyRBACPolicyKeys)
+}
+
+func TestRBACPolicyFragmentConfigMapMapper(t *testing.T) {
+	a := makeTestArgoCDForRBACPolicy()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	requests := r.rbacPolicyFragmentConfigMapMapper(context.TODO(), makeTestRBACPolicyFragment("team-a", ""))
+	assert.Len(t, requests, 1)
+	assert.Equal(t, a.Name, requests[0].Name)
+
+	requests = r.rbacPolicyFragmentConfigMapMapper(context.TODO(), newConfigMapWithName(common.ArgoCDRBACConfigMapName, a))
+	assert.Empty(t, requests)
+
+	// configmaps that are not selected do not trigger a reconcile
+	unselected := makeTestRBACPolicyFragment("team-b", "")
+	unselected.Labels = map[string]string{"rbac": "other"}
+	requests = r.rbacPolicyFragmentConfigMapMapper(context.TODO(), unselected)
+	assert.Empty(t, requests)
+	requests = r.rbacPolicyFragmentConfigMapMapper(context.TODO(), newConfigMapWithName("argocd-gpg-keys-cm", a))
+	assert.Empty(t, requests)
+}
+
+func TestConfigMapContentPredicate(t *testing.T) {
+	fragment := makeTestRBACPolicyFragment("team-a", "p, role:dev, applications, get, */*, allow")
+	pred := configMapContentPredicate()
+
+	// metadata only changes are ignored
+	annotated := fragment.DeepCopy()
+	annotated.Annotations = map[string]string{"foo": "bar"}
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: fragment, ObjectNew: annotated}))
+
+	// deselecting and changing a fragment are not
+	deselected := fragment.DeepCopy()
+	deselected.Labels = nil
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: fragment, ObjectNew: deselected}))
+	changed := fragment.DeepCopy()
+	changed.Data[common.ArgoCDKeyRBACPolicyCSV] = ""
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: fragment, ObjectNew: changed}))
+}
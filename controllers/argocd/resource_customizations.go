@@ -0,0 +1,447 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	resourceCustomizationsReasonValid    = "Valid"
+	resourceCustomizationsReasonInvalid  = "InvalidCustomization"
+	resourceCustomizationsReasonConflict = "CustomizationConflict"
+)
+
+// resourceCustomizationSource is a resource customization merged into the argocd-cm ConfigMap.
+type resourceCustomizationSource struct {
+	name          string
+	key           string
+	value         string
+	fromConfigMap bool
+}
+
+// resourceCustomizationRef is a resource customization of the spec loaded from a ConfigMap key.
+type resourceCustomizationRef struct {
+	key string
+	ref *corev1.ConfigMapKeySelector
+}
+
+// resourceActionDefinitions holds the Lua scripts of the custom actions of a resource kind.
+type resourceActionDefinitions struct {
+	DiscoveryLua string `yaml:"discovery.lua"`
+	Definitions  []struct {
+		Name      string `yaml:"name"`
+		ActionLua string `yaml:"action.lua"`
+	} `yaml:"definitions"`
+}
+
+// getResourceCustomizationKey returns the argocd-cm key of the customization with the given prefix for the given
+// group and kind.
+func getResourceCustomizationKey(prefix, group, kind string) string {
+	if group != "" {
+		group += "_"
+	}
+	return prefix + group + kind
+}
+
+// getResourceCustomizationRefs will return the resource customizations of the given ArgoCD that are loaded from
+// ConfigMap keys.
+func getResourceCustomizationRefs(cr *argoproj.ArgoCD) []resourceCustomizationRef {
+	refs := []resourceCustomizationRef{}
+	for _, hc := range cr.Spec.ResourceHealthChecks {
+		if hc.CheckFrom != nil {
+			refs = append(refs, resourceCustomizationRef{key: getResourceCustomizationKey(common.ArgoCDKeyResourceHealthPrefix, hc.Group, hc.Kind), ref: hc.CheckFrom})
+		}
+	}
+	for _, action := range cr.Spec.ResourceActions {
+		if action.ActionFrom != nil {
+			refs = append(refs, resourceCustomizationRef{key: getResourceCustomizationKey(common.ArgoCDKeyResourceActionsPrefix, action.Group, action.Kind), ref: action.ActionFrom})
+		}
+	}
+	if ignoreDiff := cr.Spec.ResourceIgnoreDifferences; ignoreDiff != nil {
+		if ignoreDiff.AllFrom != nil {
+			refs = append(refs, resourceCustomizationRef{key: common.ArgoCDKeyResourceIgnoreDifferencesPrefix + "all", ref: ignoreDiff.AllFrom})
+		}
+		for _, id := range ignoreDiff.ResourceIdentifiers {
+			if id.CustomizationFrom != nil {
+				refs = append(refs, resourceCustomizationRef{key: getResourceCustomizationKey(common.ArgoCDKeyResourceIgnoreDifferencesPrefix, id.Group, id.Kind), ref: id.CustomizationFrom})
+			}
+		}
+	}
+	return refs
+}
+
+// getResourceCustomizationConfigMap will return the value of the ConfigMap key referenced by the given selector,
+// and whether the key was found.
+func (r *ReconcileArgoCD) getResourceCustomizationConfigMap(cr *argoproj.ArgoCD, ref *corev1.ConfigMapKeySelector) (string, bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: cr.Namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to get resource customization configmap %s: %w", ref.Name, err)
+	}
+	value, ok := cm.Data[ref.Key]
+	return value, ok, nil
+}
+
+// getResourceCustomizationConfigMaps will return the ConfigMaps selected by the resource customization selector of
+// the given ArgoCD, sorted by name.
+func (r *ReconcileArgoCD) getResourceCustomizationConfigMaps(cr *argoproj.ArgoCD) ([]corev1.ConfigMap, error) {
+	if cr.Spec.ResourceCustomizationSelector == nil {
+		return nil, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(cr.Spec.ResourceCustomizationSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid .spec.resourceCustomizationSelector: %w", err)
+	}
+
+	list := &corev1.ConfigMapList{}
+	if err := r.Client.List(context.TODO(), list, client.InNamespace(cr.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list resource customization configmaps: %w", err)
+	}
+
+	cms := []corev1.ConfigMap{}
+	for _, cm := range list.Items {
+		if cm.Name == common.ArgoCDConfigMapName {
+			continue
+		}
+		cms = append(cms, cm)
+	}
+	sort.Slice(cms, func(i, j int) bool { return cms[i].Name < cms[j].Name })
+	return cms, nil
+}
+
+// getDesiredResourceCustomizations will return the resource.customizations.* keys of the argocd-cm ConfigMap for the
+// given ArgoCD, along with the ResourceCustomizationsValid condition describing them. Customizations of the spec take
+// precedence over the ones of the selected ConfigMaps. Invalid customizations loaded from ConfigMaps are left out and
+// reported in the condition.
+func (r *ReconcileArgoCD) getDesiredResourceCustomizations(cr *argoproj.ArgoCD) (map[string]string, metav1.Condition, error) {
+	problems := []string{}
+	invalid := false
+
+	ignoreDifferences, err := getResourceIgnoreDifferences(cr)
+	if err != nil {
+		return nil, metav1.Condition{}, err
+	}
+
+	sources := []resourceCustomizationSource{}
+	for name, customizations := range map[string]map[string]string{
+		".spec.resourceHealthChecks":      getResourceHealthChecks(cr),
+		".spec.resourceActions":           getResourceActions(cr),
+		".spec.resourceIgnoreDifferences": ignoreDifferences,
+	} {
+		for key, value := range customizations {
+			sources = append(sources, resourceCustomizationSource{name: name, key: key, value: value})
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].key < sources[j].key })
+
+	for _, ref := range getResourceCustomizationRefs(cr) {
+		name := fmt.Sprintf("configmap %s key %s", ref.ref.Name, ref.ref.Key)
+		value, ok, err := r.getResourceCustomizationConfigMap(cr, ref.ref)
+		if err != nil {
+			return nil, metav1.Condition{}, err
+		}
+		if !ok {
+			if ref.ref.Optional == nil || !*ref.ref.Optional {
+				problems = append(problems, fmt.Sprintf("%s: not found", name))
+				invalid = true
+			}
+			continue
+		}
+		sources = append(sources, resourceCustomizationSource{name: name, key: ref.key, value: value, fromConfigMap: true})
+	}
+
+	cms, err := r.getResourceCustomizationConfigMaps(cr)
+	if err != nil {
+		return nil, metav1.Condition{}, err
+	}
+	for _, cm := range cms {
+		keys := []string{}
+		for key := range cm.Data {
+			if strings.HasPrefix(key, common.ArgoCDKeyResourceCustomizationsPrefix) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			sources = append(sources, resourceCustomizationSource{name: fmt.Sprintf("configmap %s", cm.Name), key: key, value: cm.Data[key], fromConfigMap: true})
+		}
+	}
+
+	customizations := map[string]string{}
+	loadedFrom := map[string]string{}
+	for _, source := range sources {
+		if err := validateResourceCustomization(source.key, source.value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s: %v", source.name, source.key, err))
+			invalid = true
+			// the customizations of the spec are applied as is for compatibility, invalid ones of configmaps are skipped
+			if source.fromConfigMap {
+				continue
+			}
+		}
+		if name, ok := loadedFrom[source.key]; ok {
+			problems = append(problems, fmt.Sprintf("%s: %s is already set by %s", source.name, source.key, name))
+			continue
+		}
+		customizations[source.key] = source.value
+		loadedFrom[source.key] = source.name
+	}
+
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeResourceCustomizationsValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             resourceCustomizationsReasonValid,
+		Message:            "Resource customizations are valid",
+		ObservedGeneration: cr.Generation,
+	}
+	if len(problems) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = resourceCustomizationsReasonConflict
+		if invalid {
+			condition.Reason = resourceCustomizationsReasonInvalid
+		}
+		condition.Message = strings.Join(problems, "; ")
+	}
+	return customizations, condition, nil
+}
+
+// validateResourceCustomization will return an error when the given value is not a valid resource customization for
+// the given argocd-cm key. The Lua scripts of health checks and actions are checked with checkLuaSyntax.
+func validateResourceCustomization(key, value string) error {
+	switch {
+	case strings.HasPrefix(key, common.ArgoCDKeyResourceHealthPrefix):
+		return checkLuaSyntax(value)
+	case strings.HasPrefix(key, common.ArgoCDKeyResourceActionsPrefix):
+		actions := resourceActionDefinitions{}
+		if err := yaml.Unmarshal([]byte(value), &actions); err != nil {
+			return fmt.Errorf("invalid action definitions: %w", err)
+		}
+		if actions.DiscoveryLua != "" {
+			if err := checkLuaSyntax(actions.DiscoveryLua); err != nil {
+				return fmt.Errorf("discovery.lua: %w", err)
+			}
+		}
+		for _, definition := range actions.Definitions {
+			if definition.ActionLua == "" {
+				return fmt.Errorf("action %s: missing action.lua", definition.Name)
+			}
+			if err := checkLuaSyntax(definition.ActionLua); err != nil {
+				return fmt.Errorf("action %s: action.lua: %w", definition.Name, err)
+			}
+		}
+	case strings.HasPrefix(key, common.ArgoCDKeyResourceIgnoreDifferencesPrefix):
+		customization := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(value), &customization); err != nil {
+			return fmt.Errorf("invalid ignore differences: %w", err)
+		}
+	}
+	return nil
+}
+
+// luaBlock is a block or bracket opened in a Lua script.
+type luaBlock struct {
+	token      string
+	line       int
+	awaitingDo bool
+}
+
+// luaClosingBrackets maps the closing brackets of Lua to their opening brackets.
+var luaClosingBrackets = map[byte]string{')': "(", ']': "[", '}': "{"}
+
+// checkLuaSyntax will return an error when the given Lua script has unfinished strings or comments, or unbalanced
+// brackets or blocks. It is a structural check of the script rather than a full parse, which is left to Argo CD.
+func checkLuaSyntax(script string) error {
+	stack := []luaBlock{}
+	line := 1
+
+	unexpected := func(token string) error {
+		if len(stack) == 0 {
+			return fmt.Errorf("line %d: unexpected %q", line, token)
+		}
+		top := stack[len(stack)-1]
+		return fmt.Errorf("line %d: unexpected %q, %q of line %d is not closed", line, token, top.token, top.line)
+	}
+
+	for i := 0; i < len(script); {
+		c := script[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == '-' && strings.HasPrefix(script[i:], "--"):
+			i += 2
+			if level := getLuaLongBracketLevel(script, i); level >= 0 {
+				end := strings.Index(script[i+level+2:], "]"+strings.Repeat("=", level)+"]")
+				if end < 0 {
+					return fmt.Errorf("line %d: unfinished long comment", line)
+				}
+				end += i + 2*level + 4
+				line += strings.Count(script[i:end], "\n")
+				i = end
+				continue
+			}
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			start := line
+			closed := false
+			for i++; i < len(script); i++ {
+				if script[i] == '\\' {
+					if i+1 < len(script) && script[i+1] == '\n' {
+						line++
+					}
+					i++
+					continue
+				}
+				if script[i] == '\n' {
+					break
+				}
+				if script[i] == c {
+					closed = true
+					i++
+					break
+				}
+			}
+			if !closed {
+				return fmt.Errorf("line %d: unfinished string", start)
+			}
+		case c == '[' && getLuaLongBracketLevel(script, i) >= 0:
+			level := getLuaLongBracketLevel(script, i)
+			end := strings.Index(script[i+level+2:], "]"+strings.Repeat("=", level)+"]")
+			if end < 0 {
+				return fmt.Errorf("line %d: unfinished long string", line)
+			}
+			end += i + 2*level + 4
+			line += strings.Count(script[i:end], "\n")
+			i = end
+		case c == '(' || c == '[' || c == '{':
+			stack = append(stack, luaBlock{token: string(c), line: line})
+			i++
+		case c == ')' || c == ']' || c == '}':
+			if len(stack) == 0 || stack[len(stack)-1].token != luaClosingBrackets[c] {
+				return unexpected(string(c))
+			}
+			stack = stack[:len(stack)-1]
+			i++
+		case isLuaNameChar(c) && !(c >= '0' && c <= '9'):
+			start := i
+			for i < len(script) && isLuaNameChar(script[i]) {
+				i++
+			}
+			word := script[start:i]
+			var top *luaBlock
+			if len(stack) > 0 {
+				top = &stack[len(stack)-1]
+			}
+			switch word {
+			case "function", "if", "repeat":
+				stack = append(stack, luaBlock{token: word, line: line})
+			case "while", "for":
+				stack = append(stack, luaBlock{token: word, line: line, awaitingDo: true})
+			case "do":
+				if top != nil && top.awaitingDo {
+					top.awaitingDo = false
+				} else {
+					stack = append(stack, luaBlock{token: word, line: line})
+				}
+			case "elseif", "else":
+				if top == nil || top.token != "if" {
+					return unexpected(word)
+				}
+			case "end":
+				if top == nil || top.awaitingDo || !strings.Contains(" function if do while for ", " "+top.token+" ") {
+					return unexpected(word)
+				}
+				stack = stack[:len(stack)-1]
+			case "until":
+				if top == nil || top.token != "repeat" {
+					return unexpected(word)
+				}
+				stack = stack[:len(stack)-1]
+			}
+		case c >= '0' && c <= '9':
+			for i < len(script) && (isLuaNameChar(script[i]) || script[i] == '.') {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		return fmt.Errorf("line %d: %q is not closed", top.line, top.token)
+	}
+	return nil
+}
+
+// getLuaLongBracketLevel returns the level of the Lua long bracket opened at the given index of the script, or -1
+// when no long bracket is opened there.
+func getLuaLongBracketLevel(script string, i int) int {
+	if i >= len(script) || script[i] != '[' {
+		return -1
+	}
+	level := 0
+	for j := i + 1; j < len(script); j++ {
+		switch script[j] {
+		case '=':
+			level++
+		case '[':
+			return level
+		default:
+			return -1
+		}
+	}
+	return -1
+}
+
+// isLuaNameChar returns true when the given character may be part of a Lua name.
+func isLuaNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// reconcileStatusResourceCustomizations will ensure that the ResourceCustomizationsValid condition is updated for
+// the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusResourceCustomizations(cr *argoproj.ArgoCD, condition metav1.Condition) error {
+	current := meta.FindStatusCondition(cr.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason &&
+		current.Message == condition.Message && current.ObservedGeneration == condition.ObservedGeneration {
+		return nil
+	}
+
+	if condition.Status == metav1.ConditionFalse {
+		log.Info(fmt.Sprintf("resource customizations of ArgoCD %s in namespace %s are not valid: %s", cr.Name, cr.Namespace, condition.Message))
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	return r.Client.Status().Update(context.TODO(), cr)
+}
@@ -0,0 +1,305 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const testHealthCheck = `hs = {}
+if obj.status ~= nil and obj.status.ready then
+  hs.status = "Healthy"
+else
+  hs.status = "Progressing"
+end
+return hs
+`
+
+func makeTestResourceCustomizationConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{"argocd.argoproj.io/resource-customizations": "true"},
+		},
+		Data: data,
+	}
+}
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_withResourceCustomizationConfigMaps(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.ResourceHealthChecks = []argoproj.ResourceHealthCheck{
+			{Kind: "Foo", Check: "return {}"},
+			{Group: "example.com", Kind: "Bar", CheckFrom: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "health"},
+				Key:                  "bar.lua",
+			}},
+		}
+		cr.Spec.ResourceActions = []argoproj.ResourceAction{
+			{Kind: "Baz", ActionFrom: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "actions"},
+				Key:                  "baz.yaml",
+				Optional:             boolPtr(true),
+			}},
+		}
+		cr.Spec.ResourceCustomizationSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"argocd.argoproj.io/resource-customizations": "true"},
+		}
+	})
+	health := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "health", Namespace: testNamespace},
+		Data:       map[string]string{"bar.lua": testHealthCheck},
+	}
+	team := makeTestResourceCustomizationConfigMap("team", map[string]string{
+		"resource.customizations.health.Foo":                    "return {status = \"Healthy\"}",
+		"resource.customizations.health.apps_Deployment":        testHealthCheck,
+		"resource.customizations.health.apps_StatefulSet":       "if obj.status then\nreturn {}\n",
+		"resource.customizations.ignoreDifferences.apps_Deploy": "jsonPointers:\n- /spec/replicas\n",
+		"README": "ignored",
+	})
+
+	resObjs := []client.Object{a, health, team}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "return {}", cm.Data["resource.customizations.health.Foo"])
+	assert.Equal(t, testHealthCheck, cm.Data["resource.customizations.health.example.com_Bar"])
+	assert.Equal(t, testHealthCheck, cm.Data["resource.customizations.health.apps_Deployment"])
+	assert.Equal(t, "jsonPointers:\n- /spec/replicas\n", cm.Data["resource.customizations.ignoreDifferences.apps_Deploy"])
+	assert.NotContains(t, cm.Data, "resource.customizations.health.apps_StatefulSet")
+	assert.NotContains(t, cm.Data, "resource.customizations.actions.Baz")
+	assert.NotContains(t, cm.Data, "README")
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeResourceCustomizationsValid)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, resourceCustomizationsReasonInvalid, condition.Reason)
+		assert.Contains(t, condition.Message, "configmap team: resource.customizations.health.Foo is already set by .spec.resourceHealthChecks")
+		assert.Contains(t, condition.Message, "configmap team: resource.customizations.health.apps_StatefulSet: line 1: \"if\" is not closed")
+	}
+
+	// the fixed health check is merged once the configmap changes
+	team.Data["resource.customizations.health.apps_StatefulSet"] = "if obj.status then\nreturn {}\nend\n"
+	delete(team.Data, "resource.customizations.health.Foo")
+	assert.NoError(t, r.Client.Update(context.TODO(), team))
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "if obj.status then\nreturn {}\nend\n", cm.Data["resource.customizations.health.apps_StatefulSet"])
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeResourceCustomizationsValid)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	}
+}
+
+func TestValidateResourceCustomization(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr string
+	}{
+		{
+			name:  "valid health check",
+			key:   "resource.customizations.health.Foo",
+			value: testHealthCheck,
+		},
+		{
+			name: "valid actions",
+			key:  "resource.customizations.actions.apps_Deployment",
+			value: `discovery.lua: |
+  actions = {}
+  actions["restart"] = {}
+  return actions
+definitions:
+- name: restart
+  action.lua: |
+    for i, c in ipairs(obj.spec.template.spec.containers) do
+      c.env = c.env or {}
+    end
+    return obj
+`,
+		},
+		{
+			name:    "action without script",
+			key:     "resource.customizations.actions.apps_Deployment",
+			value:   "definitions:\n- name: restart\n",
+			wantErr: "action restart: missing action.lua",
+		},
+		{
+			name:    "action with unbalanced script",
+			key:     "resource.customizations.actions.apps_Deployment",
+			value:   "definitions:\n- name: restart\n  action.lua: |\n    while true do\n    return obj\n",
+			wantErr: "action restart: action.lua: line 1: \"while\" is not closed",
+		},
+		{
+			name:    "invalid ignore differences",
+			key:     "resource.customizations.ignoreDifferences.all",
+			value:   "- /spec/replicas",
+			wantErr: "invalid ignore differences",
+		},
+		{
+			name:  "other customization",
+			key:   "resource.customizations.useOpenLibs.Foo",
+			value: "true",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateResourceCustomization(test.key, test.value)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckLuaSyntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+	}{
+		{name: "empty", script: ""},
+		{name: "health check", script: testHealthCheck},
+		{name: "nested functions", script: "local f = function(x)\n  return function() return x end\nend\nrepeat\n  f(1)\nuntil true"},
+		{name: "keywords in strings and comments", script: "-- end\nlocal s = \"end\" .. 'if' .. [[\nfunction\n]]\n--[==[\nwhile\n]==]\nreturn s"},
+		{name: "escaped quote", script: "return \"a \\\" end\""},
+		{name: "elseif", script: "if a then\nelseif b then\nelse\nend"},
+		{name: "unclosed if", script: "if a then\nreturn 1", wantErr: "line 1: \"if\" is not closed"},
+		{name: "unexpected end", script: "return 1\nend", wantErr: "line 2: unexpected \"end\""},
+		{name: "unclosed function in call", script: "f(function()\n)", wantErr: "line 2: unexpected \")\", \"function\" of line 1 is not closed"},
+		{name: "else outside if", script: "while a do\nelse\nend", wantErr: "line 2: unexpected \"else\", \"while\" of line 1 is not closed"},
+		{name: "unfinished string", script: "local s = \"abc\nreturn s", wantErr: "line 1: unfinished string"},
+		{name: "unfinished long comment", script: "--[[ comment", wantErr: "line 1: unfinished long comment"},
+		{name: "unbalanced brackets", script: "local t = {1, 2]", wantErr: "line 1: unexpected \"]\", \"{\" of line 1 is not closed"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkLuaSyntax(test.script)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestReconcileArgoCD_resourceCustomizationConfigMapMapper(t *testing.T) {
+	selecting := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Name = "selecting"
+		cr.Spec.ResourceCustomizationSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"argocd.argoproj.io/resource-customizations": "true"},
+		}
+	})
+	referencing := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Name = "referencing"
+		cr.Spec.ResourceHealthChecks = []argoproj.ResourceHealthCheck{{Kind: "Foo", CheckFrom: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "health"},
+			Key:                  "foo.lua",
+		}}}
+	})
+	other := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Name = "other"
+	})
+
+	resObjs := []client.Object{selecting, referencing, other}
+	subresObjs := []client.Object{}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	requests := r.resourceCustomizationConfigMapMapper(context.TODO(), makeTestResourceCustomizationConfigMap("health", nil))
+	names := []string{}
+	for _, request := range requests {
+		names = append(names, request.Name)
+	}
+	assert.ElementsMatch(t, []string{"selecting", "referencing"}, names)
+
+	requests = r.resourceCustomizationConfigMapMapper(context.TODO(), makeTestResourceCustomizationConfigMap("team", nil))
+	assert.Len(t, requests, 1)
+
+	requests = r.resourceCustomizationConfigMapMapper(context.TODO(), newConfigMapWithName(common.ArgoCDConfigMapName, selecting))
+	assert.Empty(t, requests)
+
+	// configmaps that are neither selected nor referenced do not trigger a reconcile
+	requests = r.resourceCustomizationConfigMapMapper(context.TODO(), newConfigMapWithName("argocd-gpg-keys-cm", selecting))
+	assert.Empty(t, requests)
+
+	// the referenced configmap is mapped without the labels
+	health := makeTestResourceCustomizationConfigMap("health", nil)
+	health.Labels = nil
+	requests = r.resourceCustomizationConfigMapMapper(context.TODO(), health)
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, "referencing", requests[0].Name)
+	}
+}
+
+func TestReconcileArgoCD_configMapResourceMapper(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.ResourceCustomizationSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"team": "a"},
+		}
+		cr.Spec.RBAC.PolicyFragmentSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"team": "a"},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// a configmap consumed in several ways is mapped once
+	cm := makeTestResourceCustomizationConfigMap("team-a", nil)
+	cm.Labels = map[string]string{"team": "a"}
+	requests := r.configMapResourceMapper(context.TODO(), cm)
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, a.Name, requests[0].Name)
+	}
+
+	requests = r.configMapResourceMapper(context.TODO(), newConfigMapWithName(common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName, a))
+	assert.Len(t, requests, 1)
+
+	requests = r.configMapResourceMapper(context.TODO(), newConfigMapWithName("argocd-gpg-keys-cm", a))
+	assert.Empty(t, requests)
+}
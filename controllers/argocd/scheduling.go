@@ -0,0 +1,115 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// getZoneSpreadConstraints will return the default topology spread constraints of the pods with the given name, which
+// spread the replicas of a component across zones when HA is enabled for the given ArgoCD.
+func getZoneSpreadConstraints(cr *argoproj.ArgoCD, name string) []corev1.TopologySpreadConstraint {
+	if !cr.Spec.HA.Enabled {
+		return nil
+	}
+	return []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       common.ArgoCDKeyTopologyZone,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				common.ArgoCDKeyName: name,
+			},
+		},
+	}}
+}
+
+// getDexScheduling will return the scheduling options of the Dex component for the given ArgoCD.
+func getDexScheduling(cr *argoproj.ArgoCD) *argoproj.ArgoCDSchedulingSpec {
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil {
+		return cr.Spec.SSO.Dex.Scheduling
+	}
+	return nil
+}
+
+// setPodScheduling will set the scheduling options of the given pod spec. The options of the component take
+// precedence over the instance-wide options of the given ArgoCD, which take precedence over the defaults of the
+// component: the affinity already set on the pod spec and the given topology spread constraints.
+func setPodScheduling(cr *argoproj.ArgoCD, podSpec *corev1.PodSpec, component *argoproj.ArgoCDSchedulingSpec, defaultSpread []corev1.TopologySpreadConstraint) {
+	instance := &argoproj.ArgoCDSchedulingSpec{}
+	if cr.Spec.NodePlacement != nil {
+		instance = &cr.Spec.NodePlacement.ArgoCDSchedulingSpec
+	}
+	if component == nil {
+		component = &argoproj.ArgoCDSchedulingSpec{}
+	}
+
+	switch {
+	case component.Affinity != nil:
+		podSpec.Affinity = component.Affinity
+	case instance.Affinity != nil:
+		podSpec.Affinity = instance.Affinity
+	}
+
+	switch {
+	case len(component.TopologySpreadConstraints) > 0:
+		podSpec.TopologySpreadConstraints = component.TopologySpreadConstraints
+	case len(instance.TopologySpreadConstraints) > 0:
+		podSpec.TopologySpreadConstraints = instance.TopologySpreadConstraints
+	default:
+		podSpec.TopologySpreadConstraints = defaultSpread
+	}
+
+	switch {
+	case component.PriorityClassName != "":
+		podSpec.PriorityClassName = component.PriorityClassName
+	case instance.PriorityClassName != "":
+		podSpec.PriorityClassName = instance.PriorityClassName
+	}
+
+	switch {
+	case component.RuntimeClassName != nil:
+		podSpec.RuntimeClassName = component.RuntimeClassName
+	case instance.RuntimeClassName != nil:
+		podSpec.RuntimeClassName = instance.RuntimeClassName
+	}
+}
+
+// updatePodScheduling will copy the scheduling options of the desired pod spec to the existing pod spec, and set the
+// changed flag when they differ.
+func updatePodScheduling(existing *corev1.PodSpec, desired *corev1.PodSpec, changed *bool) {
+	if !reflect.DeepEqual(existing.Affinity, desired.Affinity) {
+		existing.Affinity = desired.Affinity
+		*changed = true
+	}
+	if !reflect.DeepEqual(existing.TopologySpreadConstraints, desired.TopologySpreadConstraints) {
+		existing.TopologySpreadConstraints = desired.TopologySpreadConstraints
+		*changed = true
+	}
+	if existing.PriorityClassName != desired.PriorityClassName {
+		existing.PriorityClassName = desired.PriorityClassName
+		*changed = true
+	}
+	if !reflect.DeepEqual(existing.RuntimeClassName, desired.RuntimeClassName) {
+		existing.RuntimeClassName = desired.RuntimeClassName
+		*changed = true
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestSetPodScheduling(t *testing.T) {
+	gvisor := "gvisor"
+	nodeAffinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      "node-role.kubernetes.io/infra",
+						Operator: corev1.NodeSelectorOpExists,
+					}},
+				}},
+			},
+		},
+	}
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.NodePlacement = &argoproj.ArgoCDNodePlacementSpec{
+			ArgoCDSchedulingSpec: argoproj.ArgoCDSchedulingSpec{
+				PriorityClassName: "argocd-default",
+				RuntimeClassName:  &gvisor,
+			},
+		}
+	})
+	defaultAffinity := &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{}}
+	defaultSpread := getZoneSpreadConstraints(makeTestArgoCD(func(cr *argoproj.ArgoCD) { cr.Spec.HA.Enabled = true }), "argocd-server")
+
+	// the instance-wide options apply, the defaults are kept otherwise
+	podSpec := &corev1.PodSpec{Affinity: defaultAffinity}
+	setPodScheduling(a, podSpec, nil, defaultSpread)
+	assert.Equal(t, defaultAffinity, podSpec.Affinity)
+	assert.Equal(t, defaultSpread, podSpec.TopologySpreadConstraints)
+	assert.Equal(t, "argocd-default", podSpec.PriorityClassName)
+	assert.Equal(t, &gvisor, podSpec.RuntimeClassName)
+
+	// the options of the component take precedence
+	podSpec = &corev1.PodSpec{Affinity: defaultAffinity}
+	setPodScheduling(a, podSpec, &argoproj.ArgoCDSchedulingSpec{
+		Affinity:                  nodeAffinity,
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 2, TopologyKey: common.ArgoCDKeyHostname}},
+		PriorityClassName:         "argocd-critical",
+	}, defaultSpread)
+	assert.Equal(t, nodeAffinity, podSpec.Affinity)
+	assert.Equal(t, int32(2), podSpec.TopologySpreadConstraints[0].MaxSkew)
+	assert.Equal(t, "argocd-critical", podSpec.PriorityClassName)
+	assert.Equal(t, &gvisor, podSpec.RuntimeClassName)
+}
+
+func TestGetZoneSpreadConstraints(t *testing.T) {
+	a := makeTestArgoCD()
+	assert.Empty(t, getZoneSpreadConstraints(a, "argocd-server"))
+
+	a.Spec.HA.Enabled = true
+	spread := getZoneSpreadConstraints(a, "argocd-server")
+	assert.Len(t, spread, 1)
+	assert.Equal(t, common.ArgoCDKeyTopologyZone, spread[0].TopologyKey)
+	assert.Equal(t, corev1.ScheduleAnyway, spread[0].WhenUnsatisfiable)
+	assert.Equal(t, "argocd-server", spread[0].LabelSelector.MatchLabels[common.ArgoCDKeyName])
+}
+
+func TestReconcileArgoCD_reconcileDeployments_scheduling(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.HA.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+
+	repo := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, repo))
+	assert.Equal(t, getZoneSpreadConstraints(a, "argocd-repo-server"), repo.Spec.Template.Spec.TopologySpreadConstraints)
+	controller := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, controller))
+	assert.Equal(t, getZoneSpreadConstraints(a, "argocd-application-controller"), controller.Spec.Template.Spec.TopologySpreadConstraints)
+
+	// the scheduling options of existing workloads are updated
+	affinity := &corev1.Affinity{PodAffinity: &corev1.PodAffinity{}}
+	a.Spec.Server.Scheduling = &argoproj.ArgoCDSchedulingSpec{PriorityClassName: "argocd-critical"}
+	a.Spec.Redis.Scheduling = &argoproj.ArgoCDSchedulingSpec{Affinity: affinity}
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+
+	server := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, server))
+	assert.Equal(t, "argocd-critical", server.Spec.Template.Spec.PriorityClassName)
+	assert.Equal(t, getZoneSpreadConstraints(a, "argocd-server"), server.Spec.Template.Spec.TopologySpreadConstraints)
+	redis := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: a.Namespace}, redis))
+	assert.Equal(t, affinity, redis.Spec.Template.Spec.Affinity)
+}
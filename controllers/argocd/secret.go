@@ -15,6 +15,7 @@
 package argocd
 
 import (
+	"bytes"
 	"context"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -193,6 +194,20 @@ func (r *ReconcileArgoCD) reconcileArgoSecret(cr *argoproj.ArgoCD) error {
 		secret.Data[common.ArgoCDDexSecretKey] = []byte(*dexOIDCClientSecret)
 	}
 
+	dexConnectorSecretData, err := r.getDexConnectorSecretData(cr)
+	if err != nil {
+		return err
+	}
+	updateDexConnectorSecretData(secret, dexConnectorSecretData)
+
+	if isOIDCProvider(cr) {
+		oidcClientSecret, err := r.getOIDCClientSecret(cr)
+		if err != nil {
+			return err
+		}
+		secret.Data[common.ArgoCDOIDCSecretKey] = oidcClientSecret
+	}
+
 	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
 		return err
 	}
@@ -355,13 +370,46 @@ func (r *ReconcileArgoCD) reconcileExistingArgoSecret(cr *argoproj.ArgoCD, secre
 		}
 	}
 
-	if changed {
+	// copy the values referenced by typed dex connectors
+	dexConnectorSecretData, err := r.getDexConnectorSecretData(cr)
+	if err != nil {
+		return err
+	}
+	if updateDexConnectorSecretData(secret, dexConnectorSecretData) {
+		changed = true
+	}
+
+	// copy the client secret of the oidc SSO provider
+	oidcChanged := false
+	if isOIDCProvider(cr) {
+		oidcClientSecret, err := r.getOIDCClientSecret(cr)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(secret.Data[common.ArgoCDOIDCSecretKey], oidcClientSecret) {
+			secret.Data[common.ArgoCDOIDCSecretKey] = oidcClientSecret
+			oidcChanged = true
+		}
+	} else if _, ok := secret.Data[common.ArgoCDOIDCSecretKey]; ok {
+		delete(secret.Data, common.ArgoCDOIDCSecretKey)
+		changed = true
+	}
+
+	if changed || oidcChanged {
 		log.Info("updating argo secret")
 		if err := r.Client.Update(context.TODO(), secret); err != nil {
 			return err
 		}
 	}
 
+	if oidcChanged {
+		// Trigger rollout of API server to pick up the new client secret
+		log.Info("oidc client secret changed, triggering rollout of argocd server")
+		if err := r.triggerRollout(cr, argoproj.ArgoCDSuspendableComponentServer, newDeploymentWithSuffix("server", "server", cr), "oidc.client.secret.changed"); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -396,8 +444,13 @@ func (r *ReconcileArgoCD) reconcileClusterPermissionsSecret(cr *argoproj.ArgoCD)
 		return err
 	}
 
+	accepted, _, err := filterManagedNamespaces(cr, namespaceList.Items)
+	if err != nil {
+		return err
+	}
+
 	var namespaces []string
-	for _, namespace := range namespaceList.Items {
+	for _, namespace := range accepted {
 		namespaces = append(namespaces, namespace.Name)
 	}
 
@@ -500,21 +553,21 @@ func (r *ReconcileArgoCD) reconcileRepoServerTLSSecret(cr *argoproj.ArgoCD) erro
 
 		// Trigger rollout of API server
 		apiDepl := newDeploymentWithSuffix("server", "server", cr)
-		err = r.triggerRollout(apiDepl, "repo.tls.cert.changed")
+		err = r.triggerRollout(cr, argoproj.ArgoCDSuspendableComponentServer, apiDepl, "repo.tls.cert.changed")
 		if err != nil {
 			return err
 		}
 
 		// Trigger rollout of repository server
 		repoDepl := newDeploymentWithSuffix("repo-server", "repo-server", cr)
-		err = r.triggerRollout(repoDepl, "repo.tls.cert.changed")
+		err = r.triggerRollout(cr, argoproj.ArgoCDSuspendableComponentRepo, repoDepl, "repo.tls.cert.changed")
 		if err != nil {
 			return err
 		}
 
 		// Trigger rollout of application controller
 		controllerSts := newStatefulSetWithSuffix("application-controller", "application-controller", cr)
-		err = r.triggerRollout(controllerSts, "repo.tls.cert.changed")
+		err = r.triggerRollout(cr, argoproj.ArgoCDSuspendableComponentApplicationController, controllerSts, "repo.tls.cert.changed")
 		if err != nil {
 			return err
 		}
@@ -577,7 +630,7 @@ func (r *ReconcileArgoCD) reconcileRedisTLSSecret(cr *argoproj.ArgoCD, useTLSFor
 				return err
 			}
 			haProxyDepl := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
-			err = r.triggerRollout(haProxyDepl, "redis.tls.cert.changed")
+			err = r.triggerRollout(cr, argoproj.ArgoCDSuspendableComponentRedis, haProxyDepl, "redis.tls.cert.changed")
 			if err != nil {
 				return err
 			}
@@ -586,7 +639,8 @@ func (r *ReconcileArgoCD) reconcileRedisTLSSecret(cr *argoproj.ArgoCD, useTLSFor
 			// communicate with the existing pods (which are not using tls) to establish which is the master.
 			// So instead we delete the stateful set, which will delete all the pods.
 			redisSts := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
-			if argoutil.IsObjectFound(r.Client, redisSts.Namespace, redisSts.Name, redisSts) {
+			if !skipSuspendedComponent(cr, argoproj.ArgoCDSuspendableComponentRedis, "HA server statefulset deletion") &&
+				argoutil.IsObjectFound(r.Client, redisSts.Namespace, redisSts.Name, redisSts) {
 				err = r.Client.Delete(context.TODO(), redisSts)
 				if err != nil {
 					return err
@@ -594,7 +648,7 @@ func (r *ReconcileArgoCD) reconcileRedisTLSSecret(cr *argoproj.ArgoCD, useTLSFor
 			}
 		} else {
 			redisDepl := newDeploymentWithSuffix("redis", "redis", cr)
-			err = r.triggerRollout(redisDepl, "redis.tls.cert.changed")
+			err = r.triggerRollout(cr, argoproj.ArgoCDSuspendableComponentRedis, redisDepl, "redis.tls.cert.changed")
 			if err != nil {
 				return err
 			}
@@ -602,21 +656,21 @@ func (r *ReconcileArgoCD) reconcileRedisTLSSecret(cr *argoproj.ArgoCD, useTLSFor
 
 		// Trigger rollout of API server
 		apiDepl := newDeploymentWithSuffix("server", "server", cr)
-		err = r.triggerRollout(apiDepl, "redis.tls.cert.changed")
+		err = r.triggerRollout(cr, argoproj.ArgoCDSuspendableComponentServer, apiDepl, "redis.tls.cert.changed")
 		if err != nil {
 			return err
 		}
 
 		// Trigger rollout of repository server
 		repoDepl := newDeploymentWithSuffix("repo-server", "repo-server", cr)
-		err = r.triggerRollout(repoDepl, "redis.tls.cert.changed")
+		err = r.triggerRollout(cr, argoproj.ArgoCDSuspendableComponentRepo, repoDepl, "redis.tls.cert.changed")
 		if err != nil {
 			return err
 		}
 
 		// Trigger rollout of application controller
 		controllerSts := newStatefulSetWithSuffix("application-controller", "application-controller", cr)
-		err = r.triggerRollout(controllerSts, "redis.tls.cert.changed")
+		err = r.triggerRollout(cr, argoproj.ArgoCDSuspendableComponentApplicationController, controllerSts, "redis.tls.cert.changed")
 		if err != nil {
 			return err
 		}
@@ -627,11 +681,19 @@ func (r *ReconcileArgoCD) reconcileRedisTLSSecret(cr *argoproj.ArgoCD, useTLSFor
 
 // reconcileSecrets will reconcile all ArgoCD Secret resources.
 func (r *ReconcileArgoCD) reconcileSecrets(cr *argoproj.ArgoCD) error {
-	if err := r.reconcileClusterSecrets(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileClusterSecrets", func() error { return r.reconcileClusterSecrets(cr) }); err != nil {
+		return err
+	}
+
+	if err := reconcileStep(cr, "reconcileArgoSecret", func() error { return r.reconcileArgoSecret(cr) }); err != nil {
+		return err
+	}
+
+	if err := reconcileStep(cr, "reconcileAdminPasswordRotation", func() error { return r.reconcileAdminPasswordRotation(cr) }); err != nil {
 		return err
 	}
 
-	if err := r.reconcileArgoSecret(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileLocalUsers", func() error { return r.reconcileLocalUsers(cr) }); err != nil {
 		return err
 	}
 
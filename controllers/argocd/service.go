@@ -494,41 +494,41 @@ func (r *ReconcileArgoCD) reconcileServerService(cr *argoproj.ArgoCD) error {
 // reconcileServices will ensure that all Services are present for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileServices(cr *argoproj.ArgoCD) error {
 
-	if err := r.reconcileDexService(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileDexService", func() error { return r.reconcileDexService(cr) }); err != nil {
 		log.Error(err, "error reconciling dex service")
 	}
 
-	err := r.reconcileGrafanaService(cr)
+	err := reconcileStep(cr, "reconcileGrafanaService", func() error { return r.reconcileGrafanaService(cr) })
 	if err != nil {
 		return err
 	}
 
-	err = r.reconcileMetricsService(cr)
+	err = reconcileStep(cr, "reconcileMetricsService", func() error { return r.reconcileMetricsService(cr) })
 	if err != nil {
 		return err
 	}
 
-	err = r.reconcileRedisHAServices(cr)
+	err = reconcileStep(cr, "reconcileRedisHAServices", func() error { return r.reconcileRedisHAServices(cr) })
 	if err != nil {
 		return err
 	}
 
-	err = r.reconcileRedisService(cr)
+	err = reconcileStep(cr, "reconcileRedisService", func() error { return r.reconcileRedisService(cr) })
 	if err != nil {
 		return err
 	}
 
-	err = r.reconcileRepoService(cr)
+	err = reconcileStep(cr, "reconcileRepoService", func() error { return r.reconcileRepoService(cr) })
 	if err != nil {
 		return err
 	}
 
-	err = r.reconcileServerMetricsService(cr)
+	err = reconcileStep(cr, "reconcileServerMetricsService", func() error { return r.reconcileServerMetricsService(cr) })
 	if err != nil {
 		return err
 	}
 
-	err = r.reconcileServerService(cr)
+	err = reconcileStep(cr, "reconcileServerService", func() error { return r.reconcileServerService(cr) })
 	if err != nil {
 		return err
 	}
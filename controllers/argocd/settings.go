@@ -0,0 +1,163 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	// extraConfigOverridesReason is the reason of the ExtraConfigOverrides condition when typed settings are overridden.
+	extraConfigOverridesReason = "TypedSettingsOverridden"
+
+	// extraConfigNoOverridesReason is the reason of the ExtraConfigOverrides condition when no typed setting is overridden.
+	extraConfigNoOverridesReason = "NoOverrides"
+)
+
+// getAccountSettings will return the argocd-cm settings of the local accounts and local users of the given ArgoCD. The
+// admin account, configured by DisableAdmin, and duplicated accounts are ignored.
+func getAccountSettings(cr *argoproj.ArgoCD) map[string]string {
+	settings := map[string]string{}
+	for _, account := range cr.Spec.Accounts {
+		key := common.ArgoCDKeyAccountPrefix + account.Name
+		if account.Name == common.ArgoCDDefaultAdminUsername {
+			log.Info(fmt.Sprintf("ignoring local account %s of ArgoCD %s in namespace %s, the admin account is configured by disableAdmin", account.Name, cr.Name, cr.Namespace))
+			continue
+		}
+		if _, ok := settings[key]; ok {
+			log.Info(fmt.Sprintf("ignoring duplicated local account %s of ArgoCD %s in namespace %s", account.Name, cr.Name, cr.Namespace))
+			continue
+		}
+
+		capabilities := make([]string, 0, len(account.Capabilities))
+		for _, capability := range account.Capabilities {
+			capabilities = append(capabilities, string(capability))
+		}
+		settings[key] = strings.Join(capabilities, ",")
+		if account.Enabled != nil {
+			settings[key+common.ArgoCDKeyAccountEnabledSuffix] = strconv.FormatBool(*account.Enabled)
+		}
+	}
+
+	for _, user := range getLocalUsers(cr) {
+		key := common.ArgoCDKeyAccountPrefix + user.Name
+		capabilities := []string{}
+		for _, capability := range getLocalUserCapabilities(user) {
+			capabilities = append(capabilities, string(capability))
+		}
+		settings[key] = strings.Join(capabilities, ",")
+		if user.Enabled != nil {
+			settings[key+common.ArgoCDKeyAccountEnabledSuffix] = strconv.FormatBool(*user.Enabled)
+		}
+	}
+	return settings
+}
+
+// getExecSettings will return the argocd-cm settings of the web-based terminal of the given ArgoCD.
+func getExecSettings(cr *argoproj.ArgoCD) map[string]string {
+	settings := map[string]string{}
+	if cr.Spec.Exec == nil {
+		return settings
+	}
+	settings[common.ArgoCDKeyExecEnabled] = strconv.FormatBool(cr.Spec.Exec.Enabled)
+	if len(cr.Spec.Exec.Shells) > 0 {
+		settings[common.ArgoCDKeyExecShells] = strings.Join(cr.Spec.Exec.Shells, ",")
+	}
+	return settings
+}
+
+// getReconciliationSettings will return the argocd-cm settings of the reconciliation timeout of the given ArgoCD.
+func getReconciliationSettings(cr *argoproj.ArgoCD) map[string]string {
+	settings := map[string]string{}
+	if cr.Spec.Controller.AppSync != nil {
+		settings[common.ArgoCDKeyReconciliationTimeout] = formatReconciliationDuration(cr.Spec.Controller.AppSync)
+	}
+	return settings
+}
+
+// formatReconciliationDuration will return the given duration in whole seconds, as expected by the application
+// controller.
+func formatReconciliationDuration(d *metav1.Duration) string {
+	return strconv.FormatInt(int64(d.Seconds()), 10) + "s"
+}
+
+// getUISettings will return the argocd-cm settings customizing the UI of the given ArgoCD.
+func getUISettings(cr *argoproj.ArgoCD) map[string]string {
+	settings := map[string]string{}
+	if cr.Spec.UI != nil && cr.Spec.UI.CSSURL != "" {
+		settings[common.ArgoCDKeyUICSSURL] = cr.Spec.UI.CSSURL
+	}
+	if cr.Spec.Banner != nil && cr.Spec.Banner.Content != "" {
+		if cr.Spec.Banner.Permanent {
+			settings[common.ArgoCDKeyBannerPermanent] = "true"
+		}
+		if cr.Spec.Banner.Position != "" {
+			settings[common.ArgoCDKeyBannerPosition] = cr.Spec.Banner.Position
+		}
+	}
+	return settings
+}
+
+// getExtraConfigOverrides will return the sorted keys of the given ExtraConfig overriding a setting of argocd-cm
+// rendered from the typed fields with another value.
+func getExtraConfigOverrides(data map[string]string, extraConfig map[string]string) []string {
+	var overrides []string
+	for k, v := range extraConfig {
+		if typed, ok := data[k]; ok && typed != v {
+			overrides = append(overrides, k)
+		}
+	}
+	sort.Strings(overrides)
+	return overrides
+}
+
+// reconcileStatusExtraConfigOverrides will ensure that the ExtraConfigOverrides condition reports the given keys of
+// ExtraConfig overriding typed settings for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusExtraConfigOverrides(cr *argoproj.ArgoCD, overrides []string) error {
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeExtraConfigOverrides,
+		Status:             metav1.ConditionFalse,
+		Reason:             extraConfigNoOverridesReason,
+		Message:            "ExtraConfig does not override any typed setting",
+		ObservedGeneration: cr.Generation,
+	}
+	if len(overrides) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = extraConfigOverridesReason
+		condition.Message = fmt.Sprintf("ExtraConfig overrides the typed settings %s", strings.Join(overrides, ", "))
+	}
+
+	current := meta.FindStatusCondition(cr.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason &&
+		current.Message == condition.Message && current.ObservedGeneration == condition.ObservedGeneration {
+		return nil
+	}
+
+	if condition.Status == metav1.ConditionTrue {
+		log.Info(fmt.Sprintf("ArgoCD %s in namespace %s: %s", cr.Name, cr.Namespace, condition.Message))
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	return r.Client.Status().Update(context.TODO(), cr)
+}
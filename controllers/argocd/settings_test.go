@@ -0,0 +1,131 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_typedSettings(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Accounts = []argoproj.ArgoCDAccount{
+			{Name: "alice", Capabilities: []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityLogin}},
+			{Name: "ci", Capabilities: []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityAPIKey}, Enabled: boolPtr(false)},
+			{Name: "admin", Capabilities: []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityLogin}},
+		}
+		cr.Spec.Exec = &argoproj.ArgoCDExecSpec{Enabled: true, Shells: []string{"bash", "sh"}}
+		cr.Spec.Controller.AppSync = &metav1.Duration{Duration: 5 * time.Minute}
+		cr.Spec.Controller.AppSyncJitter = &metav1.Duration{Duration: time.Minute}
+		cr.Spec.UI = &argoproj.ArgoCDUISpec{CSSURL: "./custom/style.css"}
+		cr.Spec.Banner = &argoproj.Banner{Content: "maintenance tonight", Permanent: true, Position: "bottom"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+
+	assert.Equal(t, "login", cm.Data["accounts.alice"])
+	assert.NotContains(t, cm.Data, "accounts.alice.enabled")
+	assert.Equal(t, "apiKey", cm.Data["accounts.ci"])
+	assert.Equal(t, "false", cm.Data["accounts.ci.enabled"])
+	assert.NotContains(t, cm.Data, "accounts.admin")
+	assert.Equal(t, "true", cm.Data[common.ArgoCDKeyExecEnabled])
+	assert.Equal(t, "bash,sh", cm.Data[common.ArgoCDKeyExecShells])
+	assert.Equal(t, "300s", cm.Data[common.ArgoCDKeyReconciliationTimeout])
+	assert.NotContains(t, cm.Data, "timeout.reconciliation.jitter")
+	assert.Equal(t, "./custom/style.css", cm.Data[common.ArgoCDKeyUICSSURL])
+	assert.Equal(t, "true", cm.Data[common.ArgoCDKeyBannerPermanent])
+	assert.Equal(t, "bottom", cm.Data[common.ArgoCDKeyBannerPosition])
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeExtraConfigOverrides)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	}
+}
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_extraConfigOverrides(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Exec = &argoproj.ArgoCDExecSpec{Enabled: true}
+		cr.Spec.ExtraConfig = map[string]string{
+			common.ArgoCDKeyExecEnabled:  "false",
+			common.ArgoCDKeyAdminEnabled: "true",
+			"foo":                        "bar",
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// ExtraConfig takes precedence, only the keys with another value than the typed settings are reported
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "false", cm.Data[common.ArgoCDKeyExecEnabled])
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeExtraConfigOverrides)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, "ExtraConfig overrides the typed settings exec.enabled", condition.Message)
+	}
+
+	// the condition is cleared once the override is removed
+	delete(a.Spec.ExtraConfig, common.ArgoCDKeyExecEnabled)
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeExtraConfigOverrides)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	}
+}
+
+func TestAppendServerExecPolicyRule(t *testing.T) {
+	a := makeTestArgoCD()
+	rules := policyRuleForServer()
+	assert.Equal(t, rules, appendServerExecPolicyRule(policyRuleForServer(), a))
+
+	a.Spec.Exec = &argoproj.ArgoCDExecSpec{Enabled: true}
+	execRules := appendServerExecPolicyRule(policyRuleForServer(), a)
+	assert.Len(t, execRules, len(rules)+1)
+	assert.Equal(t, v1.PolicyRule{
+		APIGroups: []string{""},
+		Resources: []string{"pods/exec"},
+		Verbs:     []string{"create"},
+	}, execRules[len(execRules)-1])
+}
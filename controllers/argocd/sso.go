@@ -86,7 +86,7 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 		if cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeDex {
 			// Relevant SSO settings at play are `.spec.sso.dex` fields, `.spec.sso.keycloak`
 
-			if cr.Spec.SSO.Dex == nil || (cr.Spec.SSO.Dex != nil && !cr.Spec.SSO.Dex.OpenShiftOAuth && cr.Spec.SSO.Dex.Config == "") {
+			if cr.Spec.SSO.Dex == nil || (cr.Spec.SSO.Dex != nil && !cr.Spec.SSO.Dex.OpenShiftOAuth && cr.Spec.SSO.Dex.Config == "" && len(cr.Spec.SSO.Dex.Connectors) == 0) {
 				// sso provider specified as dex but no dexconfig supplied. This will cause health probe to fail as per
 				// https://github.com/argoproj-labs/argocd-operator/pull/615 ==> conflict
 				errMsg = "must supply valid dex configuration when requested SSO provider is dex"
@@ -95,6 +95,14 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 				// new keycloak spec fields are expressed when `.spec.sso.provider` is set to dex ==> conflict
 				errMsg = "cannot supply keycloak configuration in .spec.sso.keycloak when requested SSO provider is dex"
 				isError = true
+			} else if cr.Spec.SSO.OIDC != nil {
+				// oidc spec fields are expressed when `.spec.sso.provider` is set to dex ==> conflict
+				errMsg = "cannot supply oidc configuration in .spec.sso.oidc when requested SSO provider is dex"
+				isError = true
+			} else if msg := validateDexConnectors(cr); msg != "" {
+				// typed dex connectors are incomplete or conflicting ==> conflict
+				errMsg = msg
+				isError = true
 			}
 
 			if isError {
@@ -115,6 +123,16 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 				errMsg = "cannot supply dex configuration when requested SSO provider is keycloak"
 				err = errors.New(illegalSSOConfiguration + errMsg)
 				isError = true
+			} else if cr.Spec.SSO.OIDC != nil {
+				// oidc spec fields are expressed when `.spec.sso.provider` is set to keycloak ==> conflict
+				errMsg = "cannot supply oidc configuration when requested SSO provider is keycloak"
+				err = errors.New(illegalSSOConfiguration + errMsg)
+				isError = true
+			} else if isKeycloakExternalMode(cr) && validateKeycloakExternalSpec(cr) != "" {
+				// external keycloak mode without a complete description of the existing realm ==> conflict
+				errMsg = validateKeycloakExternalSpec(cr)
+				err = errors.New(illegalSSOConfiguration + errMsg)
+				isError = true
 			}
 
 			if isError {
@@ -126,7 +144,7 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 
 			// DeploymentConfig API is being deprecated with OpenShift 4.14. Users who wish to
 			// install Keycloak using Template should enable the DeploymentConfig API.
-			if templateAPIFound && !deploymentConfigAPIFound {
+			if templateAPIFound && !deploymentConfigAPIFound && !isKeycloakQuarkusMode(cr) && !isKeycloakExternalMode(cr) {
 				ssoConfigLegalStatus = ssoLegalFailed
 				if err := r.reconcileStatusSSO(cr); err != nil {
 					return err
@@ -136,12 +154,37 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 		}
 
 		// case 4
+		if cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeOIDC {
+			// Relevant SSO settings at play are `.spec.sso.oidc` fields, `.spec.sso.dex`, `.spec.sso.keycloak`
+
+			if cr.Spec.SSO.Dex != nil || cr.Spec.SSO.Keycloak != nil {
+				// dex or keycloak spec fields are expressed when `.spec.sso.provider` is set to oidc ==> conflict
+				errMsg = "cannot supply dex or keycloak configuration when requested SSO provider is oidc"
+				isError = true
+			} else if msg := validateOIDCSpec(cr); msg != "" {
+				// incomplete oidc provider or conflicting `.spec.oidcConfig` ==> conflict
+				errMsg = msg
+				isError = true
+			}
+
+			if isError {
+				err = errors.New(illegalSSOConfiguration + errMsg)
+				log.Error(err, fmt.Sprintf("Illegal expression of SSO configuration detected for Argo CD %s in namespace %s. %s", cr.Name, cr.Namespace, errMsg))
+				ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
+				_ = r.reconcileStatusSSO(cr)
+				return err
+			}
+		}
+
+		// case 5
 		if cr.Spec.SSO.Provider.ToLower() == "" {
 
 			if cr.Spec.SSO.Dex != nil ||
 				// `.spec.sso.dex` expressed without specifying SSO provider ==> conflict
-				cr.Spec.SSO.Keycloak != nil {
+				cr.Spec.SSO.Keycloak != nil ||
 				// `.spec.sso.keycloak` expressed without specifying SSO provider ==> conflict
+				cr.Spec.SSO.OIDC != nil {
+				// `.spec.sso.oidc` expressed without specifying SSO provider ==> conflict
 
 				errMsg = "Cannot specify SSO provider spec without specifying SSO provider type"
 				err = errors.New(illegalSSOConfiguration + errMsg)
@@ -152,11 +195,12 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 			}
 		}
 
-		// case 5
-		if cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeDex && cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeKeycloak {
+		// case 6
+		if cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeDex && cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeKeycloak &&
+			cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeOIDC {
 			// `.spec.sso.provider` contains unsupported value
 
-			errMsg = fmt.Sprintf("Unsupported SSO provider type. Supported providers are %s and %s", argoproj.SSOProviderTypeDex, argoproj.SSOProviderTypeKeycloak)
+			errMsg = fmt.Sprintf("Unsupported SSO provider type. Supported providers are %s, %s and %s", argoproj.SSOProviderTypeDex, argoproj.SSOProviderTypeKeycloak, argoproj.SSOProviderTypeOIDC)
 			err = errors.New(illegalSSOConfiguration + errMsg)
 			log.Error(err, fmt.Sprintf("Unsupported SSO provider type for Argo CD %s in namespace %s.", cr.Name, cr.Namespace))
 			ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
@@ -185,7 +229,7 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 	} else if UseDex(cr) {
 		// dex
 		// Delete any lingering keycloak artifacts before Dex is configured as this is not handled by the reconcilliation loop
-		if err := deleteKeycloakConfiguration(cr); err != nil && !apiErrors.IsNotFound(err) {
+		if err := r.removeKeycloakConfiguration(cr); err != nil && !apiErrors.IsNotFound(err) {
 			log.Error(err, "Unable to delete existing SSO configuration before configuring Dex")
 			return err
 		}
@@ -193,6 +237,18 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 		if err := r.reconcileDexResources(cr); err != nil {
 			return err
 		}
+	} else if isOIDCProvider(cr) {
+		// oidc
+		// No SSO component is installed for an external OIDC provider, delete any lingering keycloak and dex artifacts
+		if err := r.removeKeycloakConfiguration(cr); err != nil && !apiErrors.IsNotFound(err) {
+			log.Error(err, "Unable to delete existing keycloak configuration before configuring OIDC")
+			return err
+		}
+
+		if err := r.reconcileDexResources(cr); err != nil && !apiErrors.IsNotFound(err) {
+			log.Error(err, "Unable to delete existing dex resources before configuring OIDC")
+			return err
+		}
 	}
 
 	_ = r.reconcileStatusSSO(cr)
@@ -205,7 +261,8 @@ func (r *ReconcileArgoCD) deleteSSOConfiguration(newCr *argoproj.ArgoCD, oldCr *
 	log.Info("uninstalling existing SSO configuration")
 
 	if oldCr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak {
-		if err := deleteKeycloakConfiguration(newCr); err != nil {
+		// the previous spec tells how keycloak was installed
+		if err := r.removeKeycloakConfiguration(oldCr); err != nil {
 			log.Error(err, "Unable to delete existing keycloak configuration")
 			return err
 		}
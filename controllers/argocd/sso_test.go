@@ -207,7 +207,7 @@ func TestReconcile_illegalSSOConfiguration(t *testing.T) {
 				}
 			}),
 			wantErr:                  true,
-			Err:                      errors.New("illegal SSO configuration: Unsupported SSO provider type. Supported providers are dex and keycloak"),
+			Err:                      errors.New("illegal SSO configuration: Unsupported SSO provider type. Supported providers are dex, keycloak and oidc"),
 			wantSSOConfigLegalStatus: "Failed",
 		},
 	}
@@ -25,7 +25,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
@@ -92,6 +91,9 @@ func newStatefulSetWithSuffix(suffix string, component string, cr *argoproj.Argo
 }
 
 func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
+	if skipSuspendedComponent(cr, argoproj.ArgoCDSuspendableComponentRedis, "HA statefulset") {
+		return nil
+	}
 	ss := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
 
 	redisEnv := append(proxyEnvVars(), corev1.EnvVar{
@@ -419,9 +421,16 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		Type: appsv1.RollingUpdateStatefulSetStrategyType,
 	}
 
+	setPodScheduling(cr, &ss.Spec.Template.Spec, cr.Spec.Redis.Scheduling, nil)
+	setPodImagePullSecrets(cr, &ss.Spec.Template.Spec)
+
 	if err := applyReconcilerHook(cr, ss, ""); err != nil {
 		return err
 	}
+	rendered := ss.DeepCopy()
+	if err := applyResourcePatches(ss, cr.Spec.HA.RedisPatches); err != nil {
+		return err
+	}
 
 	existing := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
@@ -430,12 +439,12 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 			return r.Client.Delete(context.TODO(), existing)
 		}
 
-		desiredImage := getRedisHAContainerImage(cr)
 		changed := false
 		updateNodePlacementStateful(existing, ss, &changed)
 		for i, container := range existing.Spec.Template.Spec.Containers {
+			desiredImage := ss.Spec.Template.Spec.Containers[i].Image
 			if container.Image != desiredImage {
-				existing.Spec.Template.Spec.Containers[i].Image = getRedisHAContainerImage(cr)
+				existing.Spec.Template.Spec.Containers[i].Image = desiredImage
 				existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
 				changed = true
 			}
@@ -461,6 +470,10 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 			changed = true
 		}
 
+		if err := updatePatchedResource(existing, rendered, cr.Spec.HA.RedisPatches, &changed); err != nil {
+			return err
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -522,6 +535,13 @@ func getArgoControllerContainerEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
 		})
 	}
 
+	if cr.Spec.Controller.AppSyncJitter != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "ARGOCD_RECONCILIATION_JITTER",
+			Value: formatReconciliationDuration(cr.Spec.Controller.AppSyncJitter),
+		})
+	}
+
 	return env
 }
 
@@ -576,6 +596,9 @@ func (r *ReconcileArgoCD) getApplicationControllerReplicaCount(cr *argoproj.Argo
 }
 
 func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
+	if skipSuspendedComponent(cr, argoproj.ArgoCDSuspendableComponentApplicationController, "statefulset") {
+		return nil
+	}
 
 	replicas := r.getApplicationControllerReplicaCount(cr)
 
@@ -586,6 +609,11 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 	controllerEnv = argoutil.EnvMerge(controllerEnv, getArgoControllerContainerEnv(cr), true)
 	// Let user specify their own environment first
 	controllerEnv = argoutil.EnvMerge(controllerEnv, proxyEnvVars(), false)
+	controllerEnv = argoutil.EnvMerge(controllerEnv, getTracingEnv(cr, "ARGOCD_APPLICATION_CONTROLLER_OTLP_HEADERS"), false)
+	selectedSourceNamespaces, err := r.getSelectedSourceNamespaces(cr)
+	if err != nil {
+		return err
+	}
 
 	if cr.Spec.Controller.InitContainers != nil {
 		ss.Spec.Template.Spec.InitContainers = append(ss.Spec.Template.Spec.InitContainers, cr.Spec.Controller.InitContainers...)
@@ -606,9 +634,14 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 		controllerVolumeMounts = append(controllerVolumeMounts, cr.Spec.Controller.VolumeMounts...)
 	}
 
+	controllerCommand := appendSelectedSourceNamespaces(getArgoApplicationControllerCommand(cr, useTLSForRedis), selectedSourceNamespaces)
+	if isRepoServerTLSVerificationRequested(cr) {
+		controllerCommand = append(controllerCommand, "--repo-server-strict-tls")
+	}
+
 	podSpec := &ss.Spec.Template.Spec
 	podSpec.Containers = []corev1.Container{{
-		Command:         getArgoApplicationControllerCommand(cr, useTLSForRedis),
+		Command:         controllerCommand,
 		Image:           getArgoContainerImage(cr),
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "argocd-application-controller",
@@ -714,7 +747,7 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 			Command:         getArgoImportCommand(r.Client, cr),
 			Env:             proxyEnvVars(getArgoImportContainerEnv(export)...),
 			Resources:       getArgoApplicationControllerResources(cr),
-			Image:           getArgoImportContainerImage(export),
+			Image:           getArgoImportContainerImage(cr, export),
 			ImagePullPolicy: corev1.PullAlways,
 			Name:            "argocd-import",
 			SecurityContext: &corev1.SecurityContext{
@@ -752,6 +785,13 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 		}
 	}
 
+	setPodScheduling(cr, &ss.Spec.Template.Spec, cr.Spec.Controller.Scheduling, getZoneSpreadConstraints(cr, ss.Name))
+	setPodImagePullSecrets(cr, &ss.Spec.Template.Spec)
+	rendered := ss.DeepCopy()
+	if err := applyResourcePatches(ss, cr.Spec.Controller.Patches); err != nil {
+		return err
+	}
+
 	existing := newStatefulSetWithSuffix("application-controller", "application-controller", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 		if !cr.Spec.Controller.IsEnabled() {
@@ -760,17 +800,14 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 			return r.Client.Delete(context.TODO(), existing)
 		}
 		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getArgoContainerImage(cr)
+		desiredImage := ss.Spec.Template.Spec.Containers[0].Image
 		changed := false
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
 			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
 			changed = true
 		}
-		desiredCommand := getArgoApplicationControllerCommand(cr, useTLSForRedis)
-		if isRepoServerTLSVerificationRequested(cr) {
-			desiredCommand = append(desiredCommand, "--repo-server-strict-tls")
-		}
+		desiredCommand := ss.Spec.Template.Spec.Containers[0].Command
 		updateNodePlacementStateful(existing, ss, &changed)
 		if !reflect.DeepEqual(desiredCommand, existing.Spec.Template.Spec.Containers[0].Command) {
 			existing.Spec.Template.Spec.Containers[0].Command = desiredCommand
@@ -827,6 +864,10 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 			existing.Spec.Template.Labels = ss.Spec.Template.Labels
 			changed = true
 		}
+		if err := updatePatchedResource(existing, rendered, cr.Spec.Controller.Patches, &changed); err != nil {
+			return err
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -855,10 +896,10 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 
 // reconcileStatefulSets will ensure that all StatefulSets are present for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatefulSets(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
-	if err := r.reconcileApplicationControllerStatefulSet(cr, useTLSForRedis); err != nil {
+	if err := reconcileStep(cr, "reconcileApplicationControllerStatefulSet", func() error { return r.reconcileApplicationControllerStatefulSet(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
-	if err := r.reconcileRedisStatefulSet(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileRedisStatefulSet", func() error { return r.reconcileRedisStatefulSet(cr) }); err != nil {
 		return err
 	}
 	return nil
@@ -875,7 +916,7 @@ func (r *ReconcileArgoCD) triggerStatefulSetRollout(sts *appsv1.StatefulSet, key
 	return r.Client.Update(context.TODO(), sts)
 }
 
-// to update nodeSelector and tolerations in reconciler
+// to update nodeSelector, tolerations, the scheduling options and the image pull secrets in reconciler
 func updateNodePlacementStateful(existing *appsv1.StatefulSet, ss *appsv1.StatefulSet, changed *bool) {
 	if !reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, ss.Spec.Template.Spec.NodeSelector) {
 		existing.Spec.Template.Spec.NodeSelector = ss.Spec.Template.Spec.NodeSelector
@@ -885,26 +926,22 @@ func updateNodePlacementStateful(existing *appsv1.StatefulSet, ss *appsv1.Statef
 		existing.Spec.Template.Spec.Tolerations = ss.Spec.Template.Spec.Tolerations
 		*changed = true
 	}
+	updatePodScheduling(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, changed)
+	updatePodImagePullSecrets(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, changed)
 }
 
-// Returns true if a StatefulSet has pods in ErrImagePull or ImagePullBackoff state.
+// Returns true if the application controller StatefulSet has pods in ErrImagePull or ImagePullBackoff state.
 // These pods cannot be restarted automatially due to known kubernetes issue https://github.com/kubernetes/kubernetes/issues/67250
 func containsInvalidImage(cr *argoproj.ArgoCD, r *ReconcileArgoCD) bool {
-
-	brokenPod := false
-
-	podList := &corev1.PodList{}
-	listOption := client.MatchingLabels{common.ArgoCDKeyName: fmt.Sprintf("%s-%s", cr.Name, "application-controller")}
-
-	if err := r.Client.List(context.TODO(), podList, listOption); err != nil {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{common.ArgoCDKeyName: fmt.Sprintf("%s-%s", cr.Name, "application-controller")}}
+	pods, err := r.listWorkloadPods(cr.Namespace, selector)
+	if err != nil {
 		log.Error(err, "Failed to list Pods")
 	}
-	if len(podList.Items) > 0 {
-		if len(podList.Items[0].Status.ContainerStatuses) > 0 {
-			if podList.Items[0].Status.ContainerStatuses[0].State.Waiting != nil && (podList.Items[0].Status.ContainerStatuses[0].State.Waiting.Reason == "ImagePullBackOff" || podList.Items[0].Status.ContainerStatuses[0].State.Waiting.Reason == "ErrImagePull") {
-				brokenPod = true
-			}
+	for i := range pods {
+		if reason, _ := getPodIssue(&pods[i], time.Now()); reason == podHealthReasonErrImagePull || reason == podHealthReasonImagePullBackOff {
+			return true
 		}
 	}
-	return brokenPod
+	return false
 }
@@ -455,6 +455,7 @@ func TestReconcileArgoCD_reconcileApplicationController_withSharding(t *testing.
 func TestReconcileArgoCD_reconcileApplicationController_withAppSync(t *testing.T) {
 
 	expectedEnv := []corev1.EnvVar{
+		{Name: "ARGOCD_RECONCILIATION_JITTER", Value: "60s"},
 		{Name: "ARGOCD_RECONCILIATION_TIMEOUT", Value: "600s"},
 		{Name: "HOME", Value: "/home/argocd"},
 		{Name: "REDIS_PASSWORD", Value: "",
@@ -470,6 +471,7 @@ func TestReconcileArgoCD_reconcileApplicationController_withAppSync(t *testing.T
 
 	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
 		a.Spec.Controller.AppSync = &metav1.Duration{Duration: time.Minute * 10}
+		a.Spec.Controller.AppSyncJitter = &metav1.Duration{Duration: time.Minute}
 	})
 
 	resObjs := []client.Object{a}
@@ -16,6 +16,7 @@ package argocd
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"strings"
 
@@ -34,6 +35,10 @@ import (
 
 // reconcileStatus will ensure that all of the Status properties are updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatus(cr *argoproj.ArgoCD) error {
+	if err := r.reconcileStatusUnhealthyWorkloads(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileStatusApplicationController(cr); err != nil {
 		return err
 	}
@@ -42,6 +47,10 @@ func (r *ReconcileArgoCD) reconcileStatus(cr *argoproj.ArgoCD) error {
 		log.Info(err.Error())
 	}
 
+	if err := r.reconcileStatusAdminBootstrap(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileStatusPhase(cr); err != nil {
 		return err
 	}
@@ -88,6 +97,8 @@ func (r *ReconcileArgoCD) reconcileStatusApplicationController(cr *argoproj.Argo
 		}
 	}
 
+	status = getWorkloadStatus(cr, status, ss.Name)
+
 	if cr.Status.ApplicationController != status {
 		cr.Status.ApplicationController = status
 		return r.Client.Status().Update(context.TODO(), cr)
@@ -118,6 +129,8 @@ func (r *ReconcileArgoCD) reconcileStatusDex(cr *argoproj.ArgoCD) error {
 		}
 	}
 
+	status = getWorkloadStatus(cr, status, deploy.Name)
+
 	if cr.Status.SSO != status {
 		cr.Status.SSO = status
 		return r.Client.Status().Update(context.TODO(), cr)
@@ -130,7 +143,10 @@ func (r *ReconcileArgoCD) reconcileStatusDex(cr *argoproj.ArgoCD) error {
 func (r *ReconcileArgoCD) reconcileStatusKeycloak(cr *argoproj.ArgoCD) error {
 	status := "Unknown"
 
-	if CanUseKeycloakWithTemplate() {
+	if isKeycloakExternalMode(cr) {
+		// nothing is installed, the external realm is checked instead.
+		status = r.getKeycloakExternalStatus(cr)
+	} else if CanUseKeycloakWithTemplate() && !isKeycloakQuarkusMode(cr) {
 		// keycloak is installed using OpenShift templates.
 		dc := &oappsv1.DeploymentConfig{
 			ObjectMeta: metav1.ObjectMeta{
@@ -155,6 +171,7 @@ func (r *ReconcileArgoCD) reconcileStatusKeycloak(cr *argoproj.ArgoCD) error {
 		}
 
 	} else {
+		// keycloak is installed using a Deployment, which is always the case in quarkus mode.
 		d := newDeploymentWithName(defaultKeycloakIdentifier, defaultKeycloakIdentifier, cr)
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, d.Name, d) {
 			status = "Pending"
@@ -187,11 +204,18 @@ func (r *ReconcileArgoCD) reconcileStatusKeycloak(cr *argoproj.ArgoCD) error {
 // reconcileStatusApplicationSetController will ensure that the ApplicationSet controller status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusApplicationSetController(cr *argoproj.ArgoCD) error {
 	status := "Unknown"
+	replicas := ""
 
 	deploy := newDeploymentWithSuffix("applicationset-controller", "controller", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
 		status = "Pending"
 
+		desiredReplicas := int32(1)
+		if deploy.Spec.Replicas != nil {
+			desiredReplicas = *deploy.Spec.Replicas
+		}
+		replicas = fmt.Sprintf("%d/%d", deploy.Status.ReadyReplicas, desiredReplicas)
+
 		if deploy.Spec.Replicas != nil {
 			if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
 				status = "Running"
@@ -207,8 +231,11 @@ func (r *ReconcileArgoCD) reconcileStatusApplicationSetController(cr *argoproj.A
 		}
 	}
 
-	if cr.Status.ApplicationSetController != status {
+	status = getWorkloadStatus(cr, status, deploy.Name)
+
+	if cr.Status.ApplicationSetController != status || cr.Status.ApplicationSetControllerReplicas != replicas {
 		cr.Status.ApplicationSetController = status
+		cr.Status.ApplicationSetControllerReplicas = replicas
 		return r.Client.Status().Update(context.TODO(), cr)
 	}
 	return nil
@@ -226,6 +253,10 @@ func (r *ReconcileArgoCD) reconcileStatusSSO(cr *argoproj.ArgoCD) error {
 			return r.reconcileStatusDex(cr)
 		} else if cr.Spec.SSO != nil && cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak {
 			return r.reconcileStatusKeycloak(cr)
+		} else if isOIDCProvider(cr) && cr.Status.SSO != status {
+			// no SSO component is running for an external OIDC provider
+			cr.Status.SSO = status
+			return r.Client.Status().Update(context.TODO(), cr)
 		}
 	} else {
 		// illegal/unknown sso configurations
@@ -262,8 +293,10 @@ func (r *ReconcileArgoCD) reconcileStatusPhase(cr *argoproj.ArgoCD) error {
 func (r *ReconcileArgoCD) reconcileStatusRedis(cr *argoproj.ArgoCD) error {
 	status := "Unknown"
 
+	workloads := []string{}
 	if !cr.Spec.HA.Enabled {
 		deploy := newDeploymentWithSuffix("redis", "redis", cr)
+		workloads = append(workloads, deploy.Name)
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
 			status = "Pending"
 
@@ -283,6 +316,7 @@ func (r *ReconcileArgoCD) reconcileStatusRedis(cr *argoproj.ArgoCD) error {
 		}
 	} else {
 		ss := newStatefulSetWithSuffix("redis-ha-server", "redis-ha-server", cr)
+		workloads = append(workloads, ss.Name, nameWithSuffix("redis-ha-haproxy", cr))
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, ss.Name, ss) {
 			status = "Pending"
 
@@ -293,6 +327,8 @@ func (r *ReconcileArgoCD) reconcileStatusRedis(cr *argoproj.ArgoCD) error {
 		// TODO: Add check for HA proxy deployment here as well?
 	}
 
+	status = getWorkloadStatus(cr, status, workloads...)
+
 	if cr.Status.Redis != status {
 		cr.Status.Redis = status
 		return r.Client.Status().Update(context.TODO(), cr)
@@ -323,6 +359,8 @@ func (r *ReconcileArgoCD) reconcileStatusRepo(cr *argoproj.ArgoCD) error {
 		}
 	}
 
+	status = getWorkloadStatus(cr, status, deploy.Name)
+
 	if cr.Status.Repo != status {
 		cr.Status.Repo = status
 		return r.Client.Status().Update(context.TODO(), cr)
@@ -354,6 +392,8 @@ func (r *ReconcileArgoCD) reconcileStatusServer(cr *argoproj.ArgoCD) error {
 		}
 	}
 
+	status = getWorkloadStatus(cr, status, deploy.Name)
+
 	if cr.Status.Server != status {
 		cr.Status.Server = status
 		return r.Client.Status().Update(context.TODO(), cr)
@@ -384,6 +424,8 @@ func (r *ReconcileArgoCD) reconcileStatusNotifications(cr *argoproj.ArgoCD) erro
 		}
 	}
 
+	status = getWorkloadStatus(cr, status, deploy.Name)
+
 	if cr.Status.NotificationsController != status {
 		if !cr.Spec.Notifications.Enabled {
 			cr.Status.NotificationsController = ""
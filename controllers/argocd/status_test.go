@@ -95,6 +95,46 @@ func TestReconcileArgoCD_reconcileStatusKeycloak_OpenShift(t *testing.T) {
 	assert.Equal(t, "Running", a.Status.SSO)
 }
 
+func TestReconcileArgoCD_reconcileStatusKeycloak_Quarkus(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCDForKeycloakQuarkus()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	// the quarkus deployment is checked even when the template API is available
+	templateAPIFound = true
+	deploymentConfigAPIFound = true
+	defer removeTemplateAPI()
+
+	d := newKeycloakQuarkusDeployment(a)
+
+	// keycloak not installed
+	_ = r.reconcileStatusKeycloak(a)
+	assert.Equal(t, "Unknown", a.Status.SSO)
+
+	// keycloak installation started
+	assert.NoError(t, r.Client.Create(context.TODO(), d))
+
+	_ = r.reconcileStatusKeycloak(a)
+	assert.Equal(t, "Pending", a.Status.SSO)
+
+	// keycloak installation completed
+	d.Status.ReadyReplicas = *d.Spec.Replicas
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), d))
+
+	_ = r.reconcileStatusKeycloak(a)
+	assert.Equal(t, "Running", a.Status.SSO)
+	assert.True(t, isSSOReady(a))
+}
+
 func TestReconcileArgoCD_reconcileStatusSSO(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
@@ -332,9 +372,12 @@ func TestReconcileArgoCD_reconcileStatusApplicationSetController(t *testing.T) {
 
 	assert.NoError(t, r.reconcileStatusApplicationSetController(a))
 	assert.Equal(t, "Unknown", a.Status.ApplicationSetController)
+	assert.Empty(t, a.Status.ApplicationSetControllerReplicas)
 
-	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	replicas := int32(2)
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{Replicas: &replicas}
 	assert.NoError(t, r.reconcileApplicationSetController(a))
 	assert.NoError(t, r.reconcileStatusApplicationSetController(a))
 	assert.Equal(t, "Pending", a.Status.ApplicationSetController)
+	assert.Equal(t, "0/2", a.Status.ApplicationSetControllerReplicas)
 }
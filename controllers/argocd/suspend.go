@@ -0,0 +1,114 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+const (
+	// suspendReasonInstance is the reason of the Suspended condition when the whole instance is suspended.
+	suspendReasonInstance = "InstanceSuspended"
+
+	// suspendReasonComponents is the reason of the Suspended condition when some components are suspended.
+	suspendReasonComponents = "ComponentsSuspended"
+
+	// suspendReasonResumed is the reason of the Suspended condition once the instance is resumed.
+	suspendReasonResumed = "Resumed"
+)
+
+// isComponentSuspended returns true when the operator must not change the workload of the given component.
+func isComponentSuspended(cr *argoproj.ArgoCD, component argoproj.ArgoCDSuspendableComponent) bool {
+	if cr.Spec.Suspend {
+		return true
+	}
+	for _, suspended := range cr.Spec.SuspendedComponents {
+		if suspended == component {
+			return true
+		}
+	}
+	return false
+}
+
+// skipSuspendedComponent returns true and logs the skipped workload when the given component is suspended.
+func skipSuspendedComponent(cr *argoproj.ArgoCD, component argoproj.ArgoCDSuspendableComponent, workload string) bool {
+	if !isComponentSuspended(cr, component) {
+		return false
+	}
+	log.Info(fmt.Sprintf("component %s of ArgoCD %s in namespace %s is suspended, skipping reconciliation of %s", component, cr.Name, cr.Namespace, workload))
+	return true
+}
+
+// getSuspendedCondition will return the Suspended condition of the given ArgoCD, or nil when it was never suspended.
+func getSuspendedCondition(cr *argoproj.ArgoCD) *metav1.Condition {
+	condition := &metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeSuspended,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+	}
+
+	if cr.Spec.Suspend {
+		condition.Reason = suspendReasonInstance
+		condition.Message = "reconciliation of the instance is suspended"
+		return condition
+	}
+
+	if len(cr.Spec.SuspendedComponents) > 0 {
+		components := []string{}
+		for _, component := range cr.Spec.SuspendedComponents {
+			if !containsString(components, string(component)) {
+				components = append(components, string(component))
+			}
+		}
+		sort.Strings(components)
+		condition.Reason = suspendReasonComponents
+		condition.Message = fmt.Sprintf("reconciliation of the workloads of components %s is suspended", strings.Join(components, ", "))
+		return condition
+	}
+
+	if meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeSuspended) == nil {
+		return nil
+	}
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = suspendReasonResumed
+	condition.Message = "reconciliation is not suspended"
+	return condition
+}
+
+// reconcileStatusSuspended will ensure that the Suspended condition is updated for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusSuspended(cr *argoproj.ArgoCD) error {
+	condition := getSuspendedCondition(cr)
+	if condition == nil {
+		return nil
+	}
+
+	current := meta.FindStatusCondition(cr.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason &&
+		current.Message == condition.Message && current.ObservedGeneration == condition.ObservedGeneration {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("ArgoCD %s in namespace %s: %s", cr.Name, cr.Namespace, condition.Message))
+	meta.SetStatusCondition(&cr.Status.Conditions, *condition)
+	return r.Client.Status().Update(context.TODO(), cr)
+}
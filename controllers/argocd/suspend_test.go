@@ -0,0 +1,149 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestGetSuspendedCondition(t *testing.T) {
+	a := makeTestArgoCD()
+	assert.Nil(t, getSuspendedCondition(a))
+
+	a.Spec.SuspendedComponents = []argoproj.ArgoCDSuspendableComponent{
+		argoproj.ArgoCDSuspendableComponentServer,
+		argoproj.ArgoCDSuspendableComponentRepo,
+		argoproj.ArgoCDSuspendableComponentServer,
+	}
+	condition := getSuspendedCondition(a)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, suspendReasonComponents, condition.Reason)
+	assert.Equal(t, "reconciliation of the workloads of components repo, server is suspended", condition.Message)
+	assert.True(t, isComponentSuspended(a, argoproj.ArgoCDSuspendableComponentServer))
+	assert.False(t, isComponentSuspended(a, argoproj.ArgoCDSuspendableComponentRedis))
+
+	a.Spec.Suspend = true
+	condition = getSuspendedCondition(a)
+	assert.Equal(t, suspendReasonInstance, condition.Reason)
+	assert.True(t, isComponentSuspended(a, argoproj.ArgoCDSuspendableComponentRedis))
+
+	// the condition is only reported as resumed once it was set
+	meta.SetStatusCondition(&a.Status.Conditions, *condition)
+	a.Spec.Suspend = false
+	a.Spec.SuspendedComponents = nil
+	condition = getSuspendedCondition(a)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, suspendReasonResumed, condition.Reason)
+}
+
+func TestReconcileArgoCD_Reconcile_suspended(t *testing.T) {
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	// a manual change to the server deployment is kept while the instance is suspended
+	server := &appsv1.Deployment{}
+	serverKey := types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), serverKey, server))
+	hotfix := []string{"argocd-server", "--hotfix"}
+	server.Spec.Template.Spec.Containers[0].Command = hotfix
+	assert.NoError(t, r.Client.Update(context.TODO(), server))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	a.Spec.Suspend = true
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), serverKey, server))
+	assert.Equal(t, hotfix, server.Spec.Template.Spec.Containers[0].Command)
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeSuspended)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, suspendReasonInstance, condition.Reason)
+	}
+
+	// the change is also kept when only the server component is suspended
+	a.Spec.Suspend = false
+	a.Spec.SuspendedComponents = []argoproj.ArgoCDSuspendableComponent{argoproj.ArgoCDSuspendableComponentServer}
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), serverKey, server))
+	assert.Equal(t, hotfix, server.Spec.Template.Spec.Containers[0].Command)
+
+	// the change is reverted once resumed
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	a.Spec.SuspendedComponents = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), serverKey, server))
+	assert.NotEqual(t, hotfix, server.Spec.Template.Spec.Containers[0].Command)
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeSuspended)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, suspendReasonResumed, condition.Reason)
+	}
+}
+
+func TestReconcileArgoCD_triggerRollout_suspended(t *testing.T) {
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	a.Spec.SuspendedComponents = []argoproj.ArgoCDSuspendableComponent{argoproj.ArgoCDSuspendableComponentServer}
+
+	// the suspended server is not rolled out, the repo server is
+	server := newDeploymentWithSuffix("server", "server", a)
+	assert.NoError(t, r.triggerRollout(a, argoproj.ArgoCDSuspendableComponentServer, server, "repo.tls.cert.changed"))
+	repo := newDeploymentWithSuffix("repo-server", "repo-server", a)
+	assert.NoError(t, r.triggerRollout(a, argoproj.ArgoCDSuspendableComponentRepo, repo, "repo.tls.cert.changed"))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: server.Name, Namespace: a.Namespace}, server))
+	assert.NotContains(t, server.Spec.Template.Labels, "repo.tls.cert.changed")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: repo.Name, Namespace: a.Namespace}, repo))
+	assert.Contains(t, repo.Spec.Template.Labels, "repo.tls.cert.changed")
+}
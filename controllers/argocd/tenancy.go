@@ -0,0 +1,177 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// tenancyEventReasonRejected is the reason of the Events emitted for namespaces rejected by the tenancy options.
+	tenancyEventReasonRejected = "ManagedNamespaceRejected"
+)
+
+// isNamespaceAllowedToJoin returns true when the given namespace matches the tenancy namespace selector or is owned
+// by one of the tenancy owners of the given ArgoCD.
+func isNamespaceAllowedToJoin(cr *argoproj.ArgoCD, namespace corev1.Namespace) (bool, error) {
+	tenancy := cr.Spec.Tenancy
+	if tenancy == nil || (tenancy.NamespaceSelector == nil && len(tenancy.Owners) == 0) {
+		return true, nil
+	}
+
+	if tenancy.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(tenancy.NamespaceSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid .spec.tenancy.namespaceSelector: %w", err)
+		}
+		if selector.Matches(labels.Set(namespace.Labels)) {
+			return true, nil
+		}
+	}
+
+	if len(tenancy.Owners) > 0 {
+		if tenancy.OwnerAnnotation == "" {
+			return false, fmt.Errorf(".spec.tenancy.ownerAnnotation must be set when .spec.tenancy.owners is set")
+		}
+		if owner, ok := namespace.Annotations[tenancy.OwnerAnnotation]; ok && containsString(tenancy.Owners, owner) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// filterManagedNamespaces will split the namespaces labelled to be managed by the given ArgoCD into the namespaces
+// allowed by its tenancy options and the rejected ones, along with the reason of every rejection. The namespace of
+// the instance and terminating namespaces are always accepted and do not count towards the namespace limit.
+func filterManagedNamespaces(cr *argoproj.ArgoCD, namespaces []corev1.Namespace) ([]corev1.Namespace, map[string]string, error) {
+	accepted := []corev1.Namespace{}
+	rejected := map[string]string{}
+	candidates := []corev1.Namespace{}
+
+	for _, namespace := range namespaces {
+		if namespace.Name == cr.Namespace || namespace.DeletionTimestamp != nil {
+			accepted = append(accepted, namespace)
+			continue
+		}
+		allowed, err := isNamespaceAllowedToJoin(cr, namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !allowed {
+			rejected[namespace.Name] = "namespace is not allowed to join by .spec.tenancy"
+			continue
+		}
+		candidates = append(candidates, namespace)
+	}
+
+	// the oldest namespaces are kept when the limit is exceeded, so that joining namespaces cannot evict others
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if !candidates[i].CreationTimestamp.Equal(&candidates[j].CreationTimestamp) {
+			return candidates[i].CreationTimestamp.Before(&candidates[j].CreationTimestamp)
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	for i, namespace := range candidates {
+		if cr.Spec.Tenancy != nil && cr.Spec.Tenancy.MaxManagedNamespaces != nil && int32(i) >= *cr.Spec.Tenancy.MaxManagedNamespaces {
+			rejected[namespace.Name] = fmt.Sprintf("instance already manages the maximum of %d namespaces", *cr.Spec.Tenancy.MaxManagedNamespaces)
+			continue
+		}
+		accepted = append(accepted, namespace)
+	}
+	return accepted, rejected, nil
+}
+
+// deleteRBACsForRejectedNamespace will remove the Roles and RoleBindings created by the given ArgoCD in a namespace
+// that is no longer allowed to be managed by it. Namespaces hosting an Argo CD instance are left untouched.
+func (r *ReconcileArgoCD) deleteRBACsForRejectedNamespace(cr *argoproj.ArgoCD, namespace string) error {
+	instances := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), instances, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	if len(instances.Items) > 0 {
+		return nil
+	}
+
+	selector := client.MatchingLabels{
+		common.ArgoCDKeyPartOf:    common.ArgoCDAppName,
+		common.ArgoCDKeyManagedBy: cr.Name,
+	}
+	roles := &v1.RoleList{}
+	if err := r.Client.List(context.TODO(), roles, client.InNamespace(namespace), selector); err != nil {
+		return err
+	}
+	for i := range roles.Items {
+		if err := r.Client.Delete(context.TODO(), &roles.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	roleBindings := &v1.RoleBindingList{}
+	if err := r.Client.List(context.TODO(), roleBindings, client.InNamespace(namespace), selector); err != nil {
+		return err
+	}
+	for i := range roleBindings.Items {
+		if err := r.Client.Delete(context.TODO(), &roleBindings.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileStatusRejectedNamespaces will ensure that the rejected namespaces are reported in the status of the given
+// ArgoCD. An Event is emitted and the RBAC of the instance is removed for every newly rejected namespace.
+func (r *ReconcileArgoCD) reconcileStatusRejectedNamespaces(cr *argoproj.ArgoCD, rejected map[string]string) error {
+	names := []string{}
+	for name := range rejected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if containsString(cr.Status.RejectedNamespaces, name) {
+			continue
+		}
+		message := fmt.Sprintf("namespace %s requested to be managed by ArgoCD %s was rejected: %s", name, cr.Name, rejected[name])
+		log.Info(message)
+		if err := argoutil.CreateEvent(r.Client, corev1.EventTypeWarning, "Rejected", message, tenancyEventReasonRejected, cr.ObjectMeta, cr.TypeMeta); err != nil {
+			log.Error(err, fmt.Sprintf("failed to create event for rejected namespace %s", name))
+		}
+		if err := r.deleteRBACsForRejectedNamespace(cr, name); err != nil {
+			return fmt.Errorf("failed to remove RBAC of rejected namespace %s: %w", name, err)
+		}
+	}
+
+	if len(names) == 0 {
+		names = nil
+	}
+	if reflect.DeepEqual(cr.Status.RejectedNamespaces, names) {
+		return nil
+	}
+	cr.Status.RejectedNamespaces = names
+	return r.Client.Status().Update(context.TODO(), cr)
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const testOwnerAnnotation = "example.com/owner"
+
+func makeTestTenantNamespace(name string, age time.Duration, labels, annotations map[string]string) *corev1.Namespace {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Labels:            map[string]string{common.ArgoCDManagedByLabel: testNamespace},
+			Annotations:       annotations,
+			CreationTimestamp: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(-age)),
+		},
+	}
+	for k, v := range labels {
+		ns.Labels[k] = v
+	}
+	return ns
+}
+
+func TestFilterManagedNamespaces(t *testing.T) {
+	maxNamespaces := int32(2)
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Tenancy = &argoproj.ArgoCDTenancySpec{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"tenant": "team-a"},
+			},
+			Owners:               []string{"alice"},
+			OwnerAnnotation:      testOwnerAnnotation,
+			MaxManagedNamespaces: &maxNamespaces,
+		}
+	})
+
+	namespaces := []corev1.Namespace{
+		*makeTestTenantNamespace("selected-new", time.Hour, map[string]string{"tenant": "team-a"}, nil),
+		*makeTestTenantNamespace("owned", 2*time.Hour, nil, map[string]string{testOwnerAnnotation: "alice"}),
+		*makeTestTenantNamespace("selected-old", 3*time.Hour, map[string]string{"tenant": "team-a"}, nil),
+		*makeTestTenantNamespace("foreign", 4*time.Hour, nil, map[string]string{testOwnerAnnotation: "mallory"}),
+		*makeTestTenantNamespace(testNamespace, 0, nil, nil),
+	}
+
+	accepted, rejected, err := filterManagedNamespaces(a, namespaces)
+	assert.NoError(t, err)
+	names := []string{}
+	for _, ns := range accepted {
+		names = append(names, ns.Name)
+	}
+	assert.ElementsMatch(t, []string{testNamespace, "selected-old", "owned"}, names)
+	assert.Equal(t, map[string]string{
+		"foreign":      "namespace is not allowed to join by .spec.tenancy",
+		"selected-new": "instance already manages the maximum of 2 namespaces",
+	}, rejected)
+
+	// any labelled namespace may join without tenancy options
+	accepted, rejected, err = filterManagedNamespaces(makeTestArgoCD(), namespaces)
+	assert.NoError(t, err)
+	assert.Len(t, accepted, len(namespaces))
+	assert.Empty(t, rejected)
+
+	// the owner annotation is required with owners
+	a.Spec.Tenancy.OwnerAnnotation = ""
+	_, _, err = filterManagedNamespaces(a, namespaces)
+	assert.ErrorContains(t, err, ".spec.tenancy.ownerAnnotation must be set")
+}
+
+func TestNamespaceFilterPredicate_tenancyOwnerAnnotation(t *testing.T) {
+	oldNS := makeTestTenantNamespace("owned", time.Hour, nil, nil)
+	newNS := oldNS.DeepCopy()
+	newNS.Annotations = map[string]string{testOwnerAnnotation: "alice"}
+
+	assert.True(t, namespaceFilterPredicate().Update(event.UpdateEvent{ObjectOld: oldNS, ObjectNew: newNS}))
+}
+
+func TestSetManagedNamespaces_tenancy(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Tenancy = &argoproj.ArgoCDTenancySpec{
+			Owners:          []string{"alice"},
+			OwnerAnnotation: testOwnerAnnotation,
+		}
+	})
+	owned := makeTestTenantNamespace("owned", time.Hour, nil, map[string]string{testOwnerAnnotation: "alice"})
+	foreign := makeTestTenantNamespace("foreign", time.Hour, nil, nil)
+	foreignRole := newRole(common.ArgoCDServerComponent, nil, a)
+	foreignRole.Namespace = foreign.Name
+
+	resObjs := []client.Object{a, owned, foreign, foreignRole}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.setManagedNamespaces(a))
+
+	names := []string{}
+	for _, ns := range r.ManagedNamespaces.Items {
+		names = append(names, ns.Name)
+	}
+	assert.ElementsMatch(t, []string{"owned", testNamespace}, names)
+	assert.Equal(t, []string{"foreign"}, a.Status.RejectedNamespaces)
+
+	// the rejection is reported as an event and the RBAC of the instance is removed
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, tenancyEventReasonRejected, events.Items[0].Reason)
+	assert.Equal(t, corev1.EventTypeWarning, events.Items[0].Type)
+	assert.Contains(t, events.Items[0].Message, "namespace foreign")
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: foreignRole.Name, Namespace: foreign.Name}, &v1.Role{})
+	assert.Error(t, err)
+
+	// no further events are emitted for namespaces that are already rejected
+	assert.NoError(t, r.setManagedNamespaces(a))
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+	assert.Len(t, events.Items, 1)
+
+	// the rejected namespace joins once the owner is allowed
+	a.Spec.Tenancy.Owners = nil
+	assert.NoError(t, r.setManagedNamespaces(a))
+	assert.Len(t, r.ManagedNamespaces.Items, 3)
+	assert.Empty(t, a.Status.RejectedNamespaces)
+}
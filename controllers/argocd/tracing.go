@@ -0,0 +1,57 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// getTracingCommandArgs will return the OTLP command arguments of the Argo CD components for the given ArgoCD.
+func getTracingCommandArgs(cr *argoproj.ArgoCD) []string {
+	if !cr.Spec.Tracing.IsEnabled() {
+		return nil
+	}
+
+	// the insecure flag defaults to true in Argo CD, so it is always set explicitly
+	return []string{
+		"--otlp-address", cr.Spec.Tracing.Endpoint,
+		fmt.Sprintf("--otlp-insecure=%t", cr.Spec.Tracing.Insecure),
+	}
+}
+
+// getTracingEnv will return the environment of an Argo CD component for the tracing options of the given ArgoCD.
+// The headers are read by Argo CD from the given component specific environment variable. Argo CD v2.12 always samples
+// every trace and sets the TLS credentials of the exporter explicitly, so the standard OTEL_* sampler and certificate
+// environment variables are not set, as they would have no effect.
+func getTracingEnv(cr *argoproj.ArgoCD, headersEnvName string) []corev1.EnvVar {
+	if !cr.Spec.Tracing.IsEnabled() {
+		return nil
+	}
+
+	env := []corev1.EnvVar{}
+	if cr.Spec.Tracing.HeadersSecretRef != nil {
+		env = append(env, corev1.EnvVar{
+			Name: headersEnvName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: cr.Spec.Tracing.HeadersSecretRef,
+			},
+		})
+	}
+	return env
+}
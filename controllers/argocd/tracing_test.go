@@ -0,0 +1,115 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func makeTestArgoCDWithTracing() *argoproj.ArgoCD {
+	return makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Tracing = &argoproj.ArgoCDTracingSpec{
+			Enabled:  true,
+			Endpoint: "otel-collector.observability:4317",
+			HeadersSecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "otel-headers"},
+				Key:                  "headers",
+			},
+		}
+	})
+}
+
+func TestGetTracingCommandArgs(t *testing.T) {
+	a := makeTestArgoCDWithTracing()
+	want := []string{"--otlp-address", "otel-collector.observability:4317", "--otlp-insecure=false"}
+
+	assert.Equal(t, want, getTracingCommandArgs(a))
+	assert.Subset(t, getArgoServerCommand(a, false), want)
+	assert.Subset(t, getArgoRepoCommand(a, false), want)
+	assert.Subset(t, getArgoApplicationControllerCommand(a, false), want)
+
+	a.Spec.Tracing.Insecure = true
+	assert.Contains(t, getTracingCommandArgs(a), "--otlp-insecure=true")
+
+	a.Spec.Tracing.Enabled = false
+	assert.Empty(t, getTracingCommandArgs(a))
+	assert.NotContains(t, getArgoServerCommand(a, false), "--otlp-address")
+}
+
+func TestGetTracingEnv(t *testing.T) {
+	a := makeTestArgoCDWithTracing()
+
+	assert.Equal(t, []corev1.EnvVar{
+		{
+			Name:      "ARGOCD_SERVER_OTLP_HEADERS",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: a.Spec.Tracing.HeadersSecretRef},
+		},
+	}, getTracingEnv(a, "ARGOCD_SERVER_OTLP_HEADERS"))
+
+	a.Spec.Tracing.HeadersSecretRef = nil
+	assert.Empty(t, getTracingEnv(a, "ARGOCD_SERVER_OTLP_HEADERS"))
+
+	assert.Empty(t, getTracingEnv(makeTestArgoCD(), "ARGOCD_SERVER_OTLP_HEADERS"))
+}
+
+func TestReconcileArgoCD_reconcileDeployments_tracing(t *testing.T) {
+	a := makeTestArgoCDWithTracing()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	server := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, server))
+	repo := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, repo))
+	controller := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, controller))
+
+	for headersEnvName, spec := range map[string]corev1.PodSpec{
+		"ARGOCD_SERVER_OTLP_HEADERS":                 server.Spec.Template.Spec,
+		"ARGOCD_REPO_OTLP_HEADERS":                   repo.Spec.Template.Spec,
+		"ARGOCD_APPLICATION_CONTROLLER_OTLP_HEADERS": controller.Spec.Template.Spec,
+	} {
+		assert.Subset(t, spec.Containers[0].Command, getTracingCommandArgs(a), headersEnvName)
+		assert.Subset(t, spec.Containers[0].Env, getTracingEnv(a, headersEnvName), headersEnvName)
+	}
+
+	// tracing is removed from the server once disabled
+	a.Spec.Tracing.Enabled = false
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, server))
+	assert.NotContains(t, server.Spec.Template.Spec.Containers[0].Command, "--otlp-address")
+	for _, env := range server.Spec.Template.Spec.Containers[0].Env {
+		assert.NotEqual(t, "ARGOCD_SERVER_OTLP_HEADERS", env.Name)
+	}
+}
@@ -54,6 +54,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
@@ -174,6 +175,8 @@ func getArgoApplicationControllerCommand(cr *argoproj.ArgoCD, useTLSForRedis boo
 	cmd = append(cmd, "--logformat")
 	cmd = append(cmd, getLogFormat(cr.Spec.Controller.LogFormat))
 
+	cmd = append(cmd, getTracingCommandArgs(cr)...)
+
 	// check if extra args are present
 	extraArgs := cr.Spec.Controller.ExtraCommandArgs
 	err := isMergable(extraArgs, cmd)
@@ -200,10 +203,10 @@ func getArgoContainerImage(cr *argoproj.ArgoCD) string {
 		defaultTag = true
 	}
 	if e := os.Getenv(common.ArgoCDImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
+		return argoutil.GetImageReference(cr, e)
 	}
 
-	return argoutil.CombineImageTag(img, tag)
+	return argoutil.GetImageReference(cr, argoutil.CombineImageTag(img, tag))
 }
 
 // getRepoServerContainerImage will return the container image for the Repo server.
@@ -239,9 +242,9 @@ func getRepoServerContainerImage(cr *argoproj.ArgoCD) string {
 		}
 	}
 	if e := os.Getenv(common.ArgoCDImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
+		return argoutil.GetImageReference(cr, e)
 	}
-	return argoutil.CombineImageTag(img, tag)
+	return argoutil.GetImageReference(cr, argoutil.CombineImageTag(img, tag))
 }
 
 // getArgoRepoResources will return the ResourceRequirements for the Argo CD Repo server container.
@@ -423,9 +426,9 @@ func getRedisContainerImage(cr *argoproj.ArgoCD) string {
 		defaultTag = true
 	}
 	if e := os.Getenv(common.ArgoCDRedisImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
+		return argoutil.GetImageReference(cr, e)
 	}
-	return argoutil.CombineImageTag(img, tag)
+	return argoutil.GetImageReference(cr, argoutil.CombineImageTag(img, tag))
 }
 
 // getRedisHAContainerImage will return the container image for the Redis server in HA mode.
@@ -442,9 +445,9 @@ func getRedisHAContainerImage(cr *argoproj.ArgoCD) string {
 		defaultTag = true
 	}
 	if e := os.Getenv(common.ArgoCDRedisHAImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
+		return argoutil.GetImageReference(cr, e)
 	}
-	return argoutil.CombineImageTag(img, tag)
+	return argoutil.GetImageReference(cr, argoutil.CombineImageTag(img, tag))
 }
 
 // getRedisHAProxyAddress will return the Redis HA Proxy service address for the given ArgoCD.
@@ -468,10 +471,10 @@ func getRedisHAProxyContainerImage(cr *argoproj.ArgoCD) string {
 	}
 
 	if e := os.Getenv(common.ArgoCDRedisHAProxyImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
+		return argoutil.GetImageReference(cr, e)
 	}
 
-	return argoutil.CombineImageTag(img, tag)
+	return argoutil.GetImageReference(cr, argoutil.CombineImageTag(img, tag))
 }
 
 // getRedisInitScript will load the redis init script from a template on disk for the given ArgoCD.
@@ -667,6 +670,10 @@ func InspectCluster() error {
 	if err := verifyVersionAPI(); err != nil {
 		return err
 	}
+
+	if err := verifyGrafanaDashboardAPI(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -739,102 +746,115 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD) error {
 	// we reconcile SSO first so that we can catch and throw errors for any illegal SSO configurations right away, and return control from here
 	// preventing dex resources from getting created anyway through the other function calls, effectively bypassing the SSO checks
 	log.Info("reconciling SSO")
-	if err := r.reconcileSSO(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileSSO", func() error { return r.reconcileSSO(cr) }); err != nil {
 		log.Info(err.Error())
 	}
 
 	log.Info("reconciling status")
-	if err := r.reconcileStatus(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileStatus", func() error { return r.reconcileStatus(cr) }); err != nil {
+		log.Info(err.Error())
+	}
+
+	if err := reconcileStep(cr, "reconcileStatusSuspended", func() error { return r.reconcileStatusSuspended(cr) }); err != nil {
+		log.Info(err.Error())
+	}
+
+	log.Info("reconciling image digests")
+	if err := reconcileStep(cr, "reconcileImageDigests", func() error { return r.reconcileImageDigests(cr) }); err != nil {
 		log.Info(err.Error())
 	}
 
 	log.Info("reconciling roles")
-	if err := r.reconcileRoles(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileRoles", func() error { return r.reconcileRoles(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling rolebindings")
-	if err := r.reconcileRoleBindings(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileRoleBindings", func() error { return r.reconcileRoleBindings(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling service accounts")
-	if err := r.reconcileServiceAccounts(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileServiceAccounts", func() error { return r.reconcileServiceAccounts(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling certificate authority")
-	if err := r.reconcileCertificateAuthority(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileCertificateAuthority", func() error { return r.reconcileCertificateAuthority(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling secrets")
-	if err := r.reconcileSecrets(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileSecrets", func() error { return r.reconcileSecrets(cr) }); err != nil {
 		return err
 	}
 
 	useTLSForRedis := r.redisShouldUseTLS(cr)
 
 	log.Info("reconciling config maps")
-	if err := r.reconcileConfigMaps(cr, useTLSForRedis); err != nil {
+	if err := reconcileStep(cr, "reconcileConfigMaps", func() error { return r.reconcileConfigMaps(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling services")
-	if err := r.reconcileServices(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileServices", func() error { return r.reconcileServices(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling deployments")
-	if err := r.reconcileDeployments(cr, useTLSForRedis); err != nil {
+	if err := reconcileStep(cr, "reconcileDeployments", func() error { return r.reconcileDeployments(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling statefulsets")
-	if err := r.reconcileStatefulSets(cr, useTLSForRedis); err != nil {
+	if err := reconcileStep(cr, "reconcileStatefulSets", func() error { return r.reconcileStatefulSets(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling autoscalers")
-	if err := r.reconcileAutoscalers(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileAutoscalers", func() error { return r.reconcileAutoscalers(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling ingresses")
-	if err := r.reconcileIngresses(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileIngresses", func() error { return r.reconcileIngresses(cr) }); err != nil {
 		return err
 	}
 
 	if IsRouteAPIAvailable() {
 		log.Info("reconciling routes")
-		if err := r.reconcileRoutes(cr); err != nil {
+		if err := reconcileStep(cr, "reconcileRoutes", func() error { return r.reconcileRoutes(cr) }); err != nil {
 			return err
 		}
 	}
 
 	if IsPrometheusAPIAvailable() {
 		log.Info("reconciling prometheus")
-		if err := r.reconcilePrometheus(cr); err != nil {
+		if err := reconcileStep(cr, "reconcilePrometheus", func() error { return r.reconcilePrometheus(cr) }); err != nil {
 			return err
 		}
 
 		// Reconciles prometheusRule created to alert based on argo-cd workload status
-		if err := r.reconcilePrometheusRule(cr); err != nil {
+		if err := reconcileStep(cr, "reconcilePrometheusRule", func() error { return r.reconcilePrometheusRule(cr) }); err != nil {
 			return err
 		}
 
-		if err := r.reconcileMetricsServiceMonitor(cr); err != nil {
+		if err := reconcileStep(cr, "reconcileAlertCatalogue", func() error { return r.reconcileAlertCatalogue(cr) }); err != nil {
 			return err
 		}
 
-		if err := r.reconcileRepoServerServiceMonitor(cr); err != nil {
+		if err := reconcileStep(cr, "reconcileMetricsServiceMonitor", func() error { return r.reconcileMetricsServiceMonitor(cr) }); err != nil {
 			return err
 		}
 
-		if err := r.reconcileServerMetricsServiceMonitor(cr); err != nil {
+		if err := reconcileStep(cr, "reconcileRepoServerServiceMonitor", func() error { return r.reconcileRepoServerServiceMonitor(cr) }); err != nil {
+			return err
+		}
+
+		if err := reconcileStep(cr, "reconcileServerMetricsServiceMonitor", func() error { return r.reconcileServerMetricsServiceMonitor(cr) }); err != nil {
 			return err
 		}
 	}
@@ -842,27 +862,27 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD) error {
 	// check ManagedApplicationSetSourceNamespaces for proper cleanup
 	if cr.Spec.ApplicationSet != nil || len(r.ManagedApplicationSetSourceNamespaces) > 0 {
 		log.Info("reconciling ApplicationSet controller")
-		if err := r.reconcileApplicationSetController(cr); err != nil {
+		if err := reconcileStep(cr, "reconcileApplicationSetController", func() error { return r.reconcileApplicationSetController(cr) }); err != nil {
 			return err
 		}
 	}
 
 	if cr.Spec.Notifications.Enabled {
 		log.Info("reconciling Notifications controller")
-		if err := r.reconcileNotificationsController(cr); err != nil {
+		if err := reconcileStep(cr, "reconcileNotificationsController", func() error { return r.reconcileNotificationsController(cr) }); err != nil {
 			return err
 		}
 	}
 
-	if err := r.reconcileRepoServerTLSSecret(cr); err != nil {
+	if err := reconcileStep(cr, "reconcileRepoServerTLSSecret", func() error { return r.reconcileRepoServerTLSSecret(cr) }); err != nil {
 		return err
 	}
 
-	if err := r.reconcileRedisTLSSecret(cr, useTLSForRedis); err != nil {
+	if err := reconcileStep(cr, "reconcileRedisTLSSecret", func() error { return r.reconcileRedisTLSSecret(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
-	if err := r.ReconcileNetworkPolicies(cr); err != nil {
+	if err := reconcileStep(cr, "ReconcileNetworkPolicies", func() error { return r.ReconcileNetworkPolicies(cr) }); err != nil {
 		return err
 	}
 
@@ -968,7 +988,7 @@ func removeString(slice []string, s string) []string {
 }
 
 // setResourceWatches will register Watches for each of the supported Resources.
-func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, applicationSetGitlabSCMTLSConfigMapMapper handler.MapFunc) *builder.Builder {
+func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, configMapResourceMapper handler.MapFunc) *builder.Builder {
 
 	deploymentConfigPred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -1083,7 +1103,7 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 
 	clusterSecretResourceHandler := handler.EnqueueRequestsFromMapFunc(clusterSecretResourceMapper)
 
-	appSetGitlabSCMTLSConfigMapHandler := handler.EnqueueRequestsFromMapFunc(applicationSetGitlabSCMTLSConfigMapMapper)
+	configMapResourceHandler := handler.EnqueueRequestsFromMapFunc(configMapResourceMapper)
 
 	tlsSecretHandler := handler.EnqueueRequestsFromMapFunc(tlsSecretMapper)
 
@@ -1091,9 +1111,9 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 
 	bldr.Watches(&v1.ClusterRole{}, clusterResourceHandler)
 
-	bldr.Watches(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
-		Name: common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName,
-	}}, appSetGitlabSCMTLSConfigMapHandler)
+	// Watch for the ApplicationSet SCM TLS certificates, RBAC policy fragments and resource customizations consumed by
+	// argocd instances
+	bldr.Watches(&corev1.ConfigMap{}, configMapResourceHandler, builder.WithPredicates(configMapContentPredicate()))
 
 	// Watch for secrets of type TLS that might be created by external processes
 	bldr.Watches(&corev1.Secret{Type: corev1.SecretTypeTLS}, tlsSecretHandler)
@@ -1124,6 +1144,16 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 
 		// Watch Prometheus ServiceMonitor sub-resources owned by ArgoCD instances.
 		bldr.Owns(&monitoringv1.ServiceMonitor{})
+
+		// Watch Prometheus PrometheusRule sub-resources owned by ArgoCD instances.
+		bldr.Owns(&monitoringv1.PrometheusRule{})
+	}
+
+	if IsGrafanaDashboardAPIAvailable() {
+		// Watch GrafanaDashboard sub-resources owned by ArgoCD instances.
+		dashboard := &unstructured.Unstructured{}
+		dashboard.SetGroupVersionKind(grafanaDashboardGVK)
+		bldr.Owns(dashboard)
 	}
 
 	if CanUseKeycloakWithTemplate() {
@@ -1152,8 +1182,12 @@ func int64Ptr(val int64) *int64 {
 }
 
 // triggerRollout will trigger a rollout of a Kubernetes resource specified as
-// obj. It currently supports Deployment and StatefulSet resources.
-func (r *ReconcileArgoCD) triggerRollout(obj interface{}, key string) error {
+// obj. It currently supports Deployment and StatefulSet resources. The rollout
+// is skipped when the given component of the ArgoCD is suspended.
+func (r *ReconcileArgoCD) triggerRollout(cr *argoproj.ArgoCD, component argoproj.ArgoCDSuspendableComponent, obj interface{}, key string) error {
+	if skipSuspendedComponent(cr, component, fmt.Sprintf("rollout on %s", key)) {
+		return nil
+	}
 	switch res := obj.(type) {
 	case *appsv1.Deployment:
 		return r.triggerDeploymentRollout(res, key)
@@ -1211,6 +1245,26 @@ type DeprecationEventEmissionStatus struct {
 // This is temporary and can be removed in v0.0.6 when we remove the deprecated fields.
 var DeprecationEventEmissionTracker = make(map[string]DeprecationEventEmissionStatus)
 
+// configMapContentPredicate filters the update events of configmaps to the ones that change their labels or data. The
+// old and the new labels are both mapped, so that a configmap that is no longer selected is also handled.
+func configMapContentPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCM, ok := e.ObjectOld.(*corev1.ConfigMap)
+			if !ok {
+				return false
+			}
+			newCM, ok := e.ObjectNew.(*corev1.ConfigMap)
+			if !ok {
+				return false
+			}
+			return !reflect.DeepEqual(oldCM.Labels, newCM.Labels) ||
+				!reflect.DeepEqual(oldCM.Data, newCM.Data) ||
+				!reflect.DeepEqual(oldCM.BinaryData, newCM.BinaryData)
+		},
+	}
+}
+
 func namespaceFilterPredicate() predicate.Predicate {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -1219,6 +1273,8 @@ func namespaceFilterPredicate() predicate.Predicate {
 			// 2. if yes, check if the old and new values are different, if yes,
 			// first deleteRBACs for the old value & return true.
 			// Event is then handled by the reconciler, which would create appropriate RBACs.
+			// Any other change, such as to the owner annotation of .spec.tenancy, is also handled by the reconciler, so
+			// that the namespace is allowed to join or rejected again.
 			if valNew, ok := e.ObjectNew.GetLabels()[common.ArgoCDManagedByLabel]; ok {
 				if valOld, ok := e.ObjectOld.GetLabels()[common.ArgoCDManagedByLabel]; ok && valOld != valNew {
 					k8sClient, err := initK8sClient()
@@ -1261,7 +1317,8 @@ func namespaceFilterPredicate() predicate.Predicate {
 				}
 
 			}
-			return false
+			// Label changes may add or remove the namespace from the sourceNamespaceSelector of an instance.
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			if ns, ok := e.Object.GetLabels()[common.ArgoCDManagedByLabel]; ok && ns != "" {
@@ -1411,13 +1468,34 @@ func (r *ReconcileArgoCD) setManagedNamespaces(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
-	namespaces.Items = append(namespaces.Items, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cr.Namespace}})
+	accepted, rejected, err := filterManagedNamespaces(cr, namespaces.Items)
+	if err != nil {
+		return err
+	}
+	if err := r.reconcileStatusRejectedNamespaces(cr, rejected); err != nil {
+		return err
+	}
+
+	namespaces.Items = append(accepted, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cr.Namespace}})
 	r.ManagedNamespaces = namespaces
 	return nil
 }
 
+// isSelectedSourceNamespace returns true when a namespace with the given labels matches the sourceNamespaceSelector
+// of the given ArgoCD.
+func isSelectedSourceNamespace(cr *argoproj.ArgoCD, namespaceLabels map[string]string) (bool, error) {
+	if cr.Spec.SourceNamespaceSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(cr.Spec.SourceNamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid .spec.sourceNamespaceSelector: %w", err)
+	}
+	return selector.Matches(labels.Set(namespaceLabels)), nil
+}
+
 // getSourceNamespaces retrieves a list of namespaces that match the sourceNamespaces
-// pattern specified in the given ArgoCD
+// pattern or the sourceNamespaceSelector specified in the given ArgoCD
 func (r *ReconcileArgoCD) getSourceNamespaces(cr *argoproj.ArgoCD) ([]string, error) {
 	sourceNamespaces := []string{}
 	namespaces := &corev1.NamespaceList{}
@@ -1427,7 +1505,11 @@ func (r *ReconcileArgoCD) getSourceNamespaces(cr *argoproj.ArgoCD) ([]string, er
 	}
 
 	for _, namespace := range namespaces.Items {
-		if glob.MatchStringInList(cr.Spec.SourceNamespaces, namespace.Name, glob.GLOB) {
+		selected, err := isSelectedSourceNamespace(cr, namespace.Labels)
+		if err != nil {
+			return nil, err
+		}
+		if selected || glob.MatchStringInList(cr.Spec.SourceNamespaces, namespace.Name, glob.GLOB) {
 			sourceNamespaces = append(sourceNamespaces, namespace.Name)
 		}
 	}
@@ -1435,6 +1517,43 @@ func (r *ReconcileArgoCD) getSourceNamespaces(cr *argoproj.ArgoCD) ([]string, er
 	return sourceNamespaces, nil
 }
 
+// getSelectedSourceNamespaces retrieves a sorted list of namespaces that match the sourceNamespaceSelector but none
+// of the sourceNamespaces patterns specified in the given ArgoCD
+func (r *ReconcileArgoCD) getSelectedSourceNamespaces(cr *argoproj.ArgoCD) ([]string, error) {
+	if cr.Spec.SourceNamespaceSelector == nil {
+		return nil, nil
+	}
+
+	sourceNamespaces, err := r.getSourceNamespaces(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := []string{}
+	for _, namespace := range sourceNamespaces {
+		if !glob.MatchStringInList(cr.Spec.SourceNamespaces, namespace, glob.GLOB) {
+			selected = append(selected, namespace)
+		}
+	}
+	sort.Strings(selected)
+	return selected, nil
+}
+
+// appendSelectedSourceNamespaces will add the given namespaces selected by the sourceNamespaceSelector to the
+// --application-namespaces argument of the given command, as Argo CD only accepts namespace patterns.
+func appendSelectedSourceNamespaces(cmd []string, selected []string) []string {
+	if len(selected) == 0 {
+		return cmd
+	}
+	for i := range cmd {
+		if cmd[i] == "--application-namespaces" && i+1 < len(cmd) {
+			cmd[i+1] = fmt.Sprintf("%s,%s", cmd[i+1], strings.Join(selected, ","))
+			return cmd
+		}
+	}
+	return append(cmd, "--application-namespaces", strings.Join(selected, ","))
+}
+
 func (r *ReconcileArgoCD) setManagedSourceNamespaces(cr *argoproj.ArgoCD) error {
 	r.ManagedSourceNamespaces = make(map[string]string)
 	namespaces := &corev1.NamespaceList{}
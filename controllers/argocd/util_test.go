@@ -19,8 +19,11 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	testclient "k8s.io/client-go/kubernetes/fake"
 )
 
@@ -1094,3 +1097,97 @@ func TestReconcileArgoCD_reconcileDexOAuthClientSecret(t *testing.T) {
 	}
 	assert.True(t, tokenExists, "Dex is enabled but unable to create oauth client secret")
 }
+
+func TestGetSourceNamespacesWithSourceNamespaceSelector(t *testing.T) {
+	a := makeTestArgoCD()
+	a.Spec = argoproj.ArgoCDSpec{
+		SourceNamespaces: []string{
+			"test*",
+		},
+		SourceNamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"team": "a"},
+		},
+	}
+	ns1 := v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-namespace-1",
+			Labels: map[string]string{"team": "a"},
+		},
+	}
+	ns2 := v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "payments",
+			Labels: map[string]string{"team": "a"},
+		},
+	}
+	ns3 := v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "other-namespace",
+			Labels: map[string]string{"team": "b"},
+		},
+	}
+
+	resObjs := []client.Object{a, &ns1, &ns2, &ns3}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sourceNamespaces, err := r.getSourceNamespaces(a)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"test-namespace-1", "payments"}, sourceNamespaces)
+
+	// only the namespaces not covered by a pattern are added to the command
+	selected, err := r.getSelectedSourceNamespaces(a)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"payments"}, selected)
+	assert.Equal(t, []string{"argocd-server", "--application-namespaces", "test*,payments"},
+		appendSelectedSourceNamespaces([]string{"argocd-server", "--application-namespaces", "test*"}, selected))
+	assert.Equal(t, []string{"argocd-server", "--application-namespaces", "payments"},
+		appendSelectedSourceNamespaces([]string{"argocd-server"}, selected))
+
+	a.Spec.SourceNamespaceSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}}
+	_, err = r.getSourceNamespaces(a)
+	assert.Error(t, err)
+}
+
+func TestRemoveUnmanagedSourceNamespaceResources_sourceNamespaceSelector(t *testing.T) {
+	a := makeTestArgoCD()
+	a.Spec = argoproj.ArgoCDSpec{
+		SourceNamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"team": "a"},
+		},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.NoError(t, createNamespace(r, "payments", ""))
+	ns := &v1.Namespace{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "payments"}, ns))
+	ns.Labels = map[string]string{"team": "a"}
+	assert.NoError(t, r.Client.Update(context.TODO(), ns))
+
+	// the source namespace resources are created once the namespace is selected
+	assert.NoError(t, r.reconcileRoleForApplicationSourceNamespaces(common.ArgoCDServerComponent, policyRuleForServerApplicationSourceNamespaces(), a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "payments"}, ns))
+	assert.Equal(t, a.Namespace, ns.Labels[common.ArgoCDManagedByClusterArgoCDLabel])
+	roleName := getRoleNameForApplicationSourceNamespaces("payments", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: roleName, Namespace: "payments"}, &rbacv1.Role{}))
+
+	// and removed once the namespace loses the label
+	delete(ns.Labels, "team")
+	assert.NoError(t, r.Client.Update(context.TODO(), ns))
+	assert.NoError(t, r.setManagedSourceNamespaces(a))
+	assert.NoError(t, r.removeUnmanagedSourceNamespaceResources(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "payments"}, ns))
+	assert.NotContains(t, ns.Labels, common.ArgoCDManagedByClusterArgoCDLabel)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: roleName, Namespace: "payments"}, &rbacv1.Role{})
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoprojv1beta1 "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argocd"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
@@ -165,7 +166,7 @@ func newCronJob(cr *argoproj.ArgoCDExport) *batchv1.CronJob {
 	}
 }
 
-func newExportPodSpec(cr *argoproj.ArgoCDExport, argocdName string, client client.Client) corev1.PodSpec {
+func newExportPodSpec(cr *argoproj.ArgoCDExport, instance *argoprojv1beta1.ArgoCD, client client.Client) corev1.PodSpec {
 	pod := corev1.PodSpec{}
 
 	boolPtr := func(value bool) *bool {
@@ -175,7 +176,7 @@ func newExportPodSpec(cr *argoproj.ArgoCDExport, argocdName string, client clien
 	pod.Containers = []corev1.Container{{
 		Command:         getArgoExportCommand(cr),
 		Env:             getArgoExportContainerEnv(cr),
-		Image:           getArgoExportContainerImage(cr),
+		Image:           argoutil.MirrorImage(instance, getArgoExportContainerImage(cr)),
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "argocd-export",
 		SecurityContext: &corev1.SecurityContext{
@@ -194,7 +195,8 @@ func newExportPodSpec(cr *argoproj.ArgoCDExport, argocdName string, client clien
 	}}
 
 	pod.RestartPolicy = corev1.RestartPolicyOnFailure
-	pod.ServiceAccountName = fmt.Sprintf("%s-%s", argocdName, "argocd-application-controller")
+	pod.ServiceAccountName = fmt.Sprintf("%s-%s", instance.Name, "argocd-application-controller")
+	pod.ImagePullSecrets = instance.Spec.ImagePullSecrets
 	pod.Volumes = []corev1.Volume{
 		getArgoStorageVolume("backup-storage", cr),
 		getArgoSecretVolume("secret-storage", cr),
@@ -213,14 +215,14 @@ func newExportPodSpec(cr *argoproj.ArgoCDExport, argocdName string, client clien
 	return pod
 }
 
-func newPodTemplateSpec(cr *argoproj.ArgoCDExport, argocdName string, client client.Client) corev1.PodTemplateSpec {
+func newPodTemplateSpec(cr *argoproj.ArgoCDExport, instance *argoprojv1beta1.ArgoCD, client client.Client) corev1.PodTemplateSpec {
 	return corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Name,
 			Namespace: cr.Namespace,
 			Labels:    common.DefaultLabels(cr.Name),
 		},
-		Spec: newExportPodSpec(cr, argocdName, client),
+		Spec: newExportPodSpec(cr, instance, client),
 	}
 }
 
@@ -241,15 +243,15 @@ func (r *ReconcileArgoCDExport) reconcileCronJob(cr *argoproj.ArgoCDExport) erro
 
 	cj.Spec.Schedule = *cr.Spec.Schedule
 
-	// To create the job, we need the name of the argocd instance.  Although the argocd export cr contains a field with
-	// the argocd instance name, it's never used anywhere, and so there may be existing argocd export resources with the
-	// wrong name. To avoid these breaking, we look up the name of the argocd instance in the namespace of the export cr.
-	argocdName, err := r.argocdName(cr.Namespace)
+	// To create the job, we need the argocd instance.  Although the argocd export cr contains a field with the argocd
+	// instance name, it's never used anywhere, and so there may be existing argocd export resources with the wrong
+	// name. To avoid these breaking, we look up the argocd instance in the namespace of the export cr.
+	instance, err := r.argocdInstance(cr.Namespace)
 	if err != nil {
 		return err
 	}
 	job := newJob(cr)
-	job.Spec.Template = newPodTemplateSpec(cr, argocdName, r.Client)
+	job.Spec.Template = newPodTemplateSpec(cr, instance, r.Client)
 
 	cj.Spec.JobTemplate.Spec = job.Spec
 
@@ -275,14 +277,14 @@ func (r *ReconcileArgoCDExport) reconcileJob(cr *argoproj.ArgoCDExport) error {
 		return nil // Job not complete, move along...
 	}
 
-	// To create the job, we need the name of the argocd instance.  Although the argocd export cr contains a field with
-	// the argocd instance name, it's never used anywhere, and so there may be existing argocd export resources with the
-	// wrong name. To avoid these breaking, we look up the name of the argocd instance in the namespace of the export cr.
-	argocdName, err := r.argocdName(cr.Namespace)
+	// To create the job, we need the argocd instance.  Although the argocd export cr contains a field with the argocd
+	// instance name, it's never used anywhere, and so there may be existing argocd export resources with the wrong
+	// name. To avoid these breaking, we look up the argocd instance in the namespace of the export cr.
+	instance, err := r.argocdInstance(cr.Namespace)
 	if err != nil {
 		return err
 	}
-	job.Spec.Template = newPodTemplateSpec(cr, argocdName, r.Client)
+	job.Spec.Template = newPodTemplateSpec(cr, instance, r.Client)
 
 	if err := controllerutil.SetControllerReference(cr, job, r.Scheme); err != nil {
 		return err
@@ -290,14 +292,13 @@ func (r *ReconcileArgoCDExport) reconcileJob(cr *argoproj.ArgoCDExport) error {
 	return r.Client.Create(context.TODO(), job)
 }
 
-func (r *ReconcileArgoCDExport) argocdName(namespace string) (string, error) {
-	argocds := &argoproj.ArgoCDList{}
+func (r *ReconcileArgoCDExport) argocdInstance(namespace string) (*argoprojv1beta1.ArgoCD, error) {
+	argocds := &argoprojv1beta1.ArgoCDList{}
 	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: namespace}); err != nil {
-		return "", err
+		return nil, err
 	}
 	if len(argocds.Items) != 1 {
-		return "", fmt.Errorf("No Argo CD instance found in namespace %s", namespace)
+		return nil, fmt.Errorf("No Argo CD instance found in namespace %s", namespace)
 	}
-	argocd := argocds.Items[0]
-	return argocd.Name, nil
+	return &argocds.Items[0], nil
 }
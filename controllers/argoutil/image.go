@@ -0,0 +1,110 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argoutil
+
+import (
+	"strings"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+const (
+	// dockerHubRegistry is the registry of the images that do not name a registry.
+	dockerHubRegistry = "docker.io"
+
+	// defaultImageTag is the tag of the images that do not name a tag or digest.
+	defaultImageTag = "latest"
+)
+
+// SplitImageRegistry will return the registry of the given image reference and the rest of the reference. The
+// images that do not name a registry are Docker Hub images, whose official images are in the library/ repository.
+func SplitImageRegistry(image string) (string, string) {
+	if i := strings.Index(image, "/"); i > 0 {
+		first := image[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			return first, image[i+1:]
+		}
+		return dockerHubRegistry, image
+	}
+	return dockerHubRegistry, "library/" + image
+}
+
+// ParseImageReference will return the registry, the repository and the tag or digest of the given image reference.
+// The tag defaults to latest.
+func ParseImageReference(image string) (string, string, string) {
+	registry, path := SplitImageRegistry(image)
+	if i := strings.Index(path, "@"); i >= 0 {
+		return registry, path[:i], path[i+1:]
+	}
+	if i := strings.LastIndex(path, ":"); i > strings.LastIndex(path, "/") {
+		return registry, path[:i], path[i+1:]
+	}
+	return registry, path, defaultImageTag
+}
+
+// MirrorImage will return the given image reference with the registry options of the given ArgoCD applied. The
+// longest matching mirror source replaces the matching prefix, the registry of the image is replaced otherwise.
+func MirrorImage(cr *argoproj.ArgoCD, image string) string {
+	if cr.Spec.ImageRegistry == nil || image == "" {
+		return image
+	}
+
+	registry, path := SplitImageRegistry(image)
+	candidates := []string{image, registry + "/" + path}
+
+	match, source, mirror := "", "", ""
+	for _, m := range cr.Spec.ImageRegistry.Mirrors {
+		for _, candidate := range candidates {
+			if hasImagePrefix(candidate, m.Source) && len(m.Source) > len(source) {
+				match, source, mirror = candidate, m.Source, m.Mirror
+			}
+		}
+	}
+	if source != "" {
+		return strings.TrimSuffix(mirror, "/") + strings.TrimPrefix(match, strings.TrimSuffix(source, "/"))
+	}
+
+	if cr.Spec.ImageRegistry.Registry == "" {
+		return image
+	}
+	return strings.TrimSuffix(cr.Spec.ImageRegistry.Registry, "/") + "/" + path
+}
+
+// GetImageReference will return the image reference to use for the given image reference of the given ArgoCD. The
+// registry options are applied, and the image is pinned to the digest recorded in the status when the digests are
+// resolved.
+func GetImageReference(cr *argoproj.ArgoCD, image string) string {
+	image = MirrorImage(cr, image)
+	if cr.Spec.ImageRegistry == nil || !cr.Spec.ImageRegistry.ResolveDigests || strings.Contains(image, "@") {
+		return image
+	}
+	for _, resolved := range cr.Status.Images {
+		if resolved.Image == image && resolved.Digest != "" {
+			return image + "@" + resolved.Digest
+		}
+	}
+	return image
+}
+
+// hasImagePrefix returns true when the given image reference starts with the given prefix, on a path, tag or digest
+// boundary.
+func hasImagePrefix(image string, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" || !strings.HasPrefix(image, prefix) {
+		return false
+	}
+	rest := image[len(prefix):]
+	return rest == "" || strings.ContainsAny(rest[:1], "/:@")
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argoutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		image      string
+		registry   string
+		repository string
+		reference  string
+	}{
+		{"redis", "docker.io", "library/redis", "latest"},
+		{"redis:7.0.15-alpine", "docker.io", "library/redis", "7.0.15-alpine"},
+		{"bitnami/redis:7", "docker.io", "bitnami/redis", "7"},
+		{"quay.io/argoproj/argocd:v2.11.0", "quay.io", "argoproj/argocd", "v2.11.0"},
+		{"localhost:5000/argocd", "localhost:5000", "argocd", "latest"},
+		{"ghcr.io/dexidp/dex@sha256:abc", "ghcr.io", "dexidp/dex", "sha256:abc"},
+	}
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			registry, repository, reference := ParseImageReference(test.image)
+			assert.Equal(t, test.registry, registry)
+			assert.Equal(t, test.repository, repository)
+			assert.Equal(t, test.reference, reference)
+		})
+	}
+}
+
+func TestMirrorImage(t *testing.T) {
+	cr := &argoproj.ArgoCD{}
+	assert.Equal(t, "quay.io/argoproj/argocd:v2.11.0", MirrorImage(cr, "quay.io/argoproj/argocd:v2.11.0"))
+
+	cr.Spec.ImageRegistry = &argoproj.ArgoCDImageRegistrySpec{
+		Registry: "registry.example.com/mirror/",
+		Mirrors: []argoproj.ArgoCDImageMirror{
+			{Source: "quay.io", Mirror: "quay.example.com"},
+			{Source: "quay.io/argoproj", Mirror: "argoproj.example.com"},
+			{Source: "docker.io/library/redis", Mirror: "redis.example.com/redis"},
+		},
+	}
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{"quay.io/argoproj/argocd:v2.11.0", "argoproj.example.com/argocd:v2.11.0"},
+		{"quay.io/argoproj-labs/argocd-operator-util@sha256:abc", "quay.example.com/argoproj-labs/argocd-operator-util@sha256:abc"},
+		{"redis:7.0.15-alpine", "redis.example.com/redis:7.0.15-alpine"},
+		{"redis-exporter:1", "registry.example.com/mirror/library/redis-exporter:1"},
+		{"ghcr.io/dexidp/dex:v2.38.0", "registry.example.com/mirror/dexidp/dex:v2.38.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			assert.Equal(t, test.want, MirrorImage(cr, test.image))
+		})
+	}
+}
+
+func TestGetImageReference(t *testing.T) {
+	cr := &argoproj.ArgoCD{}
+	cr.Spec.ImageRegistry = &argoproj.ArgoCDImageRegistrySpec{Registry: "registry.example.com"}
+	cr.Status.Images = []argoproj.ArgoCDImageStatus{
+		{Image: "registry.example.com/argoproj/argocd:v2.11.0", Digest: "sha256:abc"},
+	}
+
+	// the digests are only used when they are resolved
+	assert.Equal(t, "registry.example.com/argoproj/argocd:v2.11.0", GetImageReference(cr, "quay.io/argoproj/argocd:v2.11.0"))
+
+	cr.Spec.ImageRegistry.ResolveDigests = true
+	assert.Equal(t, "registry.example.com/argoproj/argocd:v2.11.0@sha256:abc", GetImageReference(cr, "quay.io/argoproj/argocd:v2.11.0"))
+	assert.Equal(t, "registry.example.com/argoproj/argocd:v2.12.0", GetImageReference(cr, "quay.io/argoproj/argocd:v2.12.0"))
+	assert.Equal(t, "registry.example.com/argoproj/argocd@sha256:def", GetImageReference(cr, "quay.io/argoproj/argocd@sha256:def"))
+}